@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"webook/config"
+	"webook/internal/application"
+)
+
+const commandUsage = `usage: webook [command]
+
+不带子命令时启动 Web 服务和后台 Worker。
+
+commands:
+  dlq list   [-limit N]   查看统计事件死信队列中的消息（不会移除）
+  dlq replay [-limit N]   将死信消息重新投递到统计队列
+  stats reconcile [-from N] [-to N] [-batch N] [-repair]
+                          按帖子ID区间从点赞/收藏关系表重算计数并与 post_stats、Redis 对比
+`
+
+// runCommand 执行运维子命令，返回进程退出码
+func runCommand(cfg *config.Config, args []string) int {
+	switch args[0] {
+	case "dlq":
+		return runDLQCommand(cfg, args[1:])
+	case "stats":
+		return runStatsCommand(cfg, args[1:])
+	case "help", "-h", "--help":
+		fmt.Print(commandUsage)
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", args[0], commandUsage)
+		return 2
+	}
+}
+
+func runDLQCommand(cfg *config.Config, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, commandUsage)
+		return 2
+	}
+	fs := flag.NewFlagSet("dlq "+args[0], flag.ContinueOnError)
+	limit := fs.Int("limit", 20, "max number of messages")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	ctx := context.Background()
+	dlq := InitPostStatsDLQ(cfg)
+	switch args[0] {
+	case "list":
+		letters, err := dlq.Peek(ctx, *limit)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "peek dlq failed:", err)
+			return 1
+		}
+		enc := json.NewEncoder(os.Stdout)
+		for _, l := range letters {
+			_ = enc.Encode(l)
+		}
+		fmt.Fprintf(os.Stderr, "%d message(s)\n", len(letters))
+	case "replay":
+		n, err := dlq.Replay(ctx, *limit)
+		fmt.Fprintf(os.Stderr, "replayed %d message(s)\n", n)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "replay dlq failed:", err)
+			return 1
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "unknown dlq command %q\n\n%s", args[0], commandUsage)
+		return 2
+	}
+	return 0
+}
+
+func runStatsCommand(cfg *config.Config, args []string) int {
+	if len(args) == 0 || args[0] != "reconcile" {
+		fmt.Fprint(os.Stderr, commandUsage)
+		return 2
+	}
+	fs := flag.NewFlagSet("stats reconcile", flag.ContinueOnError)
+	from := fs.Int64("from", 0, "first post id (inclusive)")
+	to := fs.Int64("to", 0, "last post id (exclusive), 0 means all")
+	batch := fs.Int64("batch", 500, "post ids per batch")
+	repair := fs.Bool("repair", false, "overwrite mismatched counters in MySQL and Redis")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	report, err := InitPostStatsReconciler(cfg).Reconcile(context.Background(), application.PostStatsReconcileOptions{
+		FromId:    *from,
+		ToId:      *to,
+		BatchSize: *batch,
+		Repair:    *repair,
+	})
+	enc := json.NewEncoder(os.Stdout)
+	for _, m := range report.Mismatches {
+		_ = enc.Encode(m)
+	}
+	fmt.Fprintf(os.Stderr, "post id [%d, %d): scanned %d, mismatches %d, repaired %d\n",
+		report.FromId, report.ToId, report.Scanned, report.MismatchCnt, report.RepairedCnt)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "reconcile failed:", err)
+		return 1
+	}
+	return 0
+}
@@ -2,17 +2,27 @@ package main
 
 import (
 	"context"
+	"os"
 	"webook/config"
+	"webook/internal/ioc"
 )
 
 func main() {
 	// 加载配置获取端口
 	cfg := config.Load()
 
+	// 运维子命令，例如 webook dlq list
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(cfg, os.Args[1:]))
+	}
+
+	// 全文索引在进程内共享：Web 服务发布时增量更新，Worker 负责定时全量重建
+	searchIndex := ioc.NewSearchService()
+
 	// 使用 Wire 生成的依赖注入代码初始化 Web 服务器
-	server := InitWebServer(cfg)
+	server := InitWebServer(cfg, searchIndex)
 
-	statsWorker := InitPostStatsWorker(cfg)
+	statsWorker := InitPostStatsWorker(cfg, searchIndex)
 	statsWorker.Start(context.Background())
 
 	// 启动服务器
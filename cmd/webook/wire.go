@@ -6,25 +6,30 @@ import (
 	"time"
 	"webook/config"
 	web "webook/internal/adapters/inbound/http"
+	"webook/internal/adapters/inbound/http/middleware"
 	mq "webook/internal/adapters/outbound/mq"
 	dao "webook/internal/adapters/outbound/persistence/mysql"
 	cache "webook/internal/adapters/outbound/persistence/redis"
 	"webook/internal/adapters/outbound/repository"
 	"webook/internal/application"
 	"webook/internal/ioc"
+	output "webook/internal/ports/output"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
 )
 
-func InitWebServer(cfg *config.Config) *gin.Engine {
+func InitWebServer(cfg *config.Config, searchIndex output.SearchService) *gin.Engine {
 	wire.Build(
 		ioc.NewDB,
 		ioc.NewRedis,
+		ioc.NewStreamBroker,
 		ioc.NewLogger,
+		ioc.NewMailer,
 		ioc.NewJWTService,
 		ioc.NewTokenService,
 		ioc.NewAccessTokenVerifier,
+		ioc.NewPublicKeyProvider,
 		ioc.NewRabbitMQConn,
 		ioc.NewRabbitMQProducerChannel,
 		ioc.NewPostStatsPublisher,
@@ -32,53 +37,166 @@ func InitWebServer(cfg *config.Config) *gin.Engine {
 		dao.NewUserDAO,
 		dao.NewPostDAO,
 		dao.NewPublishedPostDAO,
+		dao.NewPostRevisionDAO,
+		dao.NewTagDAO,
+		dao.NewCommentDAO,
 		dao.NewPostStatsDAO,
 		dao.NewPostLikeDAO,
 		dao.NewPostCollectDAO,
+		dao.NewPostDailyStatsDAO,
+		dao.NewPostReaderDAO,
+		dao.NewCollectionFolderDAO,
+		dao.NewReadHistoryDAO,
+		dao.NewFollowDAO,
+		dao.NewNotificationDAO,
+		dao.NewLoginAuditDAO,
+		dao.NewSessionDAO,
+		dao.NewPersonalAccessTokenDAO,
 
 		ProvideUserCacheExpiration,
 		cache.NewUserCache,
 		cache.NewTokenBlacklist,
+		cache.NewAccountTokenStore,
+		cache.NewLoginAttemptCache,
 		cache.NewPostCache,
 		cache.NewPostStatsCache,
+		cache.NewPostRankCache,
+		ProvideFeedInboxSize,
+		cache.NewFeedCache,
 
 		repository.NewUserRepository,
 		repository.NewCachedUserRepository,
 		repository.NewPostRepository,
+		repository.NewIndexedPostRepository,
+		repository.NewRankedPostRepository,
+		repository.NewCacheEvictingPostRepository,
 		repository.NewPublishedPostRepository,
 		repository.NewCachedPublishedPostRepository,
+		repository.NewPostRevisionRepository,
+		repository.NewCommentRepository,
+		repository.NewTagRepository,
 		repository.NewPostStatsRepository,
 		repository.NewPostLikeRepository,
 		repository.NewPostCollectRepository,
+		repository.NewPostDailyStatsRepository,
+		repository.NewPostReaderRepository,
+		repository.NewCollectionFolderRepository,
+		repository.NewReadHistoryRepository,
+		repository.NewFollowRepository,
+		repository.NewNotificationRepository,
+		repository.NewLoginAuditRepository,
+		repository.NewSessionRepository,
+		repository.NewPersonalAccessTokenRepository,
 
+		ProvideLoginGuardOptions,
 		application.NewUserService,
 		application.NewPostService,
+		application.NewPostRevisionService,
+		application.NewTagService,
+		application.NewPostSearchService,
+		application.NewCommentService,
 		application.NewPostInteractionService,
+		application.NewPostRankService,
+		application.NewPostAnalyticsService,
+		application.NewCollectionService,
+		application.NewReadHistoryService,
+		ProvideFeedOptions,
+		application.NewFeedService,
+		application.NewFollowService,
+		application.NewNotificationService,
+		cache.NewStreamTicketStore,
+		application.NewStreamService,
 		ProvideAccessExpireTime,
 		ProvideRefreshExpireTime,
 		application.NewAuthService,
+		ProvideAccountOptions,
+		application.NewAccountService,
+		application.NewPersonalAccessTokenService,
+		ProvideAdminUserIds,
+		application.NewAuthorizationService,
+		application.NewAdminService,
 
 		web.NewUserHandler,
 		web.NewPostHandler,
+		web.NewPostRevisionHandler,
+		web.NewTagHandler,
+		web.NewPostSearchHandler,
+		web.NewCommentHandler,
+		web.NewPostRankHandler,
+		web.NewPostAnalyticsHandler,
+		web.NewCollectionHandler,
+		web.NewReadHistoryHandler,
+		web.NewFollowHandler,
+		web.NewFeedHandler,
+		web.NewNotificationHandler,
+		web.NewStreamHandler,
+		middleware.NewPolicyMiddlewareBuilder,
+		web.NewAdminHandler,
+		web.NewJWKSHandler,
+		web.NewPersonalAccessTokenHandler,
 		ioc.NewGinEngine,
 	)
 	return nil
 }
 
-func InitPostStatsWorker(cfg *config.Config) *application.PostStatsWorker {
+func InitPostStatsWorker(cfg *config.Config, searchIndex output.SearchService) *application.PostStatsWorker {
 	wire.Build(
 		ioc.NewDB,
 		ioc.NewRedis,
+		ioc.NewStreamBroker,
 		ioc.NewLogger,
 		ioc.NewRabbitMQConn,
 		ioc.NewRabbitMQConsumerChannel,
+		ioc.NewRabbitMQProducerChannel,
 		ioc.NewPostStatsConsumer,
+		ioc.NewPostStatsPublisher,
 
 		dao.NewPostStatsDAO,
+		dao.NewPostLikeDAO,
+		dao.NewPostCollectDAO,
+		dao.NewPostDAO,
+		dao.NewPublishedPostDAO,
+		dao.NewTagDAO,
+		dao.NewPostStatsOutboxDAO,
+		dao.NewPostDailyStatsDAO,
+		dao.NewPostReaderDAO,
+		dao.NewFollowDAO,
+		dao.NewNotificationDAO,
 		cache.NewPostStatsCache,
+		cache.NewPostCache,
+		cache.NewPostRankCache,
+		ProvideFeedInboxSize,
+		cache.NewFeedCache,
 		repository.NewPostStatsRepository,
+		repository.NewPostLikeRepository,
+		repository.NewPostCollectRepository,
+		repository.NewPostRepository,
+		repository.NewIndexedPostRepository,
+		repository.NewCacheEvictingPostRepository,
+		repository.NewPublishedPostRepository,
+		repository.NewPostStatsOutboxRepository,
+		repository.NewPostDailyStatsRepository,
+		repository.NewPostReaderRepository,
+		repository.NewFollowRepository,
+		repository.NewNotificationRepository,
 
+		ProvideFeedOptions,
+		application.NewFeedService,
+		application.NewNotificationService,
+		cache.NewStreamTicketStore,
+		application.NewStreamService,
+		ProvideSearchRebuildInterval,
 		application.NewPostStatsFlusher,
+		application.NewPostPublishScheduler,
+		application.NewPostSearchIndexer,
+		application.NewPostStatsOutboxRelay,
+		application.NewPostStatsReconciler,
+		ProvideStatsReconcileSchedule,
+		application.NewPostHotRanker,
+		ProvideHotRankSchedule,
+		application.NewPostReaderSnapshotter,
+		ProvideReaderSnapshotInterval,
+		application.NewNotificationEventCleaner,
 		application.NewPostStatsWorker,
 
 		wire.Bind(new(application.RabbitMQStatsConsumerWrapper), new(*mq.RabbitMQStatsConsumer)),
@@ -90,6 +208,33 @@ func ProvideUserCacheExpiration(cfg *config.Config) cache.UserCacheExpiration {
 	return cache.UserCacheExpiration(cfg.Cache.UserExpiration)
 }
 
+func InitPostStatsReconciler(cfg *config.Config) *application.PostStatsReconciler {
+	wire.Build(
+		ioc.NewDB,
+		ioc.NewRedis,
+		ioc.NewLogger,
+		dao.NewPostStatsDAO,
+		dao.NewPostLikeDAO,
+		dao.NewPostCollectDAO,
+		cache.NewPostStatsCache,
+		repository.NewPostStatsRepository,
+		repository.NewPostLikeRepository,
+		repository.NewPostCollectRepository,
+		ProvideStatsReconcileSchedule,
+		application.NewPostStatsReconciler,
+	)
+	return nil
+}
+
+func InitPostStatsDLQ(cfg *config.Config) output.PostStatsDeadLetterQueue {
+	wire.Build(
+		ioc.NewRabbitMQConn,
+		ioc.NewRabbitMQProducerChannel,
+		ioc.NewPostStatsDLQ,
+	)
+	return nil
+}
+
 func ProvideAccessExpireTime(cfg *config.Config) time.Duration {
 	return cfg.JWT.ExpireTime
 }
@@ -97,3 +242,61 @@ func ProvideAccessExpireTime(cfg *config.Config) time.Duration {
 func ProvideRefreshExpireTime(cfg *config.Config) time.Duration {
 	return cfg.JWT.RefreshExpireTime
 }
+
+func ProvideAccountOptions(cfg *config.Config) application.AccountOptions {
+	return application.AccountOptions{
+		LinkBaseURL:    cfg.Account.LinkBaseURL,
+		VerifyTokenTTL: cfg.Account.VerifyTokenTTL,
+		ResetTokenTTL:  cfg.Account.ResetTokenTTL,
+		MailInterval:   cfg.Account.MailInterval,
+		SessionTTL:     cfg.JWT.RefreshExpireTime,
+	}
+}
+
+func ProvideLoginGuardOptions(cfg *config.Config) application.LoginGuardOptions {
+	return application.LoginGuardOptions{
+		MaxEmailFailures: int64(cfg.Login.MaxEmailFailures),
+		MaxIPFailures:    int64(cfg.Login.MaxIPFailures),
+		BaseLockout:      cfg.Login.BaseLockout,
+		MaxLockout:       cfg.Login.MaxLockout,
+		FailureWindow:    cfg.Login.FailureWindow,
+	}
+}
+
+func ProvideAdminUserIds(cfg *config.Config) application.AdminUserIds {
+	return application.AdminUserIds(cfg.Admin.UserIds)
+}
+
+func ProvideSearchRebuildInterval(cfg *config.Config) time.Duration {
+	return cfg.Search.RebuildInterval
+}
+
+func ProvideStatsReconcileSchedule(cfg *config.Config) application.PostStatsReconcileSchedule {
+	return application.PostStatsReconcileSchedule{
+		Interval: cfg.Stats.ReconcileInterval,
+		Repair:   cfg.Stats.ReconcileRepair,
+	}
+}
+
+func ProvideHotRankSchedule(cfg *config.Config) application.PostHotRankSchedule {
+	return application.PostHotRankSchedule{
+		RefreshInterval: cfg.Stats.HotRefreshInterval,
+		RebuildInterval: cfg.Stats.HotRebuildInterval,
+		TopN:            cfg.Stats.HotTopN,
+	}
+}
+
+func ProvideReaderSnapshotInterval(cfg *config.Config) application.PostReaderSnapshotInterval {
+	return application.PostReaderSnapshotInterval(cfg.Stats.ReaderSnapshotInterval)
+}
+
+func ProvideFeedInboxSize(cfg *config.Config) cache.FeedInboxSize {
+	return cache.FeedInboxSize(cfg.Feed.InboxSize)
+}
+
+func ProvideFeedOptions(cfg *config.Config) application.FeedOptions {
+	return application.FeedOptions{
+		PushThreshold: int64(cfg.Feed.PushThreshold),
+		BackfillSize:  cfg.Feed.BackfillSize,
+	}
+}
@@ -11,59 +11,133 @@ import (
 
 	"webook/config"
 	web "webook/internal/adapters/inbound/http"
+	"webook/internal/adapters/inbound/http/middleware"
 	dao "webook/internal/adapters/outbound/persistence/mysql"
 	cache "webook/internal/adapters/outbound/persistence/redis"
 	"webook/internal/adapters/outbound/repository"
 	"webook/internal/application"
 	"webook/internal/ioc"
+	output "webook/internal/ports/output"
 
 	"github.com/gin-gonic/gin"
 )
 
 // InitWebServer initializes the web server.
-func InitWebServer(cfg *config.Config) *gin.Engine {
+func InitWebServer(cfg *config.Config, searchIndex output.SearchService) *gin.Engine {
 	db := ioc.NewDB(cfg)
 	userDAO := dao.NewUserDAO(db)
 	postDAO := dao.NewPostDAO(db)
 	publishedPostDAO := dao.NewPublishedPostDAO(db)
+	postRevisionDAO := dao.NewPostRevisionDAO(db)
+	tagDAO := dao.NewTagDAO(db)
+	commentDAO := dao.NewCommentDAO(db)
 	postStatsDAO := dao.NewPostStatsDAO(db)
 	postLikeDAO := dao.NewPostLikeDAO(db)
 	postCollectDAO := dao.NewPostCollectDAO(db)
+	postDailyStatsDAO := dao.NewPostDailyStatsDAO(db)
 	cmdable := ioc.NewRedis(cfg)
 	userCacheExpiration := ProvideUserCacheExpiration(cfg)
 	userCache := cache.NewUserCache(cmdable, userCacheExpiration)
 	tokenBlacklist := cache.NewTokenBlacklist(cmdable)
 	postCache := cache.NewPostCache(cmdable)
 	postStatsCache := cache.NewPostStatsCache(cmdable)
+	postRankCache := cache.NewPostRankCache(cmdable)
 	userRepository := repository.NewUserRepository(userDAO)
 	cachedUserRepository := repository.NewCachedUserRepository(userRepository, userCache)
-	postRepository := repository.NewPostRepository(postDAO)
-	publishedPostRepository := repository.NewPublishedPostRepository(publishedPostDAO)
+	postRepository := repository.NewPostRepository(postDAO, tagDAO)
+	indexedPostRepository := repository.NewIndexedPostRepository(postRepository, searchIndex)
+	rankedPostRepository := repository.NewRankedPostRepository(indexedPostRepository, postRankCache)
+	cacheEvictingPostRepository := repository.NewCacheEvictingPostRepository(rankedPostRepository, postCache)
+	publishedPostRepository := repository.NewPublishedPostRepository(publishedPostDAO, tagDAO)
 	cachedPublishedPostRepository := repository.NewCachedPublishedPostRepository(publishedPostRepository, postCache)
+	postRevisionRepository := repository.NewPostRevisionRepository(postRevisionDAO)
+	tagRepository := repository.NewTagRepository(tagDAO)
+	commentRepository := repository.NewCommentRepository(commentDAO)
 	postStatsRepository := repository.NewPostStatsRepository(postStatsDAO)
 	postLikeRepository := repository.NewPostLikeRepository(postLikeDAO)
 	postCollectRepository := repository.NewPostCollectRepository(postCollectDAO)
+	postDailyStatsRepository := repository.NewPostDailyStatsRepository(postDailyStatsDAO)
+	postReaderDAO := dao.NewPostReaderDAO(db)
+	postReaderRepository := repository.NewPostReaderRepository(postReaderDAO)
+	collectionFolderDAO := dao.NewCollectionFolderDAO(db)
+	collectionFolderRepository := repository.NewCollectionFolderRepository(collectionFolderDAO)
+	readHistoryDAO := dao.NewReadHistoryDAO(db)
+	readHistoryRepository := repository.NewReadHistoryRepository(readHistoryDAO)
+	followDAO := dao.NewFollowDAO(db)
+	followRepository := repository.NewFollowRepository(followDAO)
+	feedInboxSize := ProvideFeedInboxSize(cfg)
+	feedCache := cache.NewFeedCache(cmdable, feedInboxSize)
+	notificationDAO := dao.NewNotificationDAO(db)
+	notificationRepository := repository.NewNotificationRepository(notificationDAO)
 	rabbitMQConn := ioc.NewRabbitMQConn(cfg)
 	rabbitMQProducerChannel := ioc.NewRabbitMQProducerChannel(rabbitMQConn)
 	postStatsPublisher := ioc.NewPostStatsPublisher(rabbitMQProducerChannel, cfg)
-	userService := application.NewUserService(cachedUserRepository)
-	postService := application.NewPostService(postRepository, cachedPublishedPostRepository)
-	postInteractionService := application.NewPostInteractionService(postLikeRepository, postCollectRepository, postStatsRepository, postStatsCache, postStatsPublisher)
+	loginAttemptCache := cache.NewLoginAttemptCache(cmdable)
+	loginAuditDAO := dao.NewLoginAuditDAO(db)
+	loginAuditRepository := repository.NewLoginAuditRepository(loginAuditDAO)
+	loginGuardOptions := ProvideLoginGuardOptions(cfg)
+	userService := application.NewUserService(cachedUserRepository, cachedPublishedPostRepository, loginAttemptCache, loginAuditRepository, loginGuardOptions)
+	postService := application.NewPostService(cacheEvictingPostRepository, cachedPublishedPostRepository, cachedUserRepository)
+	postRevisionService := application.NewPostRevisionService(cacheEvictingPostRepository, postRevisionRepository)
+	tagService := application.NewTagService(tagRepository)
+	postInteractionService := application.NewPostInteractionService(postLikeRepository, postCollectRepository, postStatsRepository, postStatsCache, postReaderRepository, readHistoryRepository, postStatsPublisher)
+	postSearchService := application.NewPostSearchService(searchIndex, postInteractionService)
+	commentService := application.NewCommentService(commentRepository, cacheEvictingPostRepository, cachedPublishedPostRepository)
+	postRankService := application.NewPostRankService(postRankCache, cachedPublishedPostRepository)
+	postAnalyticsService := application.NewPostAnalyticsService(cacheEvictingPostRepository, postDailyStatsRepository)
+	collectionService := application.NewCollectionService(collectionFolderRepository, postCollectRepository, cachedPublishedPostRepository)
+	readHistoryService := application.NewReadHistoryService(readHistoryRepository, cachedPublishedPostRepository)
+	feedOptions := ProvideFeedOptions(cfg)
+	feedService := application.NewFeedService(followRepository, cachedPublishedPostRepository, feedCache, feedOptions)
+	followService := application.NewFollowService(followRepository, cachedUserRepository, feedService)
+	logger := ioc.NewLogger(cfg)
+	streamBroker := ioc.NewStreamBroker(cmdable, logger)
+	notificationService := application.NewNotificationService(notificationRepository, cachedPublishedPostRepository, streamBroker)
+	streamTicketStore := cache.NewStreamTicketStore(cmdable)
+	streamService := application.NewStreamService(streamBroker, postStatsCache, streamTicketStore)
 	jwtService := ioc.NewJWTService(cfg)
 	tokenService := ioc.NewTokenService(jwtService)
 	accessTokenVerifier := ioc.NewAccessTokenVerifier(jwtService)
 	accessExpireTime := ProvideAccessExpireTime(cfg)
 	refreshExpireTime := ProvideRefreshExpireTime(cfg)
-	authService := application.NewAuthService(tokenService, tokenBlacklist, accessExpireTime, refreshExpireTime)
-	userHandler := web.NewUserHandler(userService, authService)
-	postHandler := web.NewPostHandler(postService, postInteractionService)
-	logger := ioc.NewLogger(cfg)
-	engine := ioc.NewGinEngine(cfg, userHandler, postHandler, accessTokenVerifier, logger)
+	sessionDAO := dao.NewSessionDAO(db)
+	sessionRepository := repository.NewSessionRepository(sessionDAO)
+	authService := application.NewAuthService(tokenService, tokenBlacklist, sessionRepository, accessExpireTime, refreshExpireTime)
+	accountTokenStore := cache.NewAccountTokenStore(cmdable)
+	mailer := ioc.NewMailer(cfg, logger)
+	accountOptions := ProvideAccountOptions(cfg)
+	accountService := application.NewAccountService(cachedUserRepository, accountTokenStore, mailer, tokenBlacklist, accountOptions)
+	adminUserIds := ProvideAdminUserIds(cfg)
+	authorizationService := application.NewAuthorizationService(cachedUserRepository, adminUserIds)
+	userHandler := web.NewUserHandler(userService, authService, accountService, authorizationService)
+	postHandler := web.NewPostHandler(postService, postInteractionService, authorizationService)
+	postRevisionHandler := web.NewPostRevisionHandler(postRevisionService)
+	policyMiddlewareBuilder := middleware.NewPolicyMiddlewareBuilder(authorizationService)
+	tagHandler := web.NewTagHandler(tagService, policyMiddlewareBuilder)
+	postSearchHandler := web.NewPostSearchHandler(postSearchService, postInteractionService)
+	commentHandler := web.NewCommentHandler(commentService)
+	postRankHandler := web.NewPostRankHandler(postRankService, postInteractionService)
+	postAnalyticsHandler := web.NewPostAnalyticsHandler(postAnalyticsService)
+	collectionHandler := web.NewCollectionHandler(collectionService, postInteractionService)
+	readHistoryHandler := web.NewReadHistoryHandler(readHistoryService, postInteractionService)
+	followHandler := web.NewFollowHandler(followService)
+	feedHandler := web.NewFeedHandler(feedService, postInteractionService)
+	notificationHandler := web.NewNotificationHandler(notificationService)
+	streamHandler := web.NewStreamHandler(streamService, postInteractionService)
+	personalAccessTokenDAO := dao.NewPersonalAccessTokenDAO(db)
+	personalAccessTokenRepository := repository.NewPersonalAccessTokenRepository(personalAccessTokenDAO)
+	adminService := application.NewAdminService(cachedUserRepository, cacheEvictingPostRepository, authService, personalAccessTokenRepository)
+	adminHandler := web.NewAdminHandler(userService, adminService, policyMiddlewareBuilder)
+	publicKeyProvider := ioc.NewPublicKeyProvider(jwtService)
+	jwksHandler := web.NewJWKSHandler(publicKeyProvider)
+	personalAccessTokenService := application.NewPersonalAccessTokenService(personalAccessTokenRepository)
+	personalAccessTokenHandler := web.NewPersonalAccessTokenHandler(personalAccessTokenService)
+	engine := ioc.NewGinEngine(cfg, userHandler, postHandler, postRevisionHandler, tagHandler, postSearchHandler, commentHandler, postRankHandler, postAnalyticsHandler, collectionHandler, readHistoryHandler, followHandler, feedHandler, notificationHandler, streamHandler, adminHandler, jwksHandler, personalAccessTokenHandler, accessTokenVerifier, authService, personalAccessTokenService, streamService, logger)
 	return engine
 }
 
 // InitPostStatsWorker initializes the stats worker.
-func InitPostStatsWorker(cfg *config.Config) *application.PostStatsWorker {
+func InitPostStatsWorker(cfg *config.Config, searchIndex output.SearchService) *application.PostStatsWorker {
 	db := ioc.NewDB(cfg)
 	postStatsDAO := dao.NewPostStatsDAO(db)
 	cmdable := ioc.NewRedis(cfg)
@@ -72,12 +146,81 @@ func InitPostStatsWorker(cfg *config.Config) *application.PostStatsWorker {
 	logger := ioc.NewLogger(cfg)
 	rabbitMQConn := ioc.NewRabbitMQConn(cfg)
 	rabbitMQConsumerChannel := ioc.NewRabbitMQConsumerChannel(rabbitMQConn)
-	postStatsConsumer := ioc.NewPostStatsConsumer(rabbitMQConsumerChannel, cfg, postStatsCache, logger)
-	postStatsFlusher := application.NewPostStatsFlusher(postStatsCache, postStatsRepository, logger)
-	postStatsWorker := application.NewPostStatsWorker(postStatsConsumer, postStatsFlusher)
+	postRankCache := cache.NewPostRankCache(cmdable)
+	followDAO := dao.NewFollowDAO(db)
+	followRepository := repository.NewFollowRepository(followDAO)
+	publishedPostDAO := dao.NewPublishedPostDAO(db)
+	tagDAO := dao.NewTagDAO(db)
+	publishedPostRepository := repository.NewPublishedPostRepository(publishedPostDAO, tagDAO)
+	feedInboxSize := ProvideFeedInboxSize(cfg)
+	feedCache := cache.NewFeedCache(cmdable, feedInboxSize)
+	feedOptions := ProvideFeedOptions(cfg)
+	feedService := application.NewFeedService(followRepository, publishedPostRepository, feedCache, feedOptions)
+	notificationDAO := dao.NewNotificationDAO(db)
+	notificationRepository := repository.NewNotificationRepository(notificationDAO)
+	streamBroker := ioc.NewStreamBroker(cmdable, logger)
+	notificationService := application.NewNotificationService(notificationRepository, publishedPostRepository, streamBroker)
+	streamTicketStore := cache.NewStreamTicketStore(cmdable)
+	streamService := application.NewStreamService(streamBroker, postStatsCache, streamTicketStore)
+	postStatsConsumer := ioc.NewPostStatsConsumer(rabbitMQConsumerChannel, cfg, postStatsCache, postRankCache, feedService, notificationService, streamService, logger)
+	postDailyStatsDAO := dao.NewPostDailyStatsDAO(db)
+	postDailyStatsRepository := repository.NewPostDailyStatsRepository(postDailyStatsDAO)
+	postStatsFlusher := application.NewPostStatsFlusher(postStatsCache, postStatsRepository, postDailyStatsRepository, logger)
+	postDAO := dao.NewPostDAO(db)
+	postRepository := repository.NewPostRepository(postDAO, tagDAO)
+	indexedPostRepository := repository.NewIndexedPostRepository(postRepository, searchIndex)
+	postCache := cache.NewPostCache(cmdable)
+	cacheEvictingPostRepository := repository.NewCacheEvictingPostRepository(indexedPostRepository, postCache)
+	postPublishScheduler := application.NewPostPublishScheduler(cacheEvictingPostRepository, postCache, logger)
+	searchRebuildInterval := ProvideSearchRebuildInterval(cfg)
+	postSearchIndexer := application.NewPostSearchIndexer(publishedPostRepository, searchIndex, logger, searchRebuildInterval)
+	postStatsOutboxDAO := dao.NewPostStatsOutboxDAO(db)
+	postStatsOutboxRepository := repository.NewPostStatsOutboxRepository(postStatsOutboxDAO)
+	rabbitMQProducerChannel := ioc.NewRabbitMQProducerChannel(rabbitMQConn)
+	postStatsPublisher := ioc.NewPostStatsPublisher(rabbitMQProducerChannel, cfg)
+	postStatsOutboxRelay := application.NewPostStatsOutboxRelay(postStatsOutboxRepository, postStatsPublisher, postStatsCache, logger)
+	postLikeDAO := dao.NewPostLikeDAO(db)
+	postLikeRepository := repository.NewPostLikeRepository(postLikeDAO)
+	postCollectDAO := dao.NewPostCollectDAO(db)
+	postCollectRepository := repository.NewPostCollectRepository(postCollectDAO)
+	postStatsReconcileSchedule := ProvideStatsReconcileSchedule(cfg)
+	postStatsReconciler := application.NewPostStatsReconciler(postStatsRepository, postLikeRepository, postCollectRepository, postStatsCache, logger, postStatsReconcileSchedule)
+	postHotRankSchedule := ProvideHotRankSchedule(cfg)
+	postHotRanker := application.NewPostHotRanker(postStatsRepository, publishedPostRepository, postRankCache, postStatsCache, logger, postHotRankSchedule)
+	postReaderDAO := dao.NewPostReaderDAO(db)
+	postReaderRepository := repository.NewPostReaderRepository(postReaderDAO)
+	postReaderSnapshotInterval := ProvideReaderSnapshotInterval(cfg)
+	postReaderSnapshotter := application.NewPostReaderSnapshotter(postStatsCache, postReaderRepository, logger, postReaderSnapshotInterval)
+	notificationEventCleaner := application.NewNotificationEventCleaner(notificationRepository, logger)
+	postStatsWorker := application.NewPostStatsWorker(postStatsConsumer, postStatsFlusher, postPublishScheduler, postSearchIndexer, postStatsOutboxRelay, postStatsReconciler, postHotRanker, postReaderSnapshotter, notificationEventCleaner)
 	return postStatsWorker
 }
 
+// InitPostStatsReconciler initializes the stats reconciliation job.
+func InitPostStatsReconciler(cfg *config.Config) *application.PostStatsReconciler {
+	db := ioc.NewDB(cfg)
+	postStatsDAO := dao.NewPostStatsDAO(db)
+	postLikeDAO := dao.NewPostLikeDAO(db)
+	postCollectDAO := dao.NewPostCollectDAO(db)
+	cmdable := ioc.NewRedis(cfg)
+	postStatsCache := cache.NewPostStatsCache(cmdable)
+	postStatsRepository := repository.NewPostStatsRepository(postStatsDAO)
+	postLikeRepository := repository.NewPostLikeRepository(postLikeDAO)
+	postCollectRepository := repository.NewPostCollectRepository(postCollectDAO)
+	logger := ioc.NewLogger(cfg)
+	postStatsReconcileSchedule := ProvideStatsReconcileSchedule(cfg)
+	postStatsReconciler := application.NewPostStatsReconciler(postStatsRepository, postLikeRepository, postCollectRepository, postStatsCache, logger, postStatsReconcileSchedule)
+	return postStatsReconciler
+}
+
+// InitPostStatsDLQ initializes the stats dead-letter queue admin client.
+func InitPostStatsDLQ(cfg *config.Config) output.PostStatsDeadLetterQueue {
+	rabbitMQConn := ioc.NewRabbitMQConn(cfg)
+	rabbitMQProducerChannel := ioc.NewRabbitMQProducerChannel(rabbitMQConn)
+	postStatsDeadLetterQueue := ioc.NewPostStatsDLQ(rabbitMQProducerChannel, cfg)
+	return postStatsDeadLetterQueue
+}
+
 // ProvideUserCacheExpiration provides user cache expiration.
 func ProvideUserCacheExpiration(cfg *config.Config) cache.UserCacheExpiration {
 	return cache.UserCacheExpiration(cfg.Cache.UserExpiration)
@@ -92,3 +235,70 @@ func ProvideAccessExpireTime(cfg *config.Config) time.Duration {
 func ProvideRefreshExpireTime(cfg *config.Config) time.Duration {
 	return cfg.JWT.RefreshExpireTime
 }
+
+// ProvideAccountOptions provides the account email settings.
+func ProvideAccountOptions(cfg *config.Config) application.AccountOptions {
+	return application.AccountOptions{
+		LinkBaseURL:    cfg.Account.LinkBaseURL,
+		VerifyTokenTTL: cfg.Account.VerifyTokenTTL,
+		ResetTokenTTL:  cfg.Account.ResetTokenTTL,
+		MailInterval:   cfg.Account.MailInterval,
+		SessionTTL:     cfg.JWT.RefreshExpireTime,
+	}
+}
+
+// ProvideLoginGuardOptions provides the failed login lockout settings.
+func ProvideLoginGuardOptions(cfg *config.Config) application.LoginGuardOptions {
+	return application.LoginGuardOptions{
+		MaxEmailFailures: int64(cfg.Login.MaxEmailFailures),
+		MaxIPFailures:    int64(cfg.Login.MaxIPFailures),
+		BaseLockout:      cfg.Login.BaseLockout,
+		MaxLockout:       cfg.Login.MaxLockout,
+		FailureWindow:    cfg.Login.FailureWindow,
+	}
+}
+
+// ProvideAdminUserIds provides the users who are admins regardless of their stored role.
+func ProvideAdminUserIds(cfg *config.Config) application.AdminUserIds {
+	return application.AdminUserIds(cfg.Admin.UserIds)
+}
+
+// ProvideSearchRebuildInterval provides the search index rebuild interval.
+func ProvideSearchRebuildInterval(cfg *config.Config) time.Duration {
+	return cfg.Search.RebuildInterval
+}
+
+// ProvideStatsReconcileSchedule provides the scheduled reconciliation settings.
+func ProvideStatsReconcileSchedule(cfg *config.Config) application.PostStatsReconcileSchedule {
+	return application.PostStatsReconcileSchedule{
+		Interval: cfg.Stats.ReconcileInterval,
+		Repair:   cfg.Stats.ReconcileRepair,
+	}
+}
+
+// ProvideHotRankSchedule provides the hot ranking job settings.
+func ProvideHotRankSchedule(cfg *config.Config) application.PostHotRankSchedule {
+	return application.PostHotRankSchedule{
+		RefreshInterval: cfg.Stats.HotRefreshInterval,
+		RebuildInterval: cfg.Stats.HotRebuildInterval,
+		TopN:            cfg.Stats.HotTopN,
+	}
+}
+
+// ProvideReaderSnapshotInterval provides the unique reader snapshot interval.
+func ProvideReaderSnapshotInterval(cfg *config.Config) application.PostReaderSnapshotInterval {
+	return application.PostReaderSnapshotInterval(cfg.Stats.ReaderSnapshotInterval)
+}
+
+// ProvideFeedInboxSize provides the per-user feed inbox size.
+func ProvideFeedInboxSize(cfg *config.Config) cache.FeedInboxSize {
+	return cache.FeedInboxSize(cfg.Feed.InboxSize)
+}
+
+// ProvideFeedOptions provides the feed push/pull settings.
+func ProvideFeedOptions(cfg *config.Config) application.FeedOptions {
+	return application.FeedOptions{
+		PushThreshold: int64(cfg.Feed.PushThreshold),
+		BackfillSize:  cfg.Feed.BackfillSize,
+	}
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -17,6 +18,13 @@ type Config struct {
 	Session SessionConfig
 	CORS    CORSConfig
 	Log     LogConfig
+	Search  SearchConfig
+	Stats   StatsConfig
+	Feed    FeedConfig
+	Mail    MailConfig
+	Account AccountConfig
+	Login   LoginConfig
+	Admin   AdminConfig
 }
 
 type LogConfig struct {
@@ -24,8 +32,60 @@ type LogConfig struct {
 	IsDev bool   // 开发模式：彩色控制台输出；生产模式：JSON 格式
 }
 
+type SearchConfig struct {
+	RebuildInterval time.Duration // 全文索引全量重建间隔
+}
+
+type StatsConfig struct {
+	ReconcileInterval time.Duration // 点赞/收藏计数对账间隔，0 表示不定时执行
+	ReconcileRepair   bool          // 定时对账时是否自动修复
+
+	HotRefreshInterval time.Duration // 热榜各时间窗口的刷新间隔
+	HotRebuildInterval time.Duration // 从 post_stats 全量重算累计热度的间隔
+	HotTopN            int           // 每个时间窗口保留的帖子数
+
+	ReaderSnapshotInterval time.Duration // 独立访客 HyperLogLog 落库间隔
+}
+
+type FeedConfig struct {
+	PushThreshold int // 粉丝数达到该值的作者发帖不再推送到粉丝收件箱，改为读时拉取
+	InboxSize     int // 每个用户收件箱保留的帖子数
+	BackfillSize  int // 关注作者时补进收件箱的帖子数
+}
+
+type MailConfig struct {
+	Driver       string // smtp 或 log；log 只写日志（和 FilePath 文件），用于本地开发
+	From         string // 发件人，如 webook <no-reply@example.com>
+	SMTPAddr     string // host:port，使用 STARTTLS 的提交端口（587）
+	SMTPUsername string
+	SMTPPassword string
+	FilePath     string // log 驱动下追加写入邮件的文件，为空则只写日志
+}
+
+type AccountConfig struct {
+	LinkBaseURL    string        // 邮件中链接指向的前端地址
+	VerifyTokenTTL time.Duration // 邮箱验证链接有效期
+	ResetTokenTTL  time.Duration // 重置密码链接有效期
+	MailInterval   time.Duration // 同一用户同一类邮件的最小发送间隔
+}
+
+type LoginConfig struct {
+	MaxEmailFailures int           // 同一邮箱连续失败多少次后锁定
+	MaxIPFailures    int           // 同一 IP 连续失败多少次后锁定
+	BaseLockout      time.Duration // 首次锁定时长，之后每多失败一次翻倍
+	MaxLockout       time.Duration // 锁定时长上限
+	FailureWindow    time.Duration // 失败计数在最后一次失败后保留的时长
+}
+
+type AdminConfig struct {
+	UserIds []int64 // 始终视为管理员的用户ID，不论数据库中的角色，用于指定第一个管理员
+}
+
 type ServerConfig struct {
 	Port string
+	// TrustedProxies 可信反向代理的 IP 或 CIDR，只有来自这些地址的请求才读取 X-Forwarded-For；
+	// 为空时不信任任何代理，客户端 IP 即连接的对端地址
+	TrustedProxies []string
 }
 
 type DBConfig struct {
@@ -48,6 +108,10 @@ type MQConfig struct {
 	Queue      string
 	RoutingKey string
 	Prefetch   int
+
+	// 消费失败后经重试队列延迟 RetryDelay 再投递，累计 MaxAttempts 次仍失败则转入死信队列
+	MaxAttempts int
+	RetryDelay  time.Duration
 }
 
 type CacheConfig struct {
@@ -55,9 +119,14 @@ type CacheConfig struct {
 }
 
 type JWTConfig struct {
-	SecretKey         string
+	Algorithm         string        // HS256 使用 SecretKey；RS256 / EdDSA 使用 PEM 私钥，算法由密钥类型决定
+	SecretKey         string        // HS256 的签名密钥
+	PrivateKeyFile    string        // RS256 / EdDSA 当前签名私钥的 PEM 文件
+	PublicKeyFiles    []string      // 轮换期间仍接受的旧公钥 PEM 文件
+	RefreshSecretKey  string        // Refresh Token 的签名密钥，始终使用 HS256；默认 SecretKey + "_refresh"，兼容已签发的 token
 	ExpireTime        time.Duration // Access Token 有效期
 	RefreshExpireTime time.Duration // Refresh Token 有效期
+	CheckSession      bool          // 读请求也检查 Access Token 所属会话是否已注销（多一次 Redis 查询），写请求总是检查
 }
 
 type CORSConfig struct {
@@ -67,9 +136,11 @@ type CORSConfig struct {
 
 // Load 从环境变量加载配置，未设置则使用默认值
 func Load() *Config {
+	jwtSecret := getEnv("JWT_SECRET", "your-jwt-secret-key")
 	return &Config{
 		Server: ServerConfig{
-			Port: getEnv("SERVER_PORT", ":8080"),
+			Port:           getEnv("SERVER_PORT", ":8080"),
+			TrustedProxies: getEnvAsList("TRUSTED_PROXIES"),
 		},
 		DB: DBConfig{
 			DSN: getEnv("DB_DSN", "root:root@tcp(localhost:13316)/webook"),
@@ -84,14 +155,22 @@ func Load() *Config {
 			Queue:      getEnv("MQ_QUEUE", "post.stats.queue"),
 			RoutingKey: getEnv("MQ_ROUTING_KEY", "post.stats"),
 			Prefetch:   getEnvAsInt("MQ_PREFETCH", 50),
+
+			MaxAttempts: getEnvAsInt("MQ_MAX_ATTEMPTS", 5),
+			RetryDelay:  5 * time.Second,
 		},
 		Cache: CacheConfig{
 			UserExpiration: 15 * time.Minute,
 		},
 		JWT: JWTConfig{
-			SecretKey:         getEnv("JWT_SECRET", "your-jwt-secret-key"),
+			Algorithm:         getEnv("JWT_ALG", "HS256"),
+			SecretKey:         jwtSecret,
+			PrivateKeyFile:    getEnv("JWT_PRIVATE_KEY_FILE", ""),
+			PublicKeyFiles:    getEnvAsList("JWT_PUBLIC_KEY_FILES"),
+			RefreshSecretKey:  getEnv("JWT_REFRESH_SECRET", jwtSecret+"_refresh"),
 			ExpireTime:        30 * time.Minute,   // Access Token 30 分钟
 			RefreshExpireTime: 7 * 24 * time.Hour, // Refresh Token 7 天
+			CheckSession:      getEnv("JWT_CHECK_SESSION", "false") == "true",
 		},
 		Session: SessionConfig{
 			Secret: getEnv("SESSION_SECRET", "your-secret-key-change-in-production"),
@@ -105,6 +184,48 @@ func Load() *Config {
 			Level: getEnv("LOG_LEVEL", "info"),
 			IsDev: getEnv("APP_ENV", "dev") == "dev",
 		},
+		Search: SearchConfig{
+			RebuildInterval: 10 * time.Minute,
+		},
+		Stats: StatsConfig{
+			ReconcileInterval: 24 * time.Hour,
+			ReconcileRepair:   getEnv("STATS_RECONCILE_REPAIR", "false") == "true",
+
+			HotRefreshInterval: time.Minute,
+			HotRebuildInterval: time.Hour,
+			HotTopN:            getEnvAsInt("HOT_TOP_N", 1000),
+
+			ReaderSnapshotInterval: time.Minute,
+		},
+		Feed: FeedConfig{
+			PushThreshold: getEnvAsInt("FEED_PUSH_THRESHOLD", 5000),
+			InboxSize:     getEnvAsInt("FEED_INBOX_SIZE", 1000),
+			BackfillSize:  20,
+		},
+		Mail: MailConfig{
+			Driver:       getEnv("MAIL_DRIVER", "log"),
+			From:         getEnv("MAIL_FROM", "webook <no-reply@webook.local>"),
+			SMTPAddr:     getEnv("SMTP_ADDR", "localhost:587"),
+			SMTPUsername: getEnv("SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+			FilePath:     getEnv("MAIL_FILE", ""),
+		},
+		Account: AccountConfig{
+			LinkBaseURL:    getEnv("APP_BASE_URL", "http://localhost:3000"),
+			VerifyTokenTTL: 24 * time.Hour,
+			ResetTokenTTL:  30 * time.Minute,
+			MailInterval:   time.Minute,
+		},
+		Login: LoginConfig{
+			MaxEmailFailures: getEnvAsInt("LOGIN_MAX_EMAIL_FAILURES", 5),
+			MaxIPFailures:    getEnvAsInt("LOGIN_MAX_IP_FAILURES", 50),
+			BaseLockout:      time.Minute,
+			MaxLockout:       time.Hour,
+			FailureWindow:    24 * time.Hour,
+		},
+		Admin: AdminConfig{
+			UserIds: getEnvAsInt64List("ADMIN_USER_IDS"),
+		},
 	}
 }
 
@@ -116,6 +237,29 @@ func getEnvAsInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+// getEnvAsInt64List 解析逗号分隔的整数列表，忽略无法解析的项
+func getEnvAsInt64List(key string) []int64 {
+	var result []int64
+	for _, item := range getEnvAsList(key) {
+		if v, err := strconv.ParseInt(item, 10, 64); err == nil {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// getEnvAsList 解析逗号分隔的列表，忽略空项
+func getEnvAsList(key string) []string {
+	var result []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
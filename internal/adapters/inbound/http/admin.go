@@ -0,0 +1,277 @@
+package web
+
+import (
+	"strconv"
+	"webook/internal/adapters/inbound/http/ginx"
+	"webook/internal/adapters/inbound/http/middleware"
+	"webook/internal/domain"
+	service "webook/internal/ports/input"
+
+	"github.com/gin-gonic/gin"
+)
+
+// userStatusNames 账号状态在接口中的名称
+var userStatusNames = map[domain.UserStatus]string{
+	domain.UserStatusActive:   "active",
+	domain.UserStatusDisabled: "disabled",
+	domain.UserStatusBanned:   "banned",
+}
+
+// AdminHandler 管理后台的 HTTP 请求处理，每个接口按所需权限检查角色
+type AdminHandler struct {
+	users  service.UserService
+	admin  service.AdminService
+	policy *middleware.PolicyMiddlewareBuilder
+}
+
+// NewAdminHandler 创建 AdminHandler 实例
+func NewAdminHandler(users service.UserService, admin service.AdminService, policy *middleware.PolicyMiddlewareBuilder) *AdminHandler {
+	return &AdminHandler{users: users, admin: admin, policy: policy}
+}
+
+// RegisterRoutes 注册路由
+func (h *AdminHandler) RegisterRoutes(server *gin.Engine) {
+	userRead := h.policy.Require(domain.PermUserRead)
+	userManage := h.policy.Require(domain.PermUserManage)
+	ag := server.Group("/admin")
+	{
+		ag.GET("/users", userRead, h.ListUsers)                        // 用户列表
+		ag.PUT("/users/:id/status", userManage, h.SetUserStatus)       // 停用、封禁或恢复用户
+		ag.PUT("/users/:id/role", userManage, h.SetUserRole)           // 分配角色
+		ag.POST("/users/:id/unlock", userManage, h.UnlockUser)         // 解除登录锁定
+		ag.GET("/users/:id/login-attempts", userRead, h.LoginAttempts) // 登录审计日志
+
+		postModerate := h.policy.Require(domain.PermPostModerate)
+		ag.POST("/posts/:id/hide", postModerate, h.HidePost)     // 强制隐藏帖子
+		ag.POST("/posts/:id/unhide", postModerate, h.UnhidePost) // 解除隐藏
+	}
+}
+
+// ListUsers 按注册时间倒序分页获取用户
+// GET /admin/users?page=1&pageSize=20
+func (h *AdminHandler) ListUsers(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	users, total, err := h.admin.ListUsers(c.Request.Context(), page, pageSize)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "获取用户列表失败")
+		return
+	}
+	list := make([]gin.H, len(users))
+	for i, u := range users {
+		role := u.Role
+		if role == "" {
+			role = domain.RoleUser
+		}
+		list[i] = gin.H{
+			"id":            u.Id,
+			"email":         u.Email,
+			"emailVerified": u.EmailVerified,
+			"nickname":      u.Nickname,
+			"role":          role,
+			"status":        userStatusNames[u.Status],
+			"ctime":         u.Ctime,
+		}
+	}
+	ginx.Success(c, gin.H{
+		"users":    list,
+		"total":    total,
+		"page":     page,
+		"pageSize": pageSize,
+	})
+}
+
+// SetUserStatus 停用、封禁或恢复用户，停用和封禁会注销该用户的全部会话并删除其个人访问令牌
+// PUT /admin/users/:id/status
+func (h *AdminHandler) SetUserStatus(c *gin.Context) {
+	type StatusReq struct {
+		Status string `json:"status"` // active、disabled 或 banned
+	}
+
+	id, ok := h.targetUserId(c)
+	if !ok {
+		return
+	}
+	var req StatusReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "参数错误")
+		return
+	}
+	status, ok := parseUserStatus(req.Status)
+	if !ok {
+		ginx.Error(c, ginx.CodeInvalidParams, "status 只能是 active、disabled 或 banned")
+		return
+	}
+
+	err := h.admin.SetUserStatus(c.Request.Context(), id, status)
+	if err == domain.ErrUserNotFound {
+		ginx.Error(c, ginx.CodeNotFound, "用户不存在")
+		return
+	}
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "修改账号状态失败")
+		return
+	}
+	ginx.SuccessMsg(c, "已修改")
+}
+
+// SetUserRole 修改用户角色
+// PUT /admin/users/:id/role
+func (h *AdminHandler) SetUserRole(c *gin.Context) {
+	type RoleReq struct {
+		Role domain.Role `json:"role"` // user、moderator 或 admin
+	}
+
+	id, ok := h.targetUserId(c)
+	if !ok {
+		return
+	}
+	var req RoleReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "参数错误")
+		return
+	}
+
+	err := h.admin.SetUserRole(c.Request.Context(), id, req.Role)
+	if err == domain.ErrInvalidRole {
+		ginx.Error(c, ginx.CodeInvalidParams, "role 只能是 user、moderator 或 admin")
+		return
+	}
+	if err == domain.ErrUserNotFound {
+		ginx.Error(c, ginx.CodeNotFound, "用户不存在")
+		return
+	}
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "修改角色失败")
+		return
+	}
+	ginx.SuccessMsg(c, "已修改")
+}
+
+// HidePost 隐藏任意帖子并从线上库下架，解除之前作者不能重新发布
+// POST /admin/posts/:id/hide
+func (h *AdminHandler) HidePost(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "无效的帖子ID")
+		return
+	}
+
+	err = h.admin.HidePost(c.Request.Context(), id)
+	if err == domain.ErrPostNotFound {
+		ginx.Error(c, ginx.CodeNotFound, "帖子不存在")
+		return
+	}
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "隐藏失败")
+		return
+	}
+	ginx.SuccessMsg(c, "已隐藏")
+}
+
+// UnhidePost 解除帖子的隐藏，帖子回到仅作者可见，由作者决定是否重新发布
+// POST /admin/posts/:id/unhide
+func (h *AdminHandler) UnhidePost(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "无效的帖子ID")
+		return
+	}
+
+	err = h.admin.UnhidePost(c.Request.Context(), id)
+	if err == domain.ErrPostNotHidden {
+		ginx.Error(c, ginx.CodeNotFound, "帖子不存在或未被隐藏")
+		return
+	}
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "解除隐藏失败")
+		return
+	}
+	ginx.SuccessMsg(c, "已解除隐藏")
+}
+
+// targetUserId 解析要修改的用户ID；不允许修改自己，避免管理员误操作把自己锁在外面
+func (h *AdminHandler) targetUserId(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "无效的用户ID")
+		return 0, false
+	}
+	if id == c.GetInt64("userId") {
+		ginx.Error(c, ginx.CodeInvalidParams, "不能修改自己的账号状态或角色")
+		return 0, false
+	}
+	return id, true
+}
+
+func parseUserStatus(name string) (domain.UserStatus, bool) {
+	for status, n := range userStatusNames {
+		if n == name {
+			return status, true
+		}
+	}
+	return 0, false
+}
+
+// UnlockUser 解除用户邮箱的登录锁定并清空失败计数，不影响按 IP 的锁定
+// POST /admin/users/:id/unlock
+func (h *AdminHandler) UnlockUser(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "无效的用户ID")
+		return
+	}
+
+	err = h.users.Unlock(c.Request.Context(), id)
+	if err == domain.ErrUserNotFound {
+		ginx.Error(c, ginx.CodeNotFound, "用户不存在")
+		return
+	}
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "解锁失败")
+		return
+	}
+	ginx.SuccessMsg(c, "已解锁")
+}
+
+// LoginAttempts 获取用户邮箱最近的登录尝试
+// GET /admin/users/:id/login-attempts?limit=50
+func (h *AdminHandler) LoginAttempts(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "无效的用户ID")
+		return
+	}
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if limit < 1 || limit > 200 {
+		limit = 50
+	}
+
+	attempts, err := h.users.LoginAttempts(c.Request.Context(), id, limit)
+	if err == domain.ErrUserNotFound {
+		ginx.Error(c, ginx.CodeNotFound, "用户不存在")
+		return
+	}
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "获取登录记录失败")
+		return
+	}
+	list := make([]gin.H, len(attempts))
+	for i, a := range attempts {
+		list[i] = gin.H{
+			"id":        a.Id,
+			"ip":        a.IP,
+			"userAgent": a.UserAgent,
+			"result":    a.Result,
+			"ctime":     a.Ctime,
+		}
+	}
+	ginx.Success(c, gin.H{"attempts": list})
+}
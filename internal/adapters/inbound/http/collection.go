@@ -0,0 +1,260 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"webook/internal/adapters/inbound/http/ginx"
+	"webook/internal/domain"
+	service "webook/internal/ports/input"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CollectionHandler 收藏夹相关的 HTTP 请求处理
+type CollectionHandler struct {
+	svc      service.CollectionService
+	statsSvc service.PostInteractionService
+}
+
+// NewCollectionHandler 创建 CollectionHandler 实例
+func NewCollectionHandler(svc service.CollectionService, statsSvc service.PostInteractionService) *CollectionHandler {
+	return &CollectionHandler{
+		svc:      svc,
+		statsSvc: statsSvc,
+	}
+}
+
+// RegisterRoutes 注册路由
+func (h *CollectionHandler) RegisterRoutes(server *gin.Engine) {
+	fg := server.Group("/collections/folders")
+	{
+		fg.GET("", h.ListFolders)         // 我的收藏夹（含默认收藏夹）
+		fg.POST("", h.CreateFolder)       // 创建收藏夹
+		fg.PUT("/:id", h.UpdateFolder)    // 修改名称、描述与公开状态
+		fg.DELETE("/:id", h.DeleteFolder) // 删除收藏夹，帖子移回默认收藏夹
+	}
+
+	server.PUT("/posts/:id/collect", h.CollectTo)                           // 收藏到指定收藏夹，已收藏时移动
+	server.GET("/users/:id/collections/:folderId/posts", h.ListFolderPosts) // 收藏夹中的帖子
+}
+
+// ListFolders 获取我的收藏夹及各自的帖子数
+// GET /collections/folders
+func (h *CollectionHandler) ListFolders(c *gin.Context) {
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	folders, err := h.svc.ListFolders(c.Request.Context(), userId)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "获取收藏夹失败")
+		return
+	}
+
+	list := make([]gin.H, len(folders))
+	for i, f := range folders {
+		list[i] = h.toFolderVO(f)
+	}
+	ginx.Success(c, gin.H{"folders": list})
+}
+
+// CreateFolder 创建收藏夹
+// POST /collections/folders
+func (h *CollectionHandler) CreateFolder(c *gin.Context) {
+	req, ok := h.bindFolderReq(c)
+	if !ok {
+		return
+	}
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	id, err := h.svc.CreateFolder(c.Request.Context(), domain.CollectionFolder{
+		UserId:      userId,
+		Name:        req.Name,
+		Description: req.Description,
+		Public:      req.Public,
+	})
+	if err != nil {
+		h.handleError(c, err, "创建收藏夹失败")
+		return
+	}
+	ginx.Success(c, gin.H{"id": id})
+}
+
+// UpdateFolder 修改收藏夹
+// PUT /collections/folders/:id
+func (h *CollectionHandler) UpdateFolder(c *gin.Context) {
+	id, ok := h.getIdParam(c, "id", "无效的收藏夹ID")
+	if !ok {
+		return
+	}
+	req, ok := h.bindFolderReq(c)
+	if !ok {
+		return
+	}
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	err := h.svc.UpdateFolder(c.Request.Context(), domain.CollectionFolder{
+		Id:          id,
+		UserId:      userId,
+		Name:        req.Name,
+		Description: req.Description,
+		Public:      req.Public,
+	})
+	if err != nil {
+		h.handleError(c, err, "修改收藏夹失败")
+		return
+	}
+	ginx.SuccessMsg(c, "修改成功")
+}
+
+// DeleteFolder 删除收藏夹，其中的帖子移回默认收藏夹，仍保持收藏
+// DELETE /collections/folders/:id
+func (h *CollectionHandler) DeleteFolder(c *gin.Context) {
+	id, ok := h.getIdParam(c, "id", "无效的收藏夹ID")
+	if !ok {
+		return
+	}
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	if err := h.svc.DeleteFolder(c.Request.Context(), id, userId); err != nil {
+		h.handleError(c, err, "删除收藏夹失败")
+		return
+	}
+	ginx.SuccessMsg(c, "删除成功")
+}
+
+// CollectTo 收藏帖子到指定收藏夹，帖子已收藏时移动到该收藏夹，folderId 为 0 表示默认收藏夹
+// PUT /posts/:id/collect
+func (h *CollectionHandler) CollectTo(c *gin.Context) {
+	type CollectReq struct {
+		FolderId int64 `json:"folderId"`
+	}
+
+	postId, ok := h.getIdParam(c, "id", "无效的帖子ID")
+	if !ok {
+		return
+	}
+	var req CollectReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "参数错误")
+		return
+	}
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	if err := h.svc.CollectTo(c.Request.Context(), postId, userId, req.FolderId); err != nil {
+		h.handleError(c, err, "收藏失败")
+		return
+	}
+	ginx.SuccessMsg(c, "ok")
+}
+
+// ListFolderPosts 获取收藏夹中的帖子，他人的收藏夹需公开才能查看，folderId 为 0 表示默认收藏夹
+// GET /users/:id/collections/:folderId/posts?page=1&pageSize=10
+func (h *CollectionHandler) ListFolderPosts(c *gin.Context) {
+	ownerId, ok := h.getIdParam(c, "id", "无效的用户ID")
+	if !ok {
+		return
+	}
+	folderId, ok := h.getIdParam(c, "folderId", "无效的收藏夹ID")
+	if !ok {
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	userId := c.GetInt64("userId")
+	posts, total, err := h.svc.ListPosts(c.Request.Context(), ownerId, folderId, userId, page, pageSize)
+	if err != nil {
+		h.handleError(c, err, "获取收藏失败")
+		return
+	}
+
+	postIds := make([]int64, 0, len(posts))
+	for _, p := range posts {
+		postIds = append(postIds, p.Id)
+	}
+	statsMap, userStats, _ := h.statsSvc.GetStatsBatch(c.Request.Context(), postIds, userId)
+	ginx.Success(c, gin.H{
+		"posts":    toPostVOs(posts, statsMap, userStats),
+		"total":    total,
+		"page":     page,
+		"pageSize": pageSize,
+	})
+}
+
+type folderReq struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Public      bool   `json:"public"`
+}
+
+func (h *CollectionHandler) bindFolderReq(c *gin.Context) (folderReq, bool) {
+	var req folderReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "参数错误")
+		return req, false
+	}
+	return req, true
+}
+
+func (h *CollectionHandler) toFolderVO(f domain.CollectionFolder) gin.H {
+	return gin.H{
+		"id":          f.Id,
+		"name":        f.Name,
+		"description": f.Description,
+		"public":      f.Public,
+		"postCnt":     f.PostCnt,
+		"ctime":       f.Ctime,
+		"utime":       f.Utime,
+	}
+}
+
+func (h *CollectionHandler) getIdParam(c *gin.Context, name, msg string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, msg)
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *CollectionHandler) handleError(c *gin.Context, err error, msg string) {
+	switch err {
+	case domain.ErrInvalidFolder:
+		ginx.Error(c, ginx.CodeInvalidParams, "收藏夹名称不能为空且不超过32个字符，描述不超过256个字符")
+	case domain.ErrDuplicateFolder:
+		ginx.Error(c, ginx.CodeDuplicateFolder, "收藏夹已存在")
+	case domain.ErrFolderNotFound:
+		ginx.Error(c, ginx.CodeNotFound, "收藏夹不存在")
+	case domain.ErrPostNotCollected:
+		ginx.Error(c, ginx.CodeNotFound, "帖子未收藏")
+	case domain.ErrForbidden:
+		ginx.ErrorWithStatus(c, http.StatusForbidden, ginx.CodeForbidden, "默认收藏夹不能修改或删除")
+	default:
+		ginx.Error(c, ginx.CodeInternalError, msg)
+	}
+}
@@ -0,0 +1,233 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"webook/internal/adapters/inbound/http/ginx"
+	"webook/internal/domain"
+	service "webook/internal/ports/input"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CommentHandler 评论相关的 HTTP 请求处理
+type CommentHandler struct {
+	svc service.CommentService
+}
+
+// NewCommentHandler 创建 CommentHandler 实例
+func NewCommentHandler(svc service.CommentService) *CommentHandler {
+	return &CommentHandler{svc: svc}
+}
+
+// RegisterRoutes 注册路由
+func (h *CommentHandler) RegisterRoutes(server *gin.Engine) {
+	server.GET("/posts/:id/comments", h.List)    // 根评论列表（游标分页）
+	server.POST("/posts/:id/comments", h.Create) // 发表评论或回复
+
+	cg := server.Group("/comments")
+	{
+		cg.GET("/:id/replies", h.ListReplies) // 根评论下的回复列表（游标分页）
+		cg.PUT("/:id", h.Edit)                // 修改自己的评论
+		cg.DELETE("/:id", h.Delete)           // 删除评论（评论者或帖子作者）
+	}
+}
+
+// Create 发表评论，parentId 不为 0 时表示回复
+// POST /posts/:id/comments
+func (h *CommentHandler) Create(c *gin.Context) {
+	type CreateReq struct {
+		Content  string `json:"content"`
+		ParentId int64  `json:"parentId"`
+	}
+
+	postId, ok := h.getIdParam(c, "无效的帖子ID")
+	if !ok {
+		return
+	}
+	var req CreateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "参数错误")
+		return
+	}
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	id, err := h.svc.Create(c.Request.Context(), domain.Comment{
+		PostId:   postId,
+		UserId:   userId,
+		ParentId: req.ParentId,
+		Content:  req.Content,
+	})
+	if err != nil {
+		h.handleError(c, err, "评论失败")
+		return
+	}
+	ginx.Success(c, gin.H{"id": id})
+}
+
+// Edit 修改评论内容
+// PUT /comments/:id
+func (h *CommentHandler) Edit(c *gin.Context) {
+	type EditReq struct {
+		Content string `json:"content"`
+	}
+
+	id, ok := h.getIdParam(c, "无效的评论ID")
+	if !ok {
+		return
+	}
+	var req EditReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "参数错误")
+		return
+	}
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	if err := h.svc.Edit(c.Request.Context(), id, userId, req.Content); err != nil {
+		h.handleError(c, err, "修改评论失败")
+		return
+	}
+	ginx.SuccessMsg(c, "修改成功")
+}
+
+// Delete 删除评论，根评论会连同其回复一起删除
+// DELETE /comments/:id
+func (h *CommentHandler) Delete(c *gin.Context) {
+	id, ok := h.getIdParam(c, "无效的评论ID")
+	if !ok {
+		return
+	}
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	if err := h.svc.Delete(c.Request.Context(), id, userId); err != nil {
+		h.handleError(c, err, "删除评论失败")
+		return
+	}
+	ginx.SuccessMsg(c, "删除成功")
+}
+
+// List 获取帖子的根评论，每条附带回复数与前几条回复
+// GET /posts/:id/comments?cursor=0&limit=10
+func (h *CommentHandler) List(c *gin.Context) {
+	postId, ok := h.getIdParam(c, "无效的帖子ID")
+	if !ok {
+		return
+	}
+	cursor, limit := h.parseCursor(c)
+
+	comments, err := h.svc.ListByPost(c.Request.Context(), postId, cursor, limit)
+	if err != nil {
+		h.handleError(c, err, "获取评论失败")
+		return
+	}
+
+	list := make([]gin.H, len(comments))
+	for i, cm := range comments {
+		vo := h.toCommentVO(cm)
+		replies := make([]gin.H, len(cm.Replies))
+		for j, r := range cm.Replies {
+			replies[j] = h.toCommentVO(r)
+		}
+		vo["replyCnt"] = cm.ReplyCnt
+		vo["replies"] = replies
+		list[i] = vo
+	}
+	ginx.Success(c, h.toPage(list, comments, limit))
+}
+
+// ListReplies 获取根评论下的回复
+// GET /comments/:id/replies?cursor=0&limit=10
+func (h *CommentHandler) ListReplies(c *gin.Context) {
+	id, ok := h.getIdParam(c, "无效的评论ID")
+	if !ok {
+		return
+	}
+	cursor, limit := h.parseCursor(c)
+
+	replies, err := h.svc.ListReplies(c.Request.Context(), id, cursor, limit)
+	if err != nil {
+		h.handleError(c, err, "获取回复失败")
+		return
+	}
+
+	list := make([]gin.H, len(replies))
+	for i, r := range replies {
+		list[i] = h.toCommentVO(r)
+	}
+	ginx.Success(c, h.toPage(list, replies, limit))
+}
+
+func (h *CommentHandler) toCommentVO(cm domain.Comment) gin.H {
+	return gin.H{
+		"id":            cm.Id,
+		"postId":        cm.PostId,
+		"userId":        cm.UserId,
+		"rootId":        cm.RootId,
+		"parentId":      cm.ParentId,
+		"replyToUserId": cm.ReplyToUserId,
+		"content":       cm.Content,
+		"ctime":         cm.Ctime,
+		"utime":         cm.Utime,
+	}
+}
+
+// toPage 组装游标分页结果，nextCursor 为本页最后一条评论的ID
+func (h *CommentHandler) toPage(list []gin.H, comments []domain.Comment, limit int) gin.H {
+	var nextCursor int64
+	if len(comments) > 0 {
+		nextCursor = comments[len(comments)-1].Id
+	}
+	return gin.H{
+		"comments":   list,
+		"nextCursor": nextCursor,
+		"hasMore":    len(comments) == limit,
+	}
+}
+
+func (h *CommentHandler) parseCursor(c *gin.Context) (int64, int) {
+	cursor, _ := strconv.ParseInt(c.DefaultQuery("cursor", "0"), 10, 64)
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if cursor < 0 {
+		cursor = 0
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	return cursor, limit
+}
+
+func (h *CommentHandler) getIdParam(c *gin.Context, msg string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, msg)
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *CommentHandler) handleError(c *gin.Context, err error, msg string) {
+	switch err {
+	case domain.ErrInvalidComment:
+		ginx.Error(c, ginx.CodeInvalidParams, "评论内容不能为空且不超过1000字")
+	case domain.ErrPostNotFound:
+		ginx.Error(c, ginx.CodeNotFound, "post not found")
+	case domain.ErrCommentNotFound:
+		ginx.Error(c, ginx.CodeNotFound, "评论不存在")
+	case domain.ErrForbidden:
+		ginx.ErrorWithStatus(c, http.StatusForbidden, ginx.CodeForbidden, "无权操作")
+	default:
+		ginx.Error(c, ginx.CodeInternalError, msg)
+	}
+}
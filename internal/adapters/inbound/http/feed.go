@@ -0,0 +1,68 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"webook/internal/adapters/inbound/http/ginx"
+	"webook/internal/domain"
+	service "webook/internal/ports/input"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FeedHandler 关注流的 HTTP 请求处理
+type FeedHandler struct {
+	svc      service.FeedService
+	statsSvc service.PostInteractionService
+}
+
+// NewFeedHandler 创建 FeedHandler 实例
+func NewFeedHandler(svc service.FeedService, statsSvc service.PostInteractionService) *FeedHandler {
+	return &FeedHandler{
+		svc:      svc,
+		statsSvc: statsSvc,
+	}
+}
+
+// RegisterRoutes 注册路由
+func (h *FeedHandler) RegisterRoutes(server *gin.Engine) {
+	server.GET("/feed", h.List) // 关注的人发布的帖子（游标分页）
+}
+
+// List 获取关注流，按发布时间倒序
+// GET /feed?cursor=0&cursorId=0&limit=10
+func (h *FeedHandler) List(c *gin.Context) {
+	cursor, _ := strconv.ParseInt(c.DefaultQuery("cursor", "0"), 10, 64)
+	cursorId, _ := strconv.ParseInt(c.DefaultQuery("cursorId", "0"), 10, 64)
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if cursor < 0 {
+		cursor = 0
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	page, err := h.svc.List(c.Request.Context(), userId, domain.FeedCursor{Ts: cursor, PostId: cursorId}, limit)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "获取关注流失败")
+		return
+	}
+
+	postIds := make([]int64, len(page.Posts))
+	for i, p := range page.Posts {
+		postIds[i] = p.Id
+	}
+	statsMap, userStats, _ := h.statsSvc.GetStatsBatch(c.Request.Context(), postIds, userId)
+	// nextCursor/nextCursorId 由服务端计算（通常为本页最后一篇帖子的发布时间与ID），客户端原样带回即可
+	ginx.Success(c, gin.H{
+		"posts":        toPostVOs(page.Posts, statsMap, userStats),
+		"nextCursor":   page.NextCursor.Ts,
+		"nextCursorId": page.NextCursor.PostId,
+		"hasMore":      page.HasMore,
+	})
+}
@@ -0,0 +1,181 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"webook/internal/adapters/inbound/http/ginx"
+	"webook/internal/domain"
+	service "webook/internal/ports/input"
+
+	"github.com/gin-gonic/gin"
+)
+
+// FollowHandler 关注相关的 HTTP 请求处理
+type FollowHandler struct {
+	svc service.FollowService
+}
+
+// NewFollowHandler 创建 FollowHandler 实例
+func NewFollowHandler(svc service.FollowService) *FollowHandler {
+	return &FollowHandler{svc: svc}
+}
+
+// RegisterRoutes 注册路由
+func (h *FollowHandler) RegisterRoutes(server *gin.Engine) {
+	ug := server.Group("/users/:id")
+	{
+		ug.POST("/follow", h.Follow)          // 关注用户
+		ug.POST("/unfollow", h.Unfollow)      // 取消关注
+		ug.GET("/follow-stats", h.Stats)      // 粉丝数与关注数
+		ug.GET("/followers", h.ListFollowers) // 粉丝列表（游标分页）
+		ug.GET("/followees", h.ListFollowees) // 关注列表（游标分页）
+	}
+}
+
+// Follow 关注用户
+// POST /users/:id/follow
+func (h *FollowHandler) Follow(c *gin.Context) {
+	followeeId, ok := h.getIdParam(c)
+	if !ok {
+		return
+	}
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	if err := h.svc.Follow(c.Request.Context(), userId, followeeId); err != nil {
+		h.handleError(c, err, "关注失败")
+		return
+	}
+	ginx.SuccessMsg(c, "关注成功")
+}
+
+// Unfollow 取消关注
+// POST /users/:id/unfollow
+func (h *FollowHandler) Unfollow(c *gin.Context) {
+	followeeId, ok := h.getIdParam(c)
+	if !ok {
+		return
+	}
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	if err := h.svc.Unfollow(c.Request.Context(), userId, followeeId); err != nil {
+		h.handleError(c, err, "取消关注失败")
+		return
+	}
+	ginx.SuccessMsg(c, "已取消关注")
+}
+
+// Stats 获取用户的粉丝数与关注数，登录时返回是否已关注
+// GET /users/:id/follow-stats
+func (h *FollowHandler) Stats(c *gin.Context) {
+	id, ok := h.getIdParam(c)
+	if !ok {
+		return
+	}
+
+	stats, err := h.svc.GetStats(c.Request.Context(), id, c.GetInt64("userId"))
+	if err != nil {
+		h.handleError(c, err, "获取关注数据失败")
+		return
+	}
+	ginx.Success(c, gin.H{
+		"userId":      stats.UserId,
+		"followerCnt": stats.FollowerCnt,
+		"followeeCnt": stats.FolloweeCnt,
+		"followed":    stats.Followed,
+	})
+}
+
+// ListFollowers 获取用户的粉丝
+// GET /users/:id/followers?cursor=0&limit=10
+func (h *FollowHandler) ListFollowers(c *gin.Context) {
+	id, ok := h.getIdParam(c)
+	if !ok {
+		return
+	}
+	cursor, limit := h.parseCursor(c)
+
+	rels, err := h.svc.ListFollowers(c.Request.Context(), id, cursor, limit)
+	if err != nil {
+		h.handleError(c, err, "获取粉丝列表失败")
+		return
+	}
+	list := make([]gin.H, len(rels))
+	for i, r := range rels {
+		list[i] = gin.H{"userId": r.FollowerId, "followTime": r.Ctime}
+	}
+	ginx.Success(c, h.toPage(list, rels, limit))
+}
+
+// ListFollowees 获取用户关注的人
+// GET /users/:id/followees?cursor=0&limit=10
+func (h *FollowHandler) ListFollowees(c *gin.Context) {
+	id, ok := h.getIdParam(c)
+	if !ok {
+		return
+	}
+	cursor, limit := h.parseCursor(c)
+
+	rels, err := h.svc.ListFollowees(c.Request.Context(), id, cursor, limit)
+	if err != nil {
+		h.handleError(c, err, "获取关注列表失败")
+		return
+	}
+	list := make([]gin.H, len(rels))
+	for i, r := range rels {
+		list[i] = gin.H{"userId": r.FolloweeId, "followTime": r.Ctime}
+	}
+	ginx.Success(c, h.toPage(list, rels, limit))
+}
+
+// toPage 组装游标分页结果，nextCursor 为本页最后一条的关注时间
+func (h *FollowHandler) toPage(list []gin.H, rels []domain.FollowRelation, limit int) gin.H {
+	var nextCursor int64
+	if len(rels) > 0 {
+		nextCursor = rels[len(rels)-1].Ctime
+	}
+	return gin.H{
+		"users":      list,
+		"nextCursor": nextCursor,
+		"hasMore":    len(rels) == limit,
+	}
+}
+
+func (h *FollowHandler) parseCursor(c *gin.Context) (int64, int) {
+	cursor, _ := strconv.ParseInt(c.DefaultQuery("cursor", "0"), 10, 64)
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if cursor < 0 {
+		cursor = 0
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	return cursor, limit
+}
+
+func (h *FollowHandler) getIdParam(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "无效的用户ID")
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *FollowHandler) handleError(c *gin.Context, err error, msg string) {
+	switch err {
+	case domain.ErrFollowSelf:
+		ginx.Error(c, ginx.CodeInvalidParams, "不能关注自己")
+	case domain.ErrUserNotFound:
+		ginx.Error(c, ginx.CodeNotFound, "用户不存在")
+	default:
+		ginx.Error(c, ginx.CodeInternalError, msg)
+	}
+}
@@ -47,11 +47,16 @@ func ErrorWithStatus(c *gin.Context, httpStatus int, code int, msg string) {
 
 // 常用业务错误码
 const (
-	CodeSuccess        = 0
-	CodeInvalidParams  = 400001
-	CodeUnauthorized   = 401001
-	CodeForbidden      = 403001
-	CodeNotFound       = 404001
-	CodeDuplicateEmail = 409001
-	CodeInternalError  = 500001
+	CodeSuccess          = 0
+	CodeInvalidParams    = 400001
+	CodeUnauthorized     = 401001
+	CodeForbidden        = 403001
+	CodeEmailNotVerified = 403002
+	CodeAccountDisabled  = 403003
+	CodeNotFound         = 404001
+	CodeDuplicateEmail   = 409001
+	CodeDuplicateTag     = 409002
+	CodeDuplicateFolder  = 409003
+	CodeLoginLocked      = 429001
+	CodeInternalError    = 500001
 )
@@ -0,0 +1,49 @@
+package web
+
+import (
+	"net/http"
+	ports "webook/internal/ports/output"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JWKSHandler 发布验证 access token 的公钥，其他服务据此验证 token，无需共享密钥
+type JWKSHandler struct {
+	keys ports.PublicKeyProvider
+}
+
+// NewJWKSHandler 创建 JWKSHandler 实例
+func NewJWKSHandler(keys ports.PublicKeyProvider) *JWKSHandler {
+	return &JWKSHandler{keys: keys}
+}
+
+// RegisterRoutes 注册路由
+func (h *JWKSHandler) RegisterRoutes(server *gin.Engine) {
+	server.GET("/.well-known/jwks.json", h.JWKS)
+}
+
+// JWKS 按 RFC 7517 返回公钥集合，不使用统一响应格式；HS256 模式下 keys 为空
+// GET /.well-known/jwks.json
+func (h *JWKSHandler) JWKS(c *gin.Context) {
+	keys := h.keys.PublicKeys()
+	list := make([]gin.H, len(keys))
+	for i, k := range keys {
+		jwk := gin.H{
+			"kty": k.Kty,
+			"kid": k.Kid,
+			"alg": k.Alg,
+			"use": "sig",
+		}
+		if k.Kty == "RSA" {
+			jwk["n"] = k.N
+			jwk["e"] = k.E
+		} else {
+			jwk["crv"] = k.Crv
+			jwk["x"] = k.X
+		}
+		list[i] = jwk
+	}
+	// 轮换后验证方可能还缓存着旧的公钥集合，遇到未知的 kid 时应重新拉取
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, gin.H{"keys": list})
+}
@@ -3,6 +3,8 @@ package middleware
 import (
 	"net/http"
 	"strings"
+	"webook/internal/domain"
+	service "webook/internal/ports/input"
 	ports "webook/internal/ports/output"
 
 	"github.com/gin-gonic/gin"
@@ -10,13 +12,21 @@ import (
 
 type JWTMiddlewareBuilder struct {
 	verifier    ports.AccessTokenVerifier
+	sessions    service.AuthService
+	checkReads  bool
+	tokens      service.PersonalAccessTokenService
+	tickets     service.StreamService
 	ignorePaths map[string]struct{}
+	ticketPaths map[string]struct{}
+	routeScopes map[string]domain.TokenScope
 }
 
 func NewJWTMiddlewareBuilder(verifier ports.AccessTokenVerifier) *JWTMiddlewareBuilder {
 	return &JWTMiddlewareBuilder{
 		verifier:    verifier,
 		ignorePaths: make(map[string]struct{}),
+		ticketPaths: make(map[string]struct{}),
+		routeScopes: make(map[string]domain.TokenScope),
 	}
 }
 
@@ -27,6 +37,54 @@ func (b *JWTMiddlewareBuilder) IgnorePaths(paths ...string) *JWTMiddlewareBuilde
 	return b
 }
 
+// StreamTickets lets requests to these paths authenticate with a single-use
+// ticket in the ticket query parameter when there is no Authorization header,
+// since a browser EventSource cannot set request headers. Access tokens are
+// never read from the URL, where access logs and proxies would record them.
+func (b *JWTMiddlewareBuilder) StreamTickets(tickets service.StreamService, paths ...string) *JWTMiddlewareBuilder {
+	b.tickets = tickets
+	for _, path := range paths {
+		b.ticketPaths[path] = struct{}{}
+	}
+	return b
+}
+
+// CheckWrites rejects write requests (anything but GET, HEAD and OPTIONS)
+// carrying an access token whose session has been logged out. Disabling or
+// banning a user logs out all of their sessions, so they cannot keep writing
+// until their access tokens expire. It costs a Redis lookup per write.
+func (b *JWTMiddlewareBuilder) CheckWrites(sessions service.AuthService) *JWTMiddlewareBuilder {
+	b.sessions = sessions
+	return b
+}
+
+// CheckSession extends CheckWrites to every request, so logged out sessions
+// cannot read either. It costs a Redis lookup per request.
+func (b *JWTMiddlewareBuilder) CheckSession(sessions service.AuthService) *JWTMiddlewareBuilder {
+	b.sessions = sessions
+	b.checkReads = true
+	return b
+}
+
+// PersonalAccessTokens also accepts personal access tokens, told apart from
+// JWTs by their prefix. A personal access token may only call the routes
+// registered with RouteScope for one of its scopes; any other route answers
+// 403, so a leaked token cannot change the password or mint more tokens.
+func (b *JWTMiddlewareBuilder) PersonalAccessTokens(tokens service.PersonalAccessTokenService) *JWTMiddlewareBuilder {
+	b.tokens = tokens
+	return b
+}
+
+// RouteScope opens routes to personal access tokens holding scope. Routes are
+// written as "METHOD /path" with the path as registered, e.g.
+// "GET /posts/draft/:id".
+func (b *JWTMiddlewareBuilder) RouteScope(scope domain.TokenScope, routes ...string) *JWTMiddlewareBuilder {
+	for _, route := range routes {
+		b.routeScopes[route] = scope
+	}
+	return b
+}
+
 func (b *JWTMiddlewareBuilder) Build() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		path := ctx.Request.URL.Path
@@ -36,6 +94,10 @@ func (b *JWTMiddlewareBuilder) Build() gin.HandlerFunc {
 		}
 
 		authCode := ctx.GetHeader("Authorization")
+		if _, ok := b.ticketPaths[path]; ok && authCode == "" && ctx.Query("ticket") != "" {
+			b.streamTicket(ctx, ctx.Query("ticket"))
+			return
+		}
 		if authCode == "" {
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
@@ -48,13 +110,61 @@ func (b *JWTMiddlewareBuilder) Build() gin.HandlerFunc {
 		}
 
 		tokenStr := segs[1]
-		userId, err := b.verifier.Verify(tokenStr, ctx.Request.UserAgent())
+		if b.tokens != nil && strings.HasPrefix(tokenStr, domain.PersonalAccessTokenPrefix) {
+			b.personalAccessToken(ctx, tokenStr)
+			return
+		}
+		claims, err := b.verifier.Verify(tokenStr, ctx.Request.UserAgent())
 		if err != nil {
 			ctx.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
+		if b.sessions != nil && (b.checkReads || isWrite(ctx.Request.Method)) {
+			// The token itself is valid; if Redis is down let it through rather than log everyone out.
+			active, err := b.sessions.SessionActive(ctx.Request.Context(), claims.UserId, claims.SessionId, claims.IssuedAt)
+			if err == nil && !active {
+				ctx.AbortWithStatus(http.StatusUnauthorized)
+				return
+			}
+		}
 
-		ctx.Set("userId", userId)
+		ctx.Set("userId", claims.UserId)
+		ctx.Set("sessionId", claims.SessionId)
 		ctx.Next()
 	}
 }
+
+func isWrite(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	}
+	return true
+}
+
+func (b *JWTMiddlewareBuilder) streamTicket(ctx *gin.Context, ticket string) {
+	userId, err := b.tickets.RedeemTicket(ctx.Request.Context(), ticket)
+	if err != nil {
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+
+	ctx.Set("userId", userId)
+	ctx.Next()
+}
+
+func (b *JWTMiddlewareBuilder) personalAccessToken(ctx *gin.Context, tokenStr string) {
+	token, err := b.tokens.Authenticate(ctx.Request.Context(), tokenStr)
+	if err != nil {
+		ctx.AbortWithStatus(http.StatusUnauthorized)
+		return
+	}
+	scope, ok := b.routeScopes[ctx.Request.Method+" "+ctx.FullPath()]
+	if !ok || !token.HasScope(scope) {
+		ctx.AbortWithStatus(http.StatusForbidden)
+		return
+	}
+
+	ctx.Set("userId", token.UserId)
+	ctx.Next()
+}
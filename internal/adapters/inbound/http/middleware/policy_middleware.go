@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"net/http"
+	"webook/internal/adapters/inbound/http/ginx"
+	"webook/internal/domain"
+	service "webook/internal/ports/input"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PolicyMiddlewareBuilder builds per-route permission checks. It runs after
+// the JWT middleware and reads the userId it sets.
+type PolicyMiddlewareBuilder struct {
+	authz service.AuthorizationService
+}
+
+func NewPolicyMiddlewareBuilder(authz service.AuthorizationService) *PolicyMiddlewareBuilder {
+	return &PolicyMiddlewareBuilder{authz: authz}
+}
+
+// Require lets the request through only if the user's role grants perm.
+func (b *PolicyMiddlewareBuilder) Require(perm domain.Permission) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		err := b.authz.Authorize(ctx.Request.Context(), ctx.GetInt64("userId"), perm)
+		switch err {
+		case nil:
+			ctx.Next()
+		case domain.ErrUnauthorized:
+			ginx.ErrorWithStatus(ctx, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+			ctx.Abort()
+		case domain.ErrForbidden:
+			ginx.ErrorWithStatus(ctx, http.StatusForbidden, ginx.CodeForbidden, "无权访问")
+			ctx.Abort()
+		default:
+			ginx.Error(ctx, ginx.CodeInternalError, "权限检查失败")
+			ctx.Abort()
+		}
+	}
+}
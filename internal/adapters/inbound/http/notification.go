@@ -0,0 +1,146 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"webook/internal/adapters/inbound/http/ginx"
+	"webook/internal/domain"
+	service "webook/internal/ports/input"
+
+	"github.com/gin-gonic/gin"
+)
+
+// NotificationHandler 站内通知的 HTTP 请求处理，通知由统计事件消费者写入
+type NotificationHandler struct {
+	svc service.NotificationService
+}
+
+// NewNotificationHandler 创建 NotificationHandler 实例
+func NewNotificationHandler(svc service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{svc: svc}
+}
+
+// RegisterRoutes 注册路由
+func (h *NotificationHandler) RegisterRoutes(server *gin.Engine) {
+	ng := server.Group("/notifications")
+	{
+		ng.GET("", h.List)                     // 通知列表（游标分页）
+		ng.GET("/unread-count", h.UnreadCount) // 未读数
+		ng.POST("/:id/read", h.MarkRead)       // 标记一条已读
+		ng.POST("/read-all", h.MarkAllRead)    // 全部标记已读
+	}
+}
+
+// List 获取通知，按最近更新时间倒序；unread=true 时只返回未读
+// GET /notifications?cursor=0&limit=10&unread=false
+func (h *NotificationHandler) List(c *gin.Context) {
+	cursor, _ := strconv.ParseInt(c.DefaultQuery("cursor", "0"), 10, 64)
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if cursor < 0 {
+		cursor = 0
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	unreadOnly := c.Query("unread") == "true"
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	ns, err := h.svc.List(c.Request.Context(), userId, cursor, limit, unreadOnly)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "获取通知失败")
+		return
+	}
+	list := make([]gin.H, len(ns))
+	for i, n := range ns {
+		list[i] = toNotificationVO(n)
+	}
+
+	// nextCursor 为本页最后一条的更新时间
+	var nextCursor int64
+	if len(ns) > 0 {
+		nextCursor = ns[len(ns)-1].Utime
+	}
+	ginx.Success(c, gin.H{
+		"notifications": list,
+		"nextCursor":    nextCursor,
+		"hasMore":       len(ns) == limit,
+	})
+}
+
+// UnreadCount 获取未读通知数
+// GET /notifications/unread-count
+func (h *NotificationHandler) UnreadCount(c *gin.Context) {
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	count, err := h.svc.UnreadCount(c.Request.Context(), userId)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "获取未读数失败")
+		return
+	}
+	ginx.Success(c, gin.H{"count": count})
+}
+
+// MarkRead 标记一条通知为已读
+// POST /notifications/:id/read
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "无效的通知ID")
+		return
+	}
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	err = h.svc.MarkRead(c.Request.Context(), userId, id)
+	if err == domain.ErrNotificationNotFound {
+		ginx.Error(c, ginx.CodeNotFound, "通知不存在")
+		return
+	}
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "操作失败")
+		return
+	}
+	ginx.SuccessMsg(c, "已读")
+}
+
+// MarkAllRead 标记全部通知为已读
+// POST /notifications/read-all
+func (h *NotificationHandler) MarkAllRead(c *gin.Context) {
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	if err := h.svc.MarkAllRead(c.Request.Context(), userId); err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "操作失败")
+		return
+	}
+	ginx.SuccessMsg(c, "已全部标记为已读")
+}
+
+// toNotificationVO 通知的响应结构，列表和实时推送共用
+func toNotificationVO(n domain.Notification) gin.H {
+	return gin.H{
+		"id":            n.Id,
+		"kind":          n.Kind,
+		"postId":        n.PostId,
+		"latestActorId": n.LatestActorId,
+		"actorCnt":      n.ActorCnt,
+		"summary":       n.Summary(),
+		"read":          n.Read,
+		"ctime":         n.Ctime,
+		"utime":         n.Utime,
+	}
+}
@@ -0,0 +1,128 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+	"webook/internal/adapters/inbound/http/ginx"
+	"webook/internal/domain"
+	service "webook/internal/ports/input"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PersonalAccessTokenHandler 个人访问令牌的 HTTP 请求处理
+type PersonalAccessTokenHandler struct {
+	svc service.PersonalAccessTokenService
+}
+
+// NewPersonalAccessTokenHandler 创建 PersonalAccessTokenHandler 实例
+func NewPersonalAccessTokenHandler(svc service.PersonalAccessTokenService) *PersonalAccessTokenHandler {
+	return &PersonalAccessTokenHandler{svc: svc}
+}
+
+// RegisterRoutes 注册路由
+func (h *PersonalAccessTokenHandler) RegisterRoutes(server *gin.Engine) {
+	tg := server.Group("/users/me/tokens")
+	{
+		tg.GET("", h.List)          // 我的个人访问令牌
+		tg.POST("", h.Create)       // 创建令牌，明文只返回一次
+		tg.DELETE("/:id", h.Revoke) // 撤销令牌
+	}
+}
+
+// List 获取我的个人访问令牌，不含明文
+// GET /users/me/tokens
+func (h *PersonalAccessTokenHandler) List(c *gin.Context) {
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	tokens, err := h.svc.List(c.Request.Context(), userId)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "获取令牌失败")
+		return
+	}
+
+	list := make([]gin.H, len(tokens))
+	for i, t := range tokens {
+		list[i] = h.toTokenVO(t)
+	}
+	ginx.Success(c, gin.H{"tokens": list})
+}
+
+// Create 创建个人访问令牌，expiresInDays 为 0 表示永不过期
+// POST /users/me/tokens
+func (h *PersonalAccessTokenHandler) Create(c *gin.Context) {
+	type CreateReq struct {
+		Name          string              `json:"name"`
+		Scopes        []domain.TokenScope `json:"scopes"`
+		ExpiresInDays int                 `json:"expiresInDays"`
+	}
+
+	var req CreateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "参数错误")
+		return
+	}
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	expiresIn := time.Duration(req.ExpiresInDays) * 24 * time.Hour
+	t, token, err := h.svc.Create(c.Request.Context(), userId, req.Name, req.Scopes, expiresIn)
+	if err == domain.ErrInvalidAccessToken {
+		ginx.Error(c, ginx.CodeInvalidParams, "名称不能为空且不超过 64 个字符，权限范围至少一个，有效期不超过 365 天")
+		return
+	}
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "创建令牌失败")
+		return
+	}
+
+	vo := h.toTokenVO(t)
+	vo["token"] = token
+	ginx.Success(c, vo)
+}
+
+// Revoke 撤销个人访问令牌，立即失效
+// DELETE /users/me/tokens/:id
+func (h *PersonalAccessTokenHandler) Revoke(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "无效的令牌ID")
+		return
+	}
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	err = h.svc.Revoke(c.Request.Context(), userId, id)
+	if err == domain.ErrAccessTokenNotFound {
+		ginx.Error(c, ginx.CodeNotFound, "令牌不存在")
+		return
+	}
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "撤销令牌失败")
+		return
+	}
+	ginx.SuccessMsg(c, "已撤销")
+}
+
+func (h *PersonalAccessTokenHandler) toTokenVO(t domain.PersonalAccessToken) gin.H {
+	return gin.H{
+		"id":        t.Id,
+		"name":      t.Name,
+		"hint":      t.Hint,
+		"scopes":    t.Scopes,
+		"expiresAt": t.ExpiresAt,
+		"lastUsed":  t.LastUsed,
+		"ctime":     t.Ctime,
+	}
+}
@@ -1,6 +1,9 @@
 ﻿package web
 
 import (
+	"context"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
 	"webook/internal/adapters/inbound/http/ginx"
@@ -14,13 +17,15 @@ import (
 type PostHandler struct {
 	svc      service.PostService
 	statsSvc service.PostInteractionService
+	authz    service.AuthorizationService
 }
 
 // NewPostHandler 创建 PostHandler 实例
-func NewPostHandler(svc service.PostService, statsSvc service.PostInteractionService) *PostHandler {
+func NewPostHandler(svc service.PostService, statsSvc service.PostInteractionService, authz service.AuthorizationService) *PostHandler {
 	return &PostHandler{
 		svc:      svc,
 		statsSvc: statsSvc,
+		authz:    authz,
 	}
 }
 
@@ -34,6 +39,11 @@ func (h *PostHandler) RegisterRoutes(server *gin.Engine) {
 		pg.GET("/author", h.ListByAuthor) // 获取作者的帖子列表
 		pg.GET("/draft/:id", h.GetDraft)  // 获取草稿详情
 
+		// 定时发布
+		pg.POST("/:id/schedule", h.Schedule)         // 设置定时发布
+		pg.PUT("/:id/schedule", h.Reschedule)        // 修改定时发布时间
+		pg.DELETE("/:id/schedule", h.CancelSchedule) // 取消定时发布
+
 		// Reader actions (published posts)
 		pg.GET("/:id", h.GetPublished) // Get published post
 		pg.GET("", h.ListPublished)    // List published posts
@@ -46,15 +56,20 @@ func (h *PostHandler) RegisterRoutes(server *gin.Engine) {
 		// 删除
 		pg.DELETE("/:id", h.Delete)
 	}
+
+	// 我的点赞 / 我的收藏（需要登录）
+	server.GET("/users/me/likes", h.ListLiked)
+	server.GET("/users/me/collections", h.ListCollected)
 }
 
 // Save 保存帖子（创建或更新草稿）
 // POST /posts
 func (h *PostHandler) Save(c *gin.Context) {
 	type SaveReq struct {
-		Id      int64  `json:"id"` // 0 表示新建
-		Title   string `json:"title"`
-		Content string `json:"content"`
+		Id      int64    `json:"id"` // 0 表示新建
+		Title   string   `json:"title"`
+		Content string   `json:"content"`
+		Tags    []string `json:"tags"` // 不传表示不修改标签
 	}
 
 	var req SaveReq
@@ -75,7 +90,12 @@ func (h *PostHandler) Save(c *gin.Context) {
 		Title:    req.Title,
 		Content:  req.Content,
 		AuthorId: authorId,
+		Tags:     req.Tags,
 	})
+	if err == domain.ErrTagNotFound {
+		ginx.Error(c, ginx.CodeInvalidParams, "标签不存在")
+		return
+	}
 	if err != nil {
 		ginx.Error(c, ginx.CodeInternalError, "保存失败")
 		return
@@ -88,9 +108,10 @@ func (h *PostHandler) Save(c *gin.Context) {
 // POST /posts/publish
 func (h *PostHandler) Publish(c *gin.Context) {
 	type PublishReq struct {
-		Id      int64  `json:"id"`
-		Title   string `json:"title"`
-		Content string `json:"content"`
+		Id      int64    `json:"id"`
+		Title   string   `json:"title"`
+		Content string   `json:"content"`
+		Tags    []string `json:"tags"` // 不传表示沿用草稿标签
 	}
 
 	var req PublishReq
@@ -110,7 +131,24 @@ func (h *PostHandler) Publish(c *gin.Context) {
 		Title:    req.Title,
 		Content:  req.Content,
 		AuthorId: authorId,
+		Tags:     req.Tags,
 	})
+	if err == domain.ErrTagNotFound {
+		ginx.Error(c, ginx.CodeInvalidParams, "标签不存在")
+		return
+	}
+	if err == domain.ErrEmailNotVerified {
+		ginx.ErrorWithStatus(c, http.StatusForbidden, ginx.CodeEmailNotVerified, "请先验证邮箱")
+		return
+	}
+	if err == domain.ErrUserDisabled || err == domain.ErrUserBanned {
+		ginx.ErrorWithStatus(c, http.StatusForbidden, ginx.CodeAccountDisabled, err.Error())
+		return
+	}
+	if err == domain.ErrPostHidden {
+		ginx.ErrorWithStatus(c, http.StatusForbidden, ginx.CodeForbidden, "帖子已被管理员隐藏")
+		return
+	}
 	if err != nil {
 		ginx.Error(c, ginx.CodeInternalError, "发布失败")
 		return
@@ -119,7 +157,7 @@ func (h *PostHandler) Publish(c *gin.Context) {
 	ginx.Success(c, gin.H{"id": id})
 }
 
-// GetDraft 获取草稿详情（作者用）
+// GetDraft 获取草稿详情（作者用，拥有 post:read 权限的版主和管理员也可以查看）
 // GET /posts/draft/:id
 func (h *PostHandler) GetDraft(c *gin.Context) {
 	idStr := c.Param("id")
@@ -135,20 +173,29 @@ func (h *PostHandler) GetDraft(c *gin.Context) {
 		return
 	}
 
-	// 验证是否是作者本人
-	authorId := c.GetInt64("userId")
-	if post.AuthorId != authorId {
+	err = h.authz.AuthorizeOwner(c.Request.Context(), c.GetInt64("userId"), post.AuthorId, domain.PermPostRead)
+	if err == domain.ErrUnauthorized {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+	if err == domain.ErrForbidden {
 		ginx.ErrorWithStatus(c, http.StatusForbidden, ginx.CodeForbidden, "无权访问")
 		return
 	}
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "权限检查失败")
+		return
+	}
 
 	ginx.Success(c, gin.H{
-		"id":      post.Id,
-		"title":   post.Title,
-		"content": post.Content,
-		"status":  post.Status,
-		"ctime":   post.Ctime,
-		"utime":   post.Utime,
+		"id":          post.Id,
+		"title":       post.Title,
+		"content":     post.Content,
+		"tags":        post.Tags,
+		"status":      post.Status,
+		"scheduledAt": post.ScheduledAt,
+		"ctime":       post.Ctime,
+		"utime":       post.Utime,
 	})
 }
 
@@ -169,20 +216,23 @@ func (h *PostHandler) GetPublished(c *gin.Context) {
 	}
 
 	userId := c.GetInt64("userId")
-	_ = h.statsSvc.Read(c.Request.Context(), id, userId, c.ClientIP(), c.Request.UserAgent())
+	_ = h.statsSvc.Read(c.Request.Context(), id, userId, c.ClientIP(), c.Request.UserAgent(), domain.ReadProgressUnknown)
 	stats, userStats, _ := h.statsSvc.GetStats(c.Request.Context(), id, userId)
 	ginx.Success(c, gin.H{
-		"id":         post.Id,
-		"title":      post.Title,
-		"content":    post.Content,
-		"authorId":   post.AuthorId,
-		"ctime":      post.Ctime,
-		"utime":      post.Utime,
-		"likeCnt":    stats.LikeCnt,
-		"collectCnt": stats.CollectCnt,
-		"readCnt":    stats.ReadCnt,
-		"liked":      userStats.Liked,
-		"collected":  userStats.Collected,
+		"id":            post.Id,
+		"title":         post.Title,
+		"content":       post.Content,
+		"tags":          post.Tags,
+		"authorId":      post.AuthorId,
+		"ctime":         post.Ctime,
+		"utime":         post.Utime,
+		"likeCnt":       stats.LikeCnt,
+		"collectCnt":    stats.CollectCnt,
+		"readCnt":       stats.ReadCnt,
+		"commentCnt":    stats.CommentCnt,
+		"uniqueReadCnt": stats.UniqueReadCnt,
+		"liked":         userStats.Liked,
+		"collected":     userStats.Collected,
 	})
 }
 
@@ -217,15 +267,15 @@ func (h *PostHandler) ListByAuthor(c *gin.Context) {
 	}
 	statsMap, userStats, _ := h.statsSvc.GetStatsBatch(c.Request.Context(), postIds, authorId)
 	ginx.Success(c, gin.H{
-		"posts":    h.toPostVOs(posts, statsMap, userStats),
+		"posts":    toPostVOs(posts, statsMap, userStats),
 		"total":    total,
 		"page":     page,
 		"pageSize": pageSize,
 	})
 }
 
-// ListPublished 获取已发布帖子列表（公开）
-// GET /posts?page=1&pageSize=10
+// ListPublished 获取已发布帖子列表（公开），可按标签过滤
+// GET /posts?page=1&pageSize=10&tag=go
 func (h *PostHandler) ListPublished(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
@@ -237,7 +287,16 @@ func (h *PostHandler) ListPublished(c *gin.Context) {
 		pageSize = 10
 	}
 
-	posts, total, err := h.svc.ListPublished(c.Request.Context(), page, pageSize)
+	var (
+		posts []domain.Post
+		total int64
+		err   error
+	)
+	if tag := c.Query("tag"); tag != "" {
+		posts, total, err = h.svc.ListPublishedByTag(c.Request.Context(), tag, page, pageSize)
+	} else {
+		posts, total, err = h.svc.ListPublished(c.Request.Context(), page, pageSize)
+	}
 	if err != nil {
 		ginx.Error(c, ginx.CodeInternalError, "获取列表失败")
 		return
@@ -250,7 +309,56 @@ func (h *PostHandler) ListPublished(c *gin.Context) {
 	userId := c.GetInt64("userId")
 	statsMap, userStats, _ := h.statsSvc.GetStatsBatch(c.Request.Context(), postIds, userId)
 	ginx.Success(c, gin.H{
-		"posts":    h.toPostVOs(posts, statsMap, userStats),
+		"posts":    toPostVOs(posts, statsMap, userStats),
+		"total":    total,
+		"page":     page,
+		"pageSize": pageSize,
+	})
+}
+
+// ListLiked 获取我点赞的帖子，按点赞时间倒序
+// GET /users/me/likes?page=1&pageSize=10
+func (h *PostHandler) ListLiked(c *gin.Context) {
+	h.listByUser(c, h.svc.ListLikedBy)
+}
+
+// ListCollected 获取我收藏的帖子（所有收藏夹），按收藏时间倒序
+// GET /users/me/collections?page=1&pageSize=10
+func (h *PostHandler) ListCollected(c *gin.Context) {
+	h.listByUser(c, h.svc.ListCollectedBy)
+}
+
+// listByUser 分页获取与当前用户相关的已发布帖子，返回字段与 ListPublished 一致
+func (h *PostHandler) listByUser(c *gin.Context, list func(ctx context.Context, uid int64, page, pageSize int) ([]domain.Post, int64, error)) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	posts, total, err := list(c.Request.Context(), userId, page, pageSize)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "获取列表失败")
+		return
+	}
+
+	postIds := make([]int64, 0, len(posts))
+	for _, p := range posts {
+		postIds = append(postIds, p.Id)
+	}
+	statsMap, userStats, _ := h.statsSvc.GetStatsBatch(c.Request.Context(), postIds, userId)
+	ginx.Success(c, gin.H{
+		"posts":    toPostVOs(posts, statsMap, userStats),
 		"total":    total,
 		"page":     page,
 		"pageSize": pageSize,
@@ -274,6 +382,10 @@ func (h *PostHandler) Delete(c *gin.Context) {
 	}
 
 	err = h.svc.Delete(c.Request.Context(), id, authorId)
+	if err == domain.ErrPostHidden {
+		ginx.ErrorWithStatus(c, http.StatusForbidden, ginx.CodeForbidden, "帖子已被管理员隐藏")
+		return
+	}
 	if err != nil {
 		ginx.Error(c, ginx.CodeInternalError, "删除失败")
 		return
@@ -282,25 +394,122 @@ func (h *PostHandler) Delete(c *gin.Context) {
 	ginx.SuccessMsg(c, "删除成功")
 }
 
+// Schedule 设置定时发布
+// POST /posts/:id/schedule
+func (h *PostHandler) Schedule(c *gin.Context) {
+	id, authorId, scheduledAt, ok := h.parseScheduleReq(c)
+	if !ok {
+		return
+	}
+	err := h.svc.Schedule(c.Request.Context(), id, authorId, scheduledAt)
+	if err != nil {
+		h.handleScheduleError(c, err, "设置定时发布失败")
+		return
+	}
+	ginx.SuccessMsg(c, "设置成功")
+}
+
+// Reschedule 修改定时发布时间
+// PUT /posts/:id/schedule
+func (h *PostHandler) Reschedule(c *gin.Context) {
+	id, authorId, scheduledAt, ok := h.parseScheduleReq(c)
+	if !ok {
+		return
+	}
+	err := h.svc.Reschedule(c.Request.Context(), id, authorId, scheduledAt)
+	if err != nil {
+		h.handleScheduleError(c, err, "修改定时发布失败")
+		return
+	}
+	ginx.SuccessMsg(c, "修改成功")
+}
+
+// CancelSchedule 取消定时发布
+// DELETE /posts/:id/schedule
+func (h *PostHandler) CancelSchedule(c *gin.Context) {
+	id, ok := h.getPostIdParam(c)
+	if !ok {
+		return
+	}
+	authorId := c.GetInt64("userId")
+	if authorId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+	err := h.svc.CancelSchedule(c.Request.Context(), id, authorId)
+	if err != nil {
+		h.handleScheduleError(c, err, "取消定时发布失败")
+		return
+	}
+	ginx.SuccessMsg(c, "取消成功")
+}
+
+func (h *PostHandler) parseScheduleReq(c *gin.Context) (int64, int64, int64, bool) {
+	type ScheduleReq struct {
+		ScheduledAt int64 `json:"scheduledAt"` // 毫秒时间戳
+	}
+
+	id, ok := h.getPostIdParam(c)
+	if !ok {
+		return 0, 0, 0, false
+	}
+	var req ScheduleReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "参数错误")
+		return 0, 0, 0, false
+	}
+	authorId := c.GetInt64("userId")
+	if authorId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return 0, 0, 0, false
+	}
+	return id, authorId, req.ScheduledAt, true
+}
+
+func (h *PostHandler) handleScheduleError(c *gin.Context, err error, msg string) {
+	switch err {
+	case domain.ErrInvalidScheduleTime:
+		ginx.Error(c, ginx.CodeInvalidParams, "发布时间必须晚于当前时间")
+	case domain.ErrPostNotFound:
+		ginx.Error(c, ginx.CodeNotFound, "post not found")
+	case domain.ErrPostNotScheduled:
+		ginx.Error(c, ginx.CodeInvalidParams, "帖子未设置定时发布")
+	case domain.ErrPostNotDraft:
+		ginx.Error(c, ginx.CodeInvalidParams, "只有草稿可以设置定时发布")
+	case domain.ErrPostHidden:
+		ginx.ErrorWithStatus(c, http.StatusForbidden, ginx.CodeForbidden, "帖子已被管理员隐藏")
+	case domain.ErrUserDisabled, domain.ErrUserBanned:
+		ginx.ErrorWithStatus(c, http.StatusForbidden, ginx.CodeAccountDisabled, err.Error())
+	case domain.ErrEmailNotVerified:
+		ginx.ErrorWithStatus(c, http.StatusForbidden, ginx.CodeEmailNotVerified, "请先验证邮箱")
+	default:
+		ginx.Error(c, ginx.CodeInternalError, msg)
+	}
+}
+
 // toPostVOs 转换为视图对象列表
-func (h *PostHandler) toPostVOs(posts []domain.Post, stats map[int64]domain.PostStats, userStats map[int64]domain.PostUserStats) []gin.H {
+func toPostVOs(posts []domain.Post, stats map[int64]domain.PostStats, userStats map[int64]domain.PostUserStats) []gin.H {
 	result := make([]gin.H, len(posts))
 	for i, p := range posts {
 		st := stats[p.Id]
 		us := userStats[p.Id]
 		result[i] = gin.H{
-			"id":         p.Id,
-			"title":      p.Title,
-			"content":    p.Content,
-			"authorId":   p.AuthorId,
-			"status":     p.Status,
-			"ctime":      p.Ctime,
-			"utime":      p.Utime,
-			"likeCnt":    st.LikeCnt,
-			"collectCnt": st.CollectCnt,
-			"readCnt":    st.ReadCnt,
-			"liked":      us.Liked,
-			"collected":  us.Collected,
+			"id":            p.Id,
+			"title":         p.Title,
+			"content":       p.Content,
+			"tags":          p.Tags,
+			"authorId":      p.AuthorId,
+			"status":        p.Status,
+			"scheduledAt":   p.ScheduledAt,
+			"ctime":         p.Ctime,
+			"utime":         p.Utime,
+			"likeCnt":       st.LikeCnt,
+			"collectCnt":    st.CollectCnt,
+			"readCnt":       st.ReadCnt,
+			"commentCnt":    st.CommentCnt,
+			"uniqueReadCnt": st.UniqueReadCnt,
+			"liked":         us.Liked,
+			"collected":     us.Collected,
 		}
 	}
 	return result
@@ -374,13 +583,34 @@ func (h *PostHandler) Uncollect(c *gin.Context) {
 	ginx.SuccessMsg(c, "ok")
 }
 
+// Read 记录阅读，登录用户同时记录阅读历史；body 可省略，progress 为阅读进度百分比（0-100）
+// POST /posts/:id/read
 func (h *PostHandler) Read(c *gin.Context) {
+	type ReadReq struct {
+		Progress *int `json:"progress"` // 不传表示保留原有进度
+	}
+
 	id, ok := h.getPostIdParam(c)
 	if !ok {
 		return
 	}
+	var req ReadReq
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ginx.Error(c, ginx.CodeInvalidParams, "参数错误")
+		return
+	}
+	progress := domain.ReadProgressUnknown
+	if req.Progress != nil {
+		progress = *req.Progress
+	}
+
 	userId := c.GetInt64("userId")
-	if err := h.statsSvc.Read(c.Request.Context(), id, userId, c.ClientIP(), c.Request.UserAgent()); err != nil {
+	err := h.statsSvc.Read(c.Request.Context(), id, userId, c.ClientIP(), c.Request.UserAgent(), progress)
+	if err == domain.ErrInvalidReadProgress {
+		ginx.Error(c, ginx.CodeInvalidParams, "阅读进度必须在0到100之间")
+		return
+	}
+	if err != nil {
 		ginx.Error(c, ginx.CodeInternalError, "记录阅读失败")
 		return
 	}
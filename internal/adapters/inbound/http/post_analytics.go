@@ -0,0 +1,181 @@
+package web
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+	"webook/internal/adapters/inbound/http/ginx"
+	"webook/internal/domain"
+	service "webook/internal/ports/input"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PostAnalyticsHandler 作者数据分析的 HTTP 请求处理
+type PostAnalyticsHandler struct {
+	svc service.PostAnalyticsService
+}
+
+// NewPostAnalyticsHandler 创建 PostAnalyticsHandler 实例
+func NewPostAnalyticsHandler(svc service.PostAnalyticsService) *PostAnalyticsHandler {
+	return &PostAnalyticsHandler{svc: svc}
+}
+
+// RegisterRoutes 注册路由
+func (h *PostAnalyticsHandler) RegisterRoutes(server *gin.Engine) {
+	server.GET("/posts/:id/stats/daily", h.Daily)      // 单篇帖子按天统计
+	server.GET("/posts/author/dashboard", h.Dashboard) // 作者所有帖子汇总
+}
+
+// Daily 获取单篇帖子每天的互动数，format=csv 时导出 CSV
+// GET /posts/:id/stats/daily?from=2026-01-01&to=2026-01-31&format=csv
+func (h *PostAnalyticsHandler) Daily(c *gin.Context) {
+	postId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "无效的帖子ID")
+		return
+	}
+	uid, r, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	days, err := h.svc.GetDaily(c.Request.Context(), postId, uid, r)
+	if err != nil {
+		h.handleError(c, err, "获取统计失败")
+		return
+	}
+
+	if c.Query("format") == "csv" {
+		h.writeCSV(c, fmt.Sprintf("post-%d-%s-%s.csv", postId, r.From, r.To), days, true)
+		return
+	}
+	list := make([]gin.H, len(days))
+	for i, d := range days {
+		list[i] = h.toDailyVO(d)
+	}
+	ginx.Success(c, gin.H{
+		"postId": postId,
+		"from":   r.From,
+		"to":     r.To,
+		"days":   list,
+	})
+}
+
+// Dashboard 汇总作者所有帖子的互动数：区间合计、每天合计、按帖子合计（阅读数降序）
+// 独立读者数不能跨天、跨帖子相加，汇总里不返回 uniqueReadCnt
+// format=csv 时导出每天合计
+// GET /posts/author/dashboard?from=2026-01-01&to=2026-01-31&format=csv
+func (h *PostAnalyticsHandler) Dashboard(c *gin.Context) {
+	uid, r, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	dash, err := h.svc.GetDashboard(c.Request.Context(), uid, r)
+	if err != nil {
+		h.handleError(c, err, "获取统计失败")
+		return
+	}
+
+	if c.Query("format") == "csv" {
+		h.writeCSV(c, fmt.Sprintf("dashboard-%s-%s.csv", r.From, r.To), dash.Days, false)
+		return
+	}
+	days := make([]gin.H, len(dash.Days))
+	for i, d := range dash.Days {
+		days[i] = h.toDailyVO(d)
+		delete(days[i], "uniqueReadCnt")
+	}
+	posts := make([]gin.H, len(dash.Posts))
+	for i, p := range dash.Posts {
+		vo := h.toDailyVO(p.Stats)
+		delete(vo, "date")
+		delete(vo, "uniqueReadCnt")
+		vo["postId"] = p.PostId
+		vo["title"] = p.Title
+		posts[i] = vo
+	}
+	total := h.toDailyVO(dash.Total)
+	delete(total, "date")
+	delete(total, "uniqueReadCnt")
+	ginx.Success(c, gin.H{
+		"from":  dash.From,
+		"to":    dash.To,
+		"total": total,
+		"days":  days,
+		"posts": posts,
+	})
+}
+
+// parseRequest 校验登录状态并解析日期区间
+func (h *PostAnalyticsHandler) parseRequest(c *gin.Context) (int64, domain.StatsRange, bool) {
+	uid := c.GetInt64("userId")
+	if uid == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return 0, domain.StatsRange{}, false
+	}
+	r, err := domain.NewStatsRange(c.Query("from"), c.Query("to"), time.Now())
+	if err != nil {
+		h.handleError(c, err, "参数错误")
+		return 0, domain.StatsRange{}, false
+	}
+	return uid, r, true
+}
+
+func (h *PostAnalyticsHandler) toDailyVO(d domain.PostDailyStats) gin.H {
+	return gin.H{
+		"date":          d.Date,
+		"readCnt":       d.ReadCnt,
+		"likeCnt":       d.LikeCnt,
+		"unlikeCnt":     d.UnlikeCnt,
+		"collectCnt":    d.CollectCnt,
+		"uncollectCnt":  d.UncollectCnt,
+		"uniqueReadCnt": d.UniqueReadCnt,
+	}
+}
+
+// writeCSV 导出每天的互动数，withUnique 为 false 时不输出 unique_read_cnt 列
+func (h *PostAnalyticsHandler) writeCSV(c *gin.Context, filename string, days []domain.PostDailyStats, withUnique bool) {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	header := []string{"date", "read_cnt", "like_cnt", "unlike_cnt", "collect_cnt", "uncollect_cnt"}
+	if withUnique {
+		header = append(header, "unique_read_cnt")
+	}
+	_ = w.Write(header)
+	for _, d := range days {
+		row := []string{
+			d.Date,
+			strconv.FormatInt(d.ReadCnt, 10),
+			strconv.FormatInt(d.LikeCnt, 10),
+			strconv.FormatInt(d.UnlikeCnt, 10),
+			strconv.FormatInt(d.CollectCnt, 10),
+			strconv.FormatInt(d.UncollectCnt, 10),
+		}
+		if withUnique {
+			row = append(row, strconv.FormatInt(d.UniqueReadCnt, 10))
+		}
+		_ = w.Write(row)
+	}
+	w.Flush()
+}
+
+func (h *PostAnalyticsHandler) handleError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidStatsRange):
+		ginx.Error(c, ginx.CodeInvalidParams, fmt.Sprintf("日期格式为 YYYY-MM-DD，且区间不超过 %d 天", domain.MaxStatsRangeDays))
+	case errors.Is(err, domain.ErrPostNotAuthor):
+		ginx.ErrorWithStatus(c, http.StatusForbidden, ginx.CodeForbidden, "无权访问")
+	case errors.Is(err, domain.ErrPostNotFound):
+		ginx.Error(c, ginx.CodeNotFound, "post not found")
+	default:
+		ginx.Error(c, ginx.CodeInternalError, msg)
+	}
+}
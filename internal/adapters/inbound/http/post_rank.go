@@ -0,0 +1,92 @@
+package web
+
+import (
+	"strconv"
+	"webook/internal/adapters/inbound/http/ginx"
+	"webook/internal/domain"
+	service "webook/internal/ports/input"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PostRankHandler 热门帖子榜单的 HTTP 请求处理
+type PostRankHandler struct {
+	svc      service.PostRankService
+	statsSvc service.PostInteractionService
+}
+
+// NewPostRankHandler 创建 PostRankHandler 实例
+func NewPostRankHandler(svc service.PostRankService, statsSvc service.PostInteractionService) *PostRankHandler {
+	return &PostRankHandler{
+		svc:      svc,
+		statsSvc: statsSvc,
+	}
+}
+
+// RegisterRoutes 注册路由
+func (h *PostRankHandler) RegisterRoutes(server *gin.Engine) {
+	server.GET("/posts/hot", h.ListHot)
+}
+
+// ListHot 获取热榜，window 可选 daily（近 24 小时）、weekly（近 7 天，按天衰减）、all（累计）
+// GET /posts/hot?window=daily&page=1&pageSize=10
+func (h *PostRankHandler) ListHot(c *gin.Context) {
+	window := domain.HotWindow(c.DefaultQuery("window", string(domain.HotWindowDaily)))
+	if !window.Valid() {
+		ginx.Error(c, ginx.CodeInvalidParams, "window 只能是 daily、weekly 或 all")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	hots, total, err := h.svc.ListHot(c.Request.Context(), window, page, pageSize)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "获取热榜失败")
+		return
+	}
+
+	postIds := make([]int64, 0, len(hots))
+	for _, hp := range hots {
+		postIds = append(postIds, hp.Post.Id)
+	}
+	userId := c.GetInt64("userId")
+	statsMap, userStats, _ := h.statsSvc.GetStatsBatch(c.Request.Context(), postIds, userId)
+
+	list := make([]gin.H, len(hots))
+	for i, hp := range hots {
+		p := hp.Post
+		st := statsMap[p.Id]
+		us := userStats[p.Id]
+		list[i] = gin.H{
+			"id":            p.Id,
+			"title":         p.Title,
+			"content":       p.Content,
+			"tags":          p.Tags,
+			"authorId":      p.AuthorId,
+			"ctime":         p.Ctime,
+			"utime":         p.Utime,
+			"score":         hp.Score,
+			"likeCnt":       st.LikeCnt,
+			"collectCnt":    st.CollectCnt,
+			"readCnt":       st.ReadCnt,
+			"commentCnt":    st.CommentCnt,
+			"uniqueReadCnt": st.UniqueReadCnt,
+			"liked":         us.Liked,
+			"collected":     us.Collected,
+		}
+	}
+	ginx.Success(c, gin.H{
+		"window":   window,
+		"posts":    list,
+		"total":    total,
+		"page":     page,
+		"pageSize": pageSize,
+	})
+}
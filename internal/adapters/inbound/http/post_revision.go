@@ -0,0 +1,188 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"webook/internal/adapters/inbound/http/ginx"
+	"webook/internal/domain"
+	service "webook/internal/ports/input"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PostRevisionHandler 帖子修订历史相关的 HTTP 请求处理
+type PostRevisionHandler struct {
+	svc service.PostRevisionService
+}
+
+// NewPostRevisionHandler 创建 PostRevisionHandler 实例
+func NewPostRevisionHandler(svc service.PostRevisionService) *PostRevisionHandler {
+	return &PostRevisionHandler{svc: svc}
+}
+
+// RegisterRoutes 注册路由
+func (h *PostRevisionHandler) RegisterRoutes(server *gin.Engine) {
+	rg := server.Group("/posts/:id/revisions")
+	{
+		rg.GET("", h.List)                      // 修订列表
+		rg.GET("/diff", h.Diff)                 // 两个版本的行级差异
+		rg.GET("/:version", h.Get)              // 指定版本详情
+		rg.POST("/:version/restore", h.Restore) // 恢复到草稿
+	}
+}
+
+// List 获取帖子的修订列表
+// GET /posts/:id/revisions?page=1&pageSize=10
+func (h *PostRevisionHandler) List(c *gin.Context) {
+	postId, uid, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	revisions, total, err := h.svc.List(c.Request.Context(), postId, uid, page, pageSize)
+	if err != nil {
+		h.handleError(c, err, "获取修订列表失败")
+		return
+	}
+
+	list := make([]gin.H, len(revisions))
+	for i, r := range revisions {
+		list[i] = gin.H{
+			"version": r.Version,
+			"title":   r.Title,
+			"status":  r.Status,
+			"ctime":   r.Ctime,
+		}
+	}
+	ginx.Success(c, gin.H{
+		"revisions": list,
+		"total":     total,
+		"page":      page,
+		"pageSize":  pageSize,
+	})
+}
+
+// Get 获取指定版本详情
+// GET /posts/:id/revisions/:version
+func (h *PostRevisionHandler) Get(c *gin.Context) {
+	postId, uid, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+	version, err := strconv.ParseInt(c.Param("version"), 10, 64)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "无效的版本号")
+		return
+	}
+
+	r, err := h.svc.Get(c.Request.Context(), postId, uid, version)
+	if err != nil {
+		h.handleError(c, err, "获取修订失败")
+		return
+	}
+
+	ginx.Success(c, gin.H{
+		"version": r.Version,
+		"title":   r.Title,
+		"content": r.Content,
+		"status":  r.Status,
+		"ctime":   r.Ctime,
+	})
+}
+
+// Diff 对比两个版本
+// GET /posts/:id/revisions/diff?from=1&to=2
+func (h *PostRevisionHandler) Diff(c *gin.Context) {
+	postId, uid, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+	from, err := strconv.ParseInt(c.Query("from"), 10, 64)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "无效的版本号")
+		return
+	}
+	to, err := strconv.ParseInt(c.Query("to"), 10, 64)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "无效的版本号")
+		return
+	}
+
+	lines, err := h.svc.Diff(c.Request.Context(), postId, uid, from, to)
+	if err != nil {
+		h.handleError(c, err, "对比失败")
+		return
+	}
+
+	list := make([]gin.H, len(lines))
+	for i, l := range lines {
+		list[i] = gin.H{
+			"op":   l.Op,
+			"text": l.Text,
+		}
+	}
+	ginx.Success(c, gin.H{
+		"from":  from,
+		"to":    to,
+		"lines": list,
+	})
+}
+
+// Restore 将指定版本恢复为草稿（不会自动发布）
+// POST /posts/:id/revisions/:version/restore
+func (h *PostRevisionHandler) Restore(c *gin.Context) {
+	postId, uid, ok := h.parseRequest(c)
+	if !ok {
+		return
+	}
+	version, err := strconv.ParseInt(c.Param("version"), 10, 64)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "无效的版本号")
+		return
+	}
+
+	if err := h.svc.Restore(c.Request.Context(), postId, uid, version); err != nil {
+		h.handleError(c, err, "恢复失败")
+		return
+	}
+
+	ginx.SuccessMsg(c, "恢复成功")
+}
+
+// parseRequest 解析帖子ID并校验登录状态
+func (h *PostRevisionHandler) parseRequest(c *gin.Context) (int64, int64, bool) {
+	postId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "无效的帖子ID")
+		return 0, 0, false
+	}
+	uid := c.GetInt64("userId")
+	if uid == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return 0, 0, false
+	}
+	return postId, uid, true
+}
+
+func (h *PostRevisionHandler) handleError(c *gin.Context, err error, msg string) {
+	switch {
+	case errors.Is(err, domain.ErrPostNotAuthor):
+		ginx.ErrorWithStatus(c, http.StatusForbidden, ginx.CodeForbidden, "无权访问")
+	case errors.Is(err, domain.ErrPostNotFound):
+		ginx.Error(c, ginx.CodeNotFound, "post not found")
+	case errors.Is(err, domain.ErrPostRevisionNotFound):
+		ginx.Error(c, ginx.CodeNotFound, "revision not found")
+	default:
+		ginx.Error(c, ginx.CodeInternalError, msg)
+	}
+}
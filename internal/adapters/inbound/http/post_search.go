@@ -0,0 +1,89 @@
+package web
+
+import (
+	"strconv"
+	"strings"
+	"webook/internal/adapters/inbound/http/ginx"
+	service "webook/internal/ports/input"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PostSearchHandler 帖子全文检索的 HTTP 请求处理
+type PostSearchHandler struct {
+	svc      service.PostSearchService
+	statsSvc service.PostInteractionService
+}
+
+// NewPostSearchHandler 创建 PostSearchHandler 实例
+func NewPostSearchHandler(svc service.PostSearchService, statsSvc service.PostInteractionService) *PostSearchHandler {
+	return &PostSearchHandler{
+		svc:      svc,
+		statsSvc: statsSvc,
+	}
+}
+
+// RegisterRoutes 注册路由
+func (h *PostSearchHandler) RegisterRoutes(server *gin.Engine) {
+	server.GET("/posts/search", h.Search)
+}
+
+// Search 检索已发布帖子
+// GET /posts/search?q=关键词&page=1&pageSize=10&boost=true
+func (h *PostSearchHandler) Search(c *gin.Context) {
+	q := strings.TrimSpace(c.Query("q"))
+	if q == "" {
+		ginx.Error(c, ginx.CodeInvalidParams, "请输入搜索关键词")
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("pageSize", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+	boost := c.Query("boost") == "true"
+
+	hits, total, err := h.svc.Search(c.Request.Context(), q, page, pageSize, boost)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "搜索失败")
+		return
+	}
+
+	postIds := make([]int64, 0, len(hits))
+	for _, hit := range hits {
+		postIds = append(postIds, hit.PostId)
+	}
+	userId := c.GetInt64("userId")
+	statsMap, userStats, _ := h.statsSvc.GetStatsBatch(c.Request.Context(), postIds, userId)
+
+	list := make([]gin.H, len(hits))
+	for i, hit := range hits {
+		st := statsMap[hit.PostId]
+		us := userStats[hit.PostId]
+		list[i] = gin.H{
+			"id":            hit.PostId,
+			"title":         hit.Title,
+			"snippet":       hit.Snippet,
+			"authorId":      hit.AuthorId,
+			"utime":         hit.Utime,
+			"score":         hit.Score,
+			"likeCnt":       st.LikeCnt,
+			"collectCnt":    st.CollectCnt,
+			"readCnt":       st.ReadCnt,
+			"commentCnt":    st.CommentCnt,
+			"uniqueReadCnt": st.UniqueReadCnt,
+			"liked":         us.Liked,
+			"collected":     us.Collected,
+		}
+	}
+	ginx.Success(c, gin.H{
+		"posts":    list,
+		"total":    total,
+		"page":     page,
+		"pageSize": pageSize,
+	})
+}
@@ -0,0 +1,142 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"webook/internal/adapters/inbound/http/ginx"
+	"webook/internal/domain"
+	service "webook/internal/ports/input"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReadHistoryHandler 阅读历史的 HTTP 请求处理，记录由 POST /posts/:id/read 写入
+type ReadHistoryHandler struct {
+	svc      service.ReadHistoryService
+	statsSvc service.PostInteractionService
+}
+
+// NewReadHistoryHandler 创建 ReadHistoryHandler 实例
+func NewReadHistoryHandler(svc service.ReadHistoryService, statsSvc service.PostInteractionService) *ReadHistoryHandler {
+	return &ReadHistoryHandler{
+		svc:      svc,
+		statsSvc: statsSvc,
+	}
+}
+
+// RegisterRoutes 注册路由
+func (h *ReadHistoryHandler) RegisterRoutes(server *gin.Engine) {
+	hg := server.Group("/users/me/history")
+	{
+		hg.GET("", h.List)              // 阅读历史（游标分页）
+		hg.GET("/continue", h.Continue) // 继续阅读：未读完的帖子（游标分页）
+		hg.DELETE("", h.Clear)          // 清空阅读历史
+		hg.DELETE("/:postId", h.Delete) // 删除一条阅读历史
+	}
+}
+
+// List 获取阅读历史，按最近阅读时间倒序
+// GET /users/me/history?cursor=0&cursorId=0&limit=10
+func (h *ReadHistoryHandler) List(c *gin.Context) {
+	h.list(c, false)
+}
+
+// Continue 获取未读完的帖子，按最近阅读时间倒序
+// GET /users/me/history/continue?cursor=0&cursorId=0&limit=10
+func (h *ReadHistoryHandler) Continue(c *gin.Context) {
+	h.list(c, true)
+}
+
+// Delete 删除一条阅读历史
+// DELETE /users/me/history/:postId
+func (h *ReadHistoryHandler) Delete(c *gin.Context) {
+	postId, err := strconv.ParseInt(c.Param("postId"), 10, 64)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "无效的帖子ID")
+		return
+	}
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	err = h.svc.Delete(c.Request.Context(), userId, postId)
+	if err == domain.ErrReadHistoryNotFound {
+		ginx.Error(c, ginx.CodeNotFound, "阅读记录不存在")
+		return
+	}
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "删除失败")
+		return
+	}
+	ginx.SuccessMsg(c, "删除成功")
+}
+
+// Clear 清空阅读历史
+// DELETE /users/me/history
+func (h *ReadHistoryHandler) Clear(c *gin.Context) {
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	if err := h.svc.Clear(c.Request.Context(), userId); err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "清空失败")
+		return
+	}
+	ginx.SuccessMsg(c, "清空成功")
+}
+
+func (h *ReadHistoryHandler) list(c *gin.Context, unfinished bool) {
+	cursor, _ := strconv.ParseInt(c.DefaultQuery("cursor", "0"), 10, 64)
+	cursorId, _ := strconv.ParseInt(c.DefaultQuery("cursorId", "0"), 10, 64)
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if cursor < 0 {
+		cursor = 0
+	}
+	if limit < 1 || limit > 100 {
+		limit = 10
+	}
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	histories, err := h.svc.List(c.Request.Context(), userId, domain.ReadHistoryCursor{ReadAt: cursor, Id: cursorId}, limit, unfinished)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "获取阅读历史失败")
+		return
+	}
+
+	posts := make([]domain.Post, len(histories))
+	for i, hi := range histories {
+		posts[i] = hi.Post
+	}
+	postIds := make([]int64, 0, len(posts))
+	for _, p := range posts {
+		postIds = append(postIds, p.Id)
+	}
+	statsMap, userStats, _ := h.statsSvc.GetStatsBatch(c.Request.Context(), postIds, userId)
+	list := toPostVOs(posts, statsMap, userStats)
+	for i, hi := range histories {
+		list[i]["progress"] = hi.Progress
+		list[i]["finished"] = hi.Finished()
+		list[i]["readAt"] = hi.ReadAt
+	}
+
+	// nextCursor/nextCursorId 为本页最后一条的阅读时间与记录ID
+	var next domain.ReadHistoryCursor
+	if len(histories) > 0 {
+		last := histories[len(histories)-1]
+		next = domain.ReadHistoryCursor{ReadAt: last.ReadAt, Id: last.Id}
+	}
+	ginx.Success(c, gin.H{
+		"posts":        list,
+		"nextCursor":   next.ReadAt,
+		"nextCursorId": next.Id,
+		"hasMore":      len(histories) == limit,
+	})
+}
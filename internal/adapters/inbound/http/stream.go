@@ -0,0 +1,186 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"webook/internal/adapters/inbound/http/ginx"
+	"webook/internal/domain"
+	service "webook/internal/ports/input"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// streamHeartbeat 心跳间隔，防止空闲连接被代理或负载均衡断开
+	streamHeartbeat = 15 * time.Second
+	// streamRetry 建议客户端断线后的重连间隔（毫秒）
+	streamRetry = 3000
+	// maxStreamPosts 一个连接最多订阅的帖子数
+	maxStreamPosts = 100
+)
+
+// StreamHandler 实时推送的 HTTP 请求处理，以 Server-Sent Events 推送计数变化和新通知
+type StreamHandler struct {
+	svc      service.StreamService
+	statsSvc service.PostInteractionService
+}
+
+// NewStreamHandler 创建 StreamHandler 实例
+func NewStreamHandler(svc service.StreamService, statsSvc service.PostInteractionService) *StreamHandler {
+	return &StreamHandler{
+		svc:      svc,
+		statsSvc: statsSvc,
+	}
+}
+
+// RegisterRoutes 注册路由
+func (h *StreamHandler) RegisterRoutes(server *gin.Engine) {
+	server.GET("/stream", h.Stream)              // 实时事件流（SSE）
+	server.POST("/stream/ticket", h.IssueTicket) // 换取建立连接用的一次性票据
+}
+
+// IssueTicket 签发一次性连接票据，有效期 30 秒。浏览器 EventSource 无法设置请求头，
+// 先带 Authorization 请求头换票，再用 GET /stream?ticket= 建立连接
+// POST /stream/ticket
+func (h *StreamHandler) IssueTicket(c *gin.Context) {
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	ticket, err := h.svc.IssueTicket(c.Request.Context(), userId)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "签发票据失败")
+		return
+	}
+	ginx.Success(c, gin.H{
+		"ticket":    ticket,
+		"expiresIn": int(domain.StreamTicketTTL.Seconds()),
+	})
+}
+
+// Stream 建立 SSE 连接：先推送订阅帖子的当前计数，之后推送计数变化（stats 事件）
+// 和当前用户的新通知（notification 事件），空闲时每 15 秒发送一次心跳注释。
+// 断线重连时客户端带上 Last-Event-ID 请求头（或 lastEventId 参数），补发期间错过的通知
+// 浏览器 EventSource 无法设置请求头时，先用 POST /stream/ticket 换取一次性票据
+// GET /stream?postIds=1,2,3&ticket=xxx
+func (h *StreamHandler) Stream(c *gin.Context) {
+	postIds, err := parseIdList(c.Query("postIds"))
+	if err != nil || len(postIds) > maxStreamPosts {
+		ginx.Error(c, ginx.CodeInvalidParams, "无效的帖子ID列表")
+		return
+	}
+	lastIdStr := c.GetHeader("Last-Event-ID")
+	if lastIdStr == "" {
+		lastIdStr = c.Query("lastEventId")
+	}
+	lastEventId, _ := strconv.ParseInt(lastIdStr, 10, 64)
+	userId := c.GetInt64("userId")
+	if userId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	ctx := c.Request.Context()
+	events, err := h.svc.Subscribe(ctx, userId, lastEventId, postIds)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "建立实时连接失败")
+		return
+	}
+	statsMap := map[int64]domain.PostStats{}
+	if len(postIds) > 0 {
+		statsMap, _, err = h.statsSvc.GetStatsBatch(ctx, postIds, userId)
+		if err != nil {
+			ginx.Error(c, ginx.CodeInternalError, "获取帖子统计失败")
+			return
+		}
+	}
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no") // 关闭 Nginx 缓冲
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", streamRetry)
+	for _, id := range postIds {
+		if stats, ok := statsMap[id]; ok {
+			writeStreamEvent(c.Writer, domain.StreamEvent{Type: domain.StreamEventStats, PostId: id, Stats: stats})
+		}
+	}
+	c.Writer.Flush()
+
+	ticker := time.NewTicker(streamHeartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			writeStreamEvent(c.Writer, event)
+		case <-ticker.C:
+			fmt.Fprint(c.Writer, ": ping\n\n")
+		}
+		c.Writer.Flush()
+	}
+}
+
+// writeStreamEvent 按 SSE 格式写出一个事件。经 StreamBroker.Publish 发布的事件都带有 ID；
+// 只有连接建立时直接写出的计数快照 Id 为 0，不带 id 字段，不影响客户端的 Last-Event-ID
+func writeStreamEvent(w io.Writer, event domain.StreamEvent) {
+	var data any
+	switch event.Type {
+	case domain.StreamEventStats:
+		data = gin.H{
+			"postId":     event.PostId,
+			"likeCnt":    event.Stats.LikeCnt,
+			"collectCnt": event.Stats.CollectCnt,
+			"readCnt":    event.Stats.ReadCnt,
+			"commentCnt": event.Stats.CommentCnt,
+		}
+	case domain.StreamEventNotification:
+		data = toNotificationVO(event.Notification)
+	default:
+		return
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	if event.Id > 0 {
+		fmt.Fprintf(w, "id: %d\n", event.Id)
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, payload)
+}
+
+// parseIdList 解析逗号分隔的ID列表，忽略空项和重复项
+func parseIdList(s string) ([]int64, error) {
+	ids := []int64{}
+	seen := map[int64]struct{}{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("invalid id %q", part)
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
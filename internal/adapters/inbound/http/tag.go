@@ -0,0 +1,142 @@
+package web
+
+import (
+	"net/http"
+	"strconv"
+	"webook/internal/adapters/inbound/http/ginx"
+	"webook/internal/adapters/inbound/http/middleware"
+	"webook/internal/domain"
+	service "webook/internal/ports/input"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TagHandler 标签相关的 HTTP 请求处理
+type TagHandler struct {
+	svc    service.TagService
+	policy *middleware.PolicyMiddlewareBuilder
+}
+
+// NewTagHandler 创建 TagHandler 实例
+func NewTagHandler(svc service.TagService, policy *middleware.PolicyMiddlewareBuilder) *TagHandler {
+	return &TagHandler{svc: svc, policy: policy}
+}
+
+// RegisterRoutes 注册路由，标签是全站共享的，增删改需要标签管理权限
+func (h *TagHandler) RegisterRoutes(server *gin.Engine) {
+	manage := h.policy.Require(domain.PermTagManage)
+	tg := server.Group("/tags")
+	{
+		tg.GET("", h.List)                  // 标签列表（含已发布帖子数）
+		tg.POST("", manage, h.Create)       // 创建标签
+		tg.PUT("/:id", manage, h.Rename)    // 修改标签名
+		tg.DELETE("/:id", manage, h.Delete) // 删除标签
+	}
+}
+
+// List 获取所有标签及其已发布帖子数
+// GET /tags
+func (h *TagHandler) List(c *gin.Context) {
+	tags, err := h.svc.ListWithPostCount(c.Request.Context())
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "获取标签失败")
+		return
+	}
+
+	list := make([]gin.H, len(tags))
+	for i, t := range tags {
+		list[i] = gin.H{
+			"id":      t.Id,
+			"name":    t.Name,
+			"postCnt": t.PostCnt,
+		}
+	}
+	ginx.Success(c, gin.H{"tags": list})
+}
+
+// Create 创建标签
+// POST /tags
+func (h *TagHandler) Create(c *gin.Context) {
+	type CreateReq struct {
+		Name string `json:"name"`
+	}
+
+	var req CreateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "参数错误")
+		return
+	}
+	if c.GetInt64("userId") == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	id, err := h.svc.Create(c.Request.Context(), req.Name)
+	if err != nil {
+		h.handleError(c, err, "创建标签失败")
+		return
+	}
+	ginx.Success(c, gin.H{"id": id})
+}
+
+// Rename 修改标签名
+// PUT /tags/:id
+func (h *TagHandler) Rename(c *gin.Context) {
+	type RenameReq struct {
+		Name string `json:"name"`
+	}
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "无效的标签ID")
+		return
+	}
+	var req RenameReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "参数错误")
+		return
+	}
+	if c.GetInt64("userId") == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	if err := h.svc.Rename(c.Request.Context(), id, req.Name); err != nil {
+		h.handleError(c, err, "修改标签失败")
+		return
+	}
+	ginx.SuccessMsg(c, "修改成功")
+}
+
+// Delete 删除标签（同时解除与帖子的关联）
+// DELETE /tags/:id
+func (h *TagHandler) Delete(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "无效的标签ID")
+		return
+	}
+	if c.GetInt64("userId") == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "请先登录")
+		return
+	}
+
+	if err := h.svc.Delete(c.Request.Context(), id); err != nil {
+		h.handleError(c, err, "删除标签失败")
+		return
+	}
+	ginx.SuccessMsg(c, "删除成功")
+}
+
+func (h *TagHandler) handleError(c *gin.Context, err error, msg string) {
+	switch err {
+	case domain.ErrInvalidTagName:
+		ginx.Error(c, ginx.CodeInvalidParams, "标签名不能为空且不超过32个字符")
+	case domain.ErrDuplicateTag:
+		ginx.Error(c, ginx.CodeDuplicateTag, "标签已存在")
+	case domain.ErrTagNotFound:
+		ginx.Error(c, ginx.CodeNotFound, "tag not found")
+	default:
+		ginx.Error(c, ginx.CodeInternalError, msg)
+	}
+}
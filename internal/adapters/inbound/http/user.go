@@ -1,6 +1,9 @@
 package web
 
 import (
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
 	"strconv"
 	"webook/internal/domain"
@@ -15,11 +18,13 @@ import (
 type UserHandler struct {
 	svc         service.UserService
 	auth        service.AuthService
+	account     service.AccountService
+	authz       service.AuthorizationService
 	emailExp    *regexp.Regexp
 	passwordExp *regexp.Regexp
 }
 
-func NewUserHandler(svc service.UserService, auth service.AuthService) *UserHandler {
+func NewUserHandler(svc service.UserService, auth service.AuthService, account service.AccountService, authz service.AuthorizationService) *UserHandler {
 	const (
 		emailRegex    = `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
 		passwordRegex = `^.{6,16}$`
@@ -27,6 +32,8 @@ func NewUserHandler(svc service.UserService, auth service.AuthService) *UserHand
 	return &UserHandler{
 		svc:         svc,
 		auth:        auth,
+		account:     account,
+		authz:       authz,
 		emailExp:    regexp.MustCompile(emailRegex, regexp.None),
 		passwordExp: regexp.MustCompile(passwordRegex, regexp.None),
 	}
@@ -38,9 +45,18 @@ func (u *UserHandler) RegisterRoutes(server *gin.Engine) {
 	ug.POST("/login", u.Login)
 	ug.GET("/:id", u.Profile)
 	ug.PUT("/:id/password", u.EditPassword)
+	ug.PUT("/me/profile", u.EditProfile)
+	ug.GET("/:id/public", u.PublicProfile)
+	ug.POST("/verify-email", u.VerifyEmail)
+	ug.POST("/verify-email/resend", u.ResendVerification)
+	ug.POST("/password/forgot", u.ForgotPassword)
+	ug.POST("/password/reset", u.ResetPassword)
+	ug.GET("/me/sessions", u.Sessions)
+	ug.DELETE("/me/sessions/:ssid", u.RevokeSession)
 
 	server.POST("/auth/refresh", u.RefreshToken)
 	server.POST("/auth/logout", u.Logout)
+	server.POST("/auth/logout-all", u.LogoutAll)
 }
 
 // POST /users
@@ -95,7 +111,10 @@ func (u *UserHandler) SignUp(c *gin.Context) {
 		return
 	}
 
-	ginx.SuccessMsg(c, "signup success")
+	// The account exists already; if the mail fails the user can ask for it again.
+	_ = u.account.SendVerification(c.Request.Context(), req.Email)
+
+	ginx.SuccessMsg(c, "signup success, please check your email to verify the address")
 }
 
 // POST /users/login
@@ -111,26 +130,45 @@ func (u *UserHandler) Login(c *gin.Context) {
 		return
 	}
 
-	user, err := u.svc.Login(c.Request.Context(), req.Email, req.Password)
+	user, err := u.svc.Login(c.Request.Context(), req.Email, req.Password, domain.LoginClient{
+		IP:        c.ClientIP(),
+		UserAgent: c.GetHeader("User-Agent"),
+	})
+	var locked *domain.LoginLockedError
+	if errors.As(err, &locked) {
+		retryAfter := int64(math.Ceil(locked.RetryAfter.Seconds()))
+		c.Header("Retry-After", strconv.FormatInt(retryAfter, 10))
+		ginx.ErrorWithStatus(c, http.StatusTooManyRequests, ginx.CodeLoginLocked,
+			fmt.Sprintf("too many failed attempts, retry after %d seconds", retryAfter))
+		return
+	}
 	if err == domain.ErrInvalidUserOrPassword {
 		ginx.Error(c, ginx.CodeUnauthorized, "invalid credentials")
 		return
 	}
+	if err == domain.ErrUserDisabled || err == domain.ErrUserBanned {
+		ginx.ErrorWithStatus(c, http.StatusForbidden, ginx.CodeAccountDisabled, err.Error())
+		return
+	}
 	if err != nil {
 		ginx.Error(c, ginx.CodeInternalError, "login failed")
 		return
 	}
 
-	accessToken, refreshToken, err := u.auth.GenerateTokenPair(c.Request.Context(), user.Id, c.GetHeader("User-Agent"))
+	accessToken, refreshToken, err := u.auth.GenerateTokenPair(c.Request.Context(), user.Id, domain.LoginClient{
+		IP:        c.ClientIP(),
+		UserAgent: c.GetHeader("User-Agent"),
+	})
 	if err != nil {
 		ginx.Error(c, ginx.CodeInternalError, "token generate failed")
 		return
 	}
 
 	ginx.Success(c, gin.H{
-		"userId":       user.Id,
-		"accessToken":  accessToken,
-		"refreshToken": refreshToken,
+		"userId":        user.Id,
+		"emailVerified": user.EmailVerified,
+		"accessToken":   accessToken,
+		"refreshToken":  refreshToken,
 	})
 }
 
@@ -146,7 +184,10 @@ func (u *UserHandler) RefreshToken(c *gin.Context) {
 		return
 	}
 
-	accessToken, err := u.auth.RefreshAccessToken(c.Request.Context(), req.RefreshToken, c.GetHeader("User-Agent"))
+	accessToken, refreshToken, err := u.auth.RefreshAccessToken(c.Request.Context(), req.RefreshToken, domain.LoginClient{
+		IP:        c.ClientIP(),
+		UserAgent: c.GetHeader("User-Agent"),
+	})
 	if err == domain.ErrUnauthorized {
 		ginx.Error(c, ginx.CodeUnauthorized, "unauthorized")
 		return
@@ -157,7 +198,8 @@ func (u *UserHandler) RefreshToken(c *gin.Context) {
 	}
 
 	ginx.Success(c, gin.H{
-		"accessToken": accessToken,
+		"accessToken":  accessToken,
+		"refreshToken": refreshToken,
 	})
 }
 
@@ -190,21 +232,107 @@ func (u *UserHandler) Profile(c *gin.Context) {
 		return
 	}
 
-	currentUserId := c.GetInt64("userId")
-	if currentUserId != id {
+	// Users may read their own profile; moderators and admins may read anyone's.
+	err = u.authz.AuthorizeOwner(c.Request.Context(), c.GetInt64("userId"), id, domain.PermUserRead)
+	if err == domain.ErrUnauthorized {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "unauthorized")
+		return
+	}
+	if err == domain.ErrForbidden {
 		ginx.ErrorWithStatus(c, http.StatusForbidden, ginx.CodeForbidden, "forbidden")
 		return
 	}
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "authorization failed")
+		return
+	}
 
 	user, err := u.svc.Profile(c.Request.Context(), id)
 	if err != nil {
 		ginx.Error(c, ginx.CodeNotFound, "user not found")
 		return
 	}
+	role, _ := u.authz.Role(c.Request.Context(), id)
 
 	ginx.Success(c, gin.H{
-		"id":    user.Id,
-		"email": user.Email,
+		"id":            user.Id,
+		"email":         user.Email,
+		"emailVerified": user.EmailVerified,
+		"nickname":      user.Nickname,
+		"avatar":        user.Avatar,
+		"bio":           user.Bio,
+		"birthday":      user.Birthday,
+		"role":          role,
+		"ctime":         user.Ctime,
+	})
+}
+
+// PUT /users/me/profile
+func (u *UserHandler) EditProfile(c *gin.Context) {
+	type EditProfileReq struct {
+		Nickname string `json:"nickname"`
+		Avatar   string `json:"avatar"`
+		Bio      string `json:"bio"`
+		Birthday string `json:"birthday"`
+	}
+
+	var req EditProfileReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "invalid params")
+		return
+	}
+
+	currentUserId := c.GetInt64("userId")
+	if currentUserId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "unauthorized")
+		return
+	}
+
+	err := u.svc.UpdateProfile(c.Request.Context(), domain.User{
+		Id:       currentUserId,
+		Nickname: req.Nickname,
+		Avatar:   req.Avatar,
+		Bio:      req.Bio,
+		Birthday: req.Birthday,
+	})
+	if err == domain.ErrInvalidProfile {
+		ginx.Error(c, ginx.CodeInvalidParams, "invalid profile: nickname up to 24 chars, bio up to 200 chars, avatar must be an http(s) URL, birthday as YYYY-MM-DD")
+		return
+	}
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "update failed")
+		return
+	}
+
+	ginx.SuccessMsg(c, "update success")
+}
+
+// GET /users/:id/public
+func (u *UserHandler) PublicProfile(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "invalid id")
+		return
+	}
+
+	profile, err := u.svc.PublicProfile(c.Request.Context(), id)
+	if err == domain.ErrUserNotFound {
+		ginx.Error(c, ginx.CodeNotFound, "user not found")
+		return
+	}
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "get profile failed")
+		return
+	}
+
+	ginx.Success(c, gin.H{
+		"id":       profile.Id,
+		"nickname": profile.Nickname,
+		"avatar":   profile.Avatar,
+		"bio":      profile.Bio,
+		"ctime":    profile.Ctime,
+		"postCnt":  profile.PostCnt,
+		"likeCnt":  profile.LikeCnt,
 	})
 }
 
@@ -256,3 +384,182 @@ func (u *UserHandler) EditPassword(c *gin.Context) {
 
 	ginx.SuccessMsg(c, "update success")
 }
+
+// POST /users/verify-email
+func (u *UserHandler) VerifyEmail(c *gin.Context) {
+	type VerifyEmailReq struct {
+		Token string `json:"token"`
+	}
+
+	var req VerifyEmailReq
+	if err := c.ShouldBindJSON(&req); err != nil || req.Token == "" {
+		ginx.Error(c, ginx.CodeInvalidParams, "invalid params")
+		return
+	}
+
+	err := u.account.VerifyEmail(c.Request.Context(), req.Token)
+	if err == domain.ErrInvalidAccountToken {
+		ginx.Error(c, ginx.CodeInvalidParams, "invalid or expired token")
+		return
+	}
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "verify failed")
+		return
+	}
+
+	ginx.SuccessMsg(c, "email verified")
+}
+
+// POST /users/verify-email/resend
+func (u *UserHandler) ResendVerification(c *gin.Context) {
+	currentUserId := c.GetInt64("userId")
+	if currentUserId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "unauthorized")
+		return
+	}
+
+	user, err := u.svc.Profile(c.Request.Context(), currentUserId)
+	if err != nil {
+		ginx.Error(c, ginx.CodeNotFound, "user not found")
+		return
+	}
+	if err = u.account.SendVerification(c.Request.Context(), user.Email); err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "send failed")
+		return
+	}
+
+	ginx.SuccessMsg(c, "verification email sent if the address is not verified yet")
+}
+
+// POST /users/password/forgot
+func (u *UserHandler) ForgotPassword(c *gin.Context) {
+	type ForgotPasswordReq struct {
+		Email string `json:"email"`
+	}
+
+	var req ForgotPasswordReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		ginx.Error(c, ginx.CodeInvalidParams, "invalid params")
+		return
+	}
+
+	ok, err := u.emailExp.MatchString(req.Email)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "regex error")
+		return
+	}
+	if !ok {
+		ginx.Error(c, ginx.CodeInvalidParams, "invalid email")
+		return
+	}
+
+	if err = u.account.ForgotPassword(c.Request.Context(), req.Email); err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "send failed")
+		return
+	}
+
+	// Same answer whether or not the email is registered.
+	ginx.SuccessMsg(c, "if the email is registered, a reset link has been sent")
+}
+
+// POST /users/password/reset
+func (u *UserHandler) ResetPassword(c *gin.Context) {
+	type ResetPasswordReq struct {
+		Token       string `json:"token"`
+		NewPassword string `json:"newPassword"`
+	}
+
+	var req ResetPasswordReq
+	if err := c.ShouldBindJSON(&req); err != nil || req.Token == "" {
+		ginx.Error(c, ginx.CodeInvalidParams, "invalid params")
+		return
+	}
+
+	ok, err := u.passwordExp.MatchString(req.NewPassword)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "regex error")
+		return
+	}
+	if !ok {
+		ginx.Error(c, ginx.CodeInvalidParams, "invalid password")
+		return
+	}
+
+	err = u.account.ResetPassword(c.Request.Context(), req.Token, req.NewPassword)
+	if err == domain.ErrInvalidAccountToken {
+		ginx.Error(c, ginx.CodeInvalidParams, "invalid or expired token")
+		return
+	}
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "reset failed")
+		return
+	}
+
+	ginx.SuccessMsg(c, "password reset, please log in again")
+}
+
+// GET /users/me/sessions
+func (u *UserHandler) Sessions(c *gin.Context) {
+	currentUserId := c.GetInt64("userId")
+	if currentUserId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "unauthorized")
+		return
+	}
+
+	sessions, err := u.auth.Sessions(c.Request.Context(), currentUserId)
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "get sessions failed")
+		return
+	}
+
+	currentSessionId := c.GetString("sessionId")
+	list := make([]gin.H, len(sessions))
+	for i, s := range sessions {
+		list[i] = gin.H{
+			"ssid":      s.Id,
+			"userAgent": s.UserAgent,
+			"ip":        s.IP,
+			"ctime":     s.Ctime,
+			"lastUsed":  s.LastUsed,
+			"current":   s.Id == currentSessionId,
+		}
+	}
+	ginx.Success(c, gin.H{"sessions": list})
+}
+
+// DELETE /users/me/sessions/:ssid
+func (u *UserHandler) RevokeSession(c *gin.Context) {
+	currentUserId := c.GetInt64("userId")
+	if currentUserId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "unauthorized")
+		return
+	}
+
+	err := u.auth.RevokeSession(c.Request.Context(), currentUserId, c.GetString("sessionId"), c.Param("ssid"))
+	if err == domain.ErrSessionNotFound {
+		ginx.Error(c, ginx.CodeNotFound, "session not found")
+		return
+	}
+	if err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "revoke session failed")
+		return
+	}
+
+	ginx.SuccessMsg(c, "session revoked")
+}
+
+// POST /auth/logout-all
+func (u *UserHandler) LogoutAll(c *gin.Context) {
+	currentUserId := c.GetInt64("userId")
+	if currentUserId == 0 {
+		ginx.ErrorWithStatus(c, http.StatusUnauthorized, ginx.CodeUnauthorized, "unauthorized")
+		return
+	}
+
+	if err := u.auth.LogoutAll(c.Request.Context(), currentUserId); err != nil {
+		ginx.Error(c, ginx.CodeInternalError, "logout failed")
+		return
+	}
+
+	ginx.SuccessMsg(c, "logged out from all devices")
+}
@@ -10,30 +10,33 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// JWTService signs access tokens with the access key ring, which may be
+// asymmetric so other services can verify them through the JWKS. Refresh
+// tokens are only ever read by this service and stay HS256 with their own
+// secret.
 type JWTService struct {
-	accessKey  []byte
-	refreshKey []byte
+	access  *KeyRing
+	refresh *KeyRing
 }
 
-func NewJWTService(accessKey string, refreshKey string) *JWTService {
-	if refreshKey == "" {
-		refreshKey = accessKey + "_refresh"
-	}
+func NewJWTService(access *KeyRing, refreshSecret string) *JWTService {
 	return &JWTService{
-		accessKey:  []byte(accessKey),
-		refreshKey: []byte(refreshKey),
+		access:  access,
+		refresh: NewHMACKeyRing("refresh", []byte(refreshSecret)),
 	}
 }
 
 type UserClaims struct {
 	UserId    int64  `json:"userId"`
 	UserAgent string `json:"userAgent"`
+	SessionId string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
 type RefreshClaims struct {
 	UserId int64  `json:"userId"`
 	SSid   string `json:"ssid"`
+	Family string `json:"fid,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -42,68 +45,84 @@ func hashUserAgent(userAgent string) string {
 	return hex.EncodeToString(hash[:])
 }
 
-func (s *JWTService) GenerateTokenPair(userId int64, userAgent, ssid string, accessExpire, refreshExpire time.Duration) (accessToken, refreshToken string, err error) {
-	accessToken, err = s.GenerateAccessToken(userId, userAgent, accessExpire)
+func (s *JWTService) GenerateTokenPair(userId int64, userAgent, ssid, family string, accessExpire, refreshExpire time.Duration) (accessToken, refreshToken string, err error) {
+	accessToken, err = s.GenerateAccessToken(userId, userAgent, family, accessExpire)
 	if err != nil {
 		return "", "", err
 	}
-	refreshToken, err = s.GenerateRefreshToken(userId, ssid, refreshExpire)
+	refreshToken, err = s.GenerateRefreshToken(userId, ssid, family, refreshExpire)
 	if err != nil {
 		return "", "", err
 	}
 	return accessToken, refreshToken, nil
 }
 
-func (s *JWTService) GenerateAccessToken(userId int64, userAgent string, expireTime time.Duration) (string, error) {
+func (s *JWTService) GenerateAccessToken(userId int64, userAgent, sessionId string, expireTime time.Duration) (string, error) {
 	claims := UserClaims{
 		UserId:    userId,
 		UserAgent: hashUserAgent(userAgent),
+		SessionId: sessionId,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expireTime)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(s.accessKey)
+	return s.access.sign(claims)
 }
 
-func (s *JWTService) GenerateRefreshToken(userId int64, ssid string, expireTime time.Duration) (string, error) {
+func (s *JWTService) GenerateRefreshToken(userId int64, ssid, family string, expireTime time.Duration) (string, error) {
 	claims := RefreshClaims{
 		UserId: userId,
 		SSid:   ssid,
+		Family: family,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(expireTime)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(s.refreshKey)
+	return s.refresh.sign(claims)
 }
 
 func (s *JWTService) ParseRefreshToken(tokenString string) (ports.RefreshClaims, error) {
 	claims := &RefreshClaims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
-		return s.refreshKey, nil
-	})
+	token, err := s.refresh.parse(tokenString, claims)
 	if err != nil || !token.Valid {
 		return ports.RefreshClaims{}, err
 	}
-	return ports.RefreshClaims{
+	result := ports.RefreshClaims{
 		UserId: claims.UserId,
 		SSid:   claims.SSid,
-	}, nil
+		Family: claims.Family,
+	}
+	// Tokens issued before rotation have no family; each is its own family.
+	if result.Family == "" {
+		result.Family = claims.SSid
+	}
+	if claims.IssuedAt != nil {
+		result.IssuedAt = claims.IssuedAt.Unix()
+	}
+	return result, nil
 }
 
-func (s *JWTService) Verify(tokenString, userAgent string) (int64, error) {
+func (s *JWTService) Verify(tokenString, userAgent string) (ports.AccessClaims, error) {
 	claims := &UserClaims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
-		return s.accessKey, nil
-	})
+	token, err := s.access.parse(tokenString, claims)
 	if err != nil || !token.Valid {
-		return 0, err
+		return ports.AccessClaims{}, err
 	}
 	if claims.UserAgent != "" && claims.UserAgent != hashUserAgent(userAgent) {
-		return 0, errors.New("user agent mismatch")
+		return ports.AccessClaims{}, errors.New("user agent mismatch")
 	}
-	return claims.UserId, nil
+	result := ports.AccessClaims{
+		UserId:    claims.UserId,
+		SessionId: claims.SessionId,
+	}
+	if claims.IssuedAt != nil {
+		result.IssuedAt = claims.IssuedAt.Unix()
+	}
+	return result, nil
+}
+
+func (s *JWTService) PublicKeys() []ports.JSONWebKey {
+	return s.access.JWKS()
 }
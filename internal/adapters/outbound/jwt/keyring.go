@@ -0,0 +1,237 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	ports "webook/internal/ports/output"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Key is one key of a KeyRing. Private is nil for keys that only verify,
+// such as the previous key during a rotation.
+type Key struct {
+	Kid     string
+	Method  jwt.SigningMethod
+	Private any // *rsa.PrivateKey, ed25519.PrivateKey or []byte for HMAC
+	Public  any // *rsa.PublicKey, ed25519.PublicKey or []byte for HMAC
+}
+
+// KeyRing signs with one key and verifies with any of its keys, picked by
+// the kid header. To rotate, sign with the new key and keep the old one as a
+// verification key until the tokens it signed have expired.
+type KeyRing struct {
+	signing Key
+	keys    map[string]Key
+	methods []string
+}
+
+func NewKeyRing(signing Key, verifyOnly ...Key) (*KeyRing, error) {
+	if signing.Private == nil {
+		return nil, errors.New("jwt: signing key has no private key")
+	}
+	r := &KeyRing{signing: signing, keys: make(map[string]Key, len(verifyOnly)+1)}
+	for _, k := range append([]Key{signing}, verifyOnly...) {
+		if _, ok := r.keys[k.Kid]; ok {
+			return nil, fmt.Errorf("jwt: duplicate kid %q", k.Kid)
+		}
+		r.keys[k.Kid] = k
+		r.addMethod(k.Method.Alg())
+	}
+	return r, nil
+}
+
+// NewHMACKeyRing is a ring with a single shared secret (HS256). Such a ring
+// publishes no keys in the JWKS.
+func NewHMACKeyRing(kid string, secret []byte) *KeyRing {
+	r, _ := NewKeyRing(Key{Kid: kid, Method: jwt.SigningMethodHS256, Private: secret, Public: secret})
+	return r
+}
+
+// LoadKeyRing signs with the private key in privatePath and also accepts
+// tokens signed by the keys in publicPaths. The algorithm follows the key
+// type: RS256 for RSA and EdDSA for Ed25519. kids are derived from the
+// public keys, so they stay stable across restarts.
+func LoadKeyRing(privatePath string, publicPaths ...string) (*KeyRing, error) {
+	signing, err := loadPrivateKey(privatePath)
+	if err != nil {
+		return nil, err
+	}
+	verifyOnly := make([]Key, 0, len(publicPaths))
+	for _, path := range publicPaths {
+		k, err := loadPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+		// The current key may be listed again among the public keys.
+		if k.Kid == signing.Kid {
+			continue
+		}
+		verifyOnly = append(verifyOnly, k)
+	}
+	return NewKeyRing(signing, verifyOnly...)
+}
+
+// Algorithm is the algorithm of the signing key.
+func (r *KeyRing) Algorithm() string {
+	return r.signing.Method.Alg()
+}
+
+func (r *KeyRing) addMethod(alg string) {
+	for _, m := range r.methods {
+		if m == alg {
+			return
+		}
+	}
+	r.methods = append(r.methods, alg)
+}
+
+// sign signs the claims with the signing key and sets the kid header.
+func (r *KeyRing) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(r.signing.Method, claims)
+	token.Header["kid"] = r.signing.Kid
+	return token.SignedString(r.signing.Private)
+}
+
+// parse verifies the token with the key named by its kid. Tokens without a
+// kid were issued before key rings existed and are checked against the
+// signing key.
+func (r *KeyRing) parse(tokenString string, claims jwt.Claims) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (any, error) {
+		key := r.signing
+		if kid, ok := token.Header["kid"].(string); ok {
+			if key, ok = r.keys[kid]; !ok {
+				return nil, fmt.Errorf("jwt: unknown kid %q", kid)
+			}
+		}
+		// Never let the token pick the algorithm for a key, e.g. HS256 with an RSA public key as secret.
+		if token.Method.Alg() != key.Method.Alg() {
+			return nil, fmt.Errorf("jwt: unexpected signing method %s", token.Method.Alg())
+		}
+		return key.Public, nil
+	}, jwt.WithValidMethods(r.methods))
+}
+
+// JWKS returns the public keys of the ring. HMAC secrets are never published.
+func (r *KeyRing) JWKS() []ports.JSONWebKey {
+	result := make([]ports.JSONWebKey, 0, len(r.keys))
+	// Signing key first so clients that only look at the first key still work.
+	for _, k := range append([]Key{r.signing}, r.verifyOnly()...) {
+		switch pub := k.Public.(type) {
+		case *rsa.PublicKey:
+			result = append(result, ports.JSONWebKey{
+				Kty: "RSA",
+				Kid: k.Kid,
+				Alg: k.Method.Alg(),
+				N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			result = append(result, ports.JSONWebKey{
+				Kty: "OKP",
+				Kid: k.Kid,
+				Alg: k.Method.Alg(),
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(pub),
+			})
+		}
+	}
+	return result
+}
+
+func (r *KeyRing) verifyOnly() []Key {
+	keys := make([]Key, 0, len(r.keys)-1)
+	for kid, k := range r.keys {
+		if kid != r.signing.Kid {
+			keys = append(keys, k)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Kid < keys[j].Kid })
+	return keys
+}
+
+func loadPrivateKey(path string) (Key, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return Key{}, err
+	}
+	var priv any
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		priv, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		priv, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return Key{}, fmt.Errorf("jwt: parse private key %s: %w", path, err)
+	}
+	switch k := priv.(type) {
+	case *rsa.PrivateKey:
+		return newKey(jwt.SigningMethodRS256, k, &k.PublicKey)
+	case ed25519.PrivateKey:
+		return newKey(jwt.SigningMethodEdDSA, k, k.Public())
+	default:
+		return Key{}, fmt.Errorf("jwt: unsupported private key type %T in %s", priv, path)
+	}
+}
+
+func loadPublicKey(path string) (Key, error) {
+	block, err := readPEM(path)
+	if err != nil {
+		return Key{}, err
+	}
+	var pub any
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		pub, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		pub, err = x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	if err != nil {
+		return Key{}, fmt.Errorf("jwt: parse public key %s: %w", path, err)
+	}
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		return newKey(jwt.SigningMethodRS256, nil, k)
+	case ed25519.PublicKey:
+		return newKey(jwt.SigningMethodEdDSA, nil, k)
+	default:
+		return Key{}, fmt.Errorf("jwt: unsupported public key type %T in %s", pub, path)
+	}
+}
+
+// newKey derives the kid from the SHA-256 of the DER-encoded public key.
+func newKey(method jwt.SigningMethod, priv, pub any) (Key, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return Key{}, err
+	}
+	sum := sha256.Sum256(der)
+	return Key{
+		Kid:     base64.RawURLEncoding.EncodeToString(sum[:12]),
+		Method:  method,
+		Private: priv,
+		Public:  pub,
+	}, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("jwt: read key %s: %w", path, err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("jwt: no PEM data in %s", path)
+	}
+	return block, nil
+}
@@ -0,0 +1,290 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testUserAgent = "Mozilla/5.0"
+
+func rsaKey(t *testing.T) (Key, *rsa.PrivateKey) {
+	t.Helper()
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	k, err := newKey(jwt.SigningMethodRS256, priv, &priv.PublicKey)
+	require.NoError(t, err)
+	return k, priv
+}
+
+func ed25519Key(t *testing.T) (Key, ed25519.PrivateKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	k, err := newKey(jwt.SigningMethodEdDSA, priv, pub)
+	require.NoError(t, err)
+	return k, priv
+}
+
+// verifyOnlyKey drops the private half, as for the previous key during a rotation.
+func verifyOnlyKey(k Key) Key {
+	k.Private = nil
+	return k
+}
+
+func testClaims() UserClaims {
+	return UserClaims{
+		UserId:    7,
+		UserAgent: hashUserAgent(testUserAgent),
+		SessionId: "s1",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+}
+
+// signWith signs the claims with any key and kid, bypassing the ring.
+func signWith(t *testing.T, method jwt.SigningMethod, key any, kid string) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, testClaims())
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	s, err := token.SignedString(key)
+	require.NoError(t, err)
+	return s
+}
+
+func TestKeyRing_RoundTrip(t *testing.T) {
+	rsaK, _ := rsaKey(t)
+	edK, _ := ed25519Key(t)
+	tests := []struct {
+		name    string
+		ring    func(t *testing.T) *KeyRing
+		wantAlg string
+	}{
+		{
+			name:    "HS256",
+			ring:    func(t *testing.T) *KeyRing { return NewHMACKeyRing("hs256", []byte("secret")) },
+			wantAlg: "HS256",
+		},
+		{
+			name: "RS256",
+			ring: func(t *testing.T) *KeyRing {
+				r, err := NewKeyRing(rsaK)
+				require.NoError(t, err)
+				return r
+			},
+			wantAlg: "RS256",
+		},
+		{
+			name: "EdDSA",
+			ring: func(t *testing.T) *KeyRing {
+				r, err := NewKeyRing(edK)
+				require.NoError(t, err)
+				return r
+			},
+			wantAlg: "EdDSA",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ring := tt.ring(t)
+			assert.Equal(t, tt.wantAlg, ring.Algorithm())
+			svc := NewJWTService(ring, "refresh")
+
+			token, err := svc.GenerateAccessToken(7, testUserAgent, "s1", time.Minute)
+			require.NoError(t, err)
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &UserClaims{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAlg, parsed.Method.Alg())
+			assert.Equal(t, ring.signing.Kid, parsed.Header["kid"])
+
+			claims, err := svc.Verify(token, testUserAgent)
+			require.NoError(t, err)
+			assert.Equal(t, int64(7), claims.UserId)
+			assert.Equal(t, "s1", claims.SessionId)
+
+			_, err = svc.Verify(token, "curl/8.0")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestKeyRing_Rotation(t *testing.T) {
+	oldK, oldPriv := rsaKey(t)
+	newK, _ := ed25519Key(t)
+	ring, err := NewKeyRing(newK, verifyOnlyKey(oldK))
+	require.NoError(t, err)
+	svc := NewJWTService(ring, "refresh")
+
+	// 轮换前用旧密钥签发的 token 在过期前仍然有效
+	_, err = svc.Verify(signWith(t, jwt.SigningMethodRS256, oldPriv, oldK.Kid), testUserAgent)
+	assert.NoError(t, err)
+
+	_, err = NewKeyRing(newK, verifyOnlyKey(newK))
+	assert.Error(t, err, "duplicate kid")
+	_, err = NewKeyRing(verifyOnlyKey(newK))
+	assert.Error(t, err, "signing key without private key")
+}
+
+func TestKeyRing_Reject(t *testing.T) {
+	rsaK, rsaPriv := rsaKey(t)
+	otherK, otherPriv := rsaKey(t)
+	edK, edPriv := ed25519Key(t)
+	ring, err := NewKeyRing(rsaK, verifyOnlyKey(edK))
+	require.NoError(t, err)
+	svc := NewJWTService(ring, "refresh")
+
+	rsaPubDER, err := x509.MarshalPKIXPublicKey(&rsaPriv.PublicKey)
+	require.NoError(t, err)
+	rsaPubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: rsaPubDER})
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{
+			name:  "未知 kid",
+			token: signWith(t, jwt.SigningMethodRS256, otherPriv, otherK.Kid),
+		},
+		{
+			name:  "kid 指向的密钥与签名不符",
+			token: signWith(t, jwt.SigningMethodRS256, otherPriv, rsaK.Kid),
+		},
+		{
+			name:  "HS256-以 RSA 公钥（DER）为密钥",
+			token: signWith(t, jwt.SigningMethodHS256, rsaPubDER, rsaK.Kid),
+		},
+		{
+			name:  "HS256-以 RSA 公钥（PEM）为密钥，不带 kid",
+			token: signWith(t, jwt.SigningMethodHS256, rsaPubPEM, ""),
+		},
+		{
+			name:  "kid 指向 EdDSA 密钥但用 RS256 签名",
+			token: signWith(t, jwt.SigningMethodRS256, rsaPriv, edK.Kid),
+		},
+		{
+			name:  "不带 kid-只按当前签名密钥校验，不接受旧密钥",
+			token: signWith(t, jwt.SigningMethodEdDSA, edPriv, ""),
+		},
+		{
+			name:  "alg none",
+			token: signWith(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, rsaK.Kid),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.Verify(tt.token, testUserAgent)
+			assert.Error(t, err)
+		})
+	}
+
+	// HS256 的 token 同样不能通过 RSA 密钥环
+	hsToken, err := NewJWTService(NewHMACKeyRing(rsaK.Kid, rsaPubDER), "refresh").
+		GenerateAccessToken(7, testUserAgent, "s1", time.Minute)
+	require.NoError(t, err)
+	_, err = svc.Verify(hsToken, testUserAgent)
+	assert.Error(t, err)
+}
+
+func TestKeyRing_LegacyTokenWithoutKid(t *testing.T) {
+	hsRing := NewHMACKeyRing("hs256", []byte("secret"))
+	_, err := NewJWTService(hsRing, "refresh").Verify(signWith(t, jwt.SigningMethodHS256, []byte("secret"), ""), testUserAgent)
+	assert.NoError(t, err, "密钥环之前签发的 HS256 token 不带 kid，按签名密钥校验")
+
+	rsaK, rsaPriv := rsaKey(t)
+	rsaRing, err := NewKeyRing(rsaK)
+	require.NoError(t, err)
+	_, err = NewJWTService(rsaRing, "refresh").Verify(signWith(t, jwt.SigningMethodRS256, rsaPriv, ""), testUserAgent)
+	assert.NoError(t, err)
+
+	// 切换到 RS256 之后，旧的 HS256 token 全部失效
+	_, err = NewJWTService(rsaRing, "refresh").Verify(signWith(t, jwt.SigningMethodHS256, []byte("secret"), ""), testUserAgent)
+	assert.Error(t, err)
+}
+
+func TestKeyRing_JWKS(t *testing.T) {
+	rsaK, rsaPriv := rsaKey(t)
+	edK, edPriv := ed25519Key(t)
+	ring, err := NewKeyRing(rsaK, verifyOnlyKey(edK))
+	require.NoError(t, err)
+
+	keys := ring.JWKS()
+	require.Len(t, keys, 2)
+
+	// 签名密钥排在第一个
+	rsaJWK := keys[0]
+	assert.Equal(t, "RSA", rsaJWK.Kty)
+	assert.Equal(t, rsaK.Kid, rsaJWK.Kid)
+	assert.Equal(t, "RS256", rsaJWK.Alg)
+	n, err := base64.RawURLEncoding.DecodeString(rsaJWK.N)
+	require.NoError(t, err)
+	assert.Equal(t, 0, new(big.Int).SetBytes(n).Cmp(rsaPriv.N))
+	assert.NotEqual(t, byte(0), n[0], "n 不带前导零")
+	assert.Equal(t, "AQAB", rsaJWK.E, "e = 65537")
+	assert.Empty(t, rsaJWK.Crv)
+	assert.Empty(t, rsaJWK.X)
+
+	edJWK := keys[1]
+	assert.Equal(t, "OKP", edJWK.Kty)
+	assert.Equal(t, edK.Kid, edJWK.Kid)
+	assert.Equal(t, "EdDSA", edJWK.Alg)
+	assert.Equal(t, "Ed25519", edJWK.Crv)
+	x, err := base64.RawURLEncoding.DecodeString(edJWK.X)
+	require.NoError(t, err)
+	assert.Equal(t, []byte(edPriv.Public().(ed25519.PublicKey)), x)
+	assert.Empty(t, edJWK.N)
+	assert.Empty(t, edJWK.E)
+
+	assert.Empty(t, NewHMACKeyRing("hs256", []byte("secret")).JWKS(), "共享密钥不公开")
+}
+
+func TestLoadKeyRing(t *testing.T) {
+	dir := t.TempDir()
+	writePEM := func(name, typ string, der []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600))
+		return path
+	}
+
+	edK, edPriv := ed25519Key(t)
+	edDER, err := x509.MarshalPKCS8PrivateKey(edPriv)
+	require.NoError(t, err)
+	edPubDER, err := x509.MarshalPKIXPublicKey(edPriv.Public())
+	require.NoError(t, err)
+	rsaK, rsaPriv := rsaKey(t)
+	rsaPubDER, err := x509.MarshalPKIXPublicKey(&rsaPriv.PublicKey)
+	require.NoError(t, err)
+
+	ring, err := LoadKeyRing(
+		writePEM("ed.pem", "PRIVATE KEY", edDER),
+		writePEM("ed.pub", "PUBLIC KEY", edPubDER), // 当前密钥重复列出时跳过
+		writePEM("rsa.pub", "PUBLIC KEY", rsaPubDER),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "EdDSA", ring.Algorithm())
+	assert.Equal(t, edK.Kid, ring.signing.Kid, "kid 由公钥决定，重启后不变")
+	require.Len(t, ring.JWKS(), 2)
+	assert.Equal(t, rsaK.Kid, ring.JWKS()[1].Kid)
+
+	_, err = LoadKeyRing(filepath.Join(dir, "missing.pem"))
+	assert.Error(t, err)
+	_, err = LoadKeyRing(writePEM("bad.pem", "PRIVATE KEY", []byte("garbage")))
+	assert.Error(t, err)
+}
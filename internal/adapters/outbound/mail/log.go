@@ -0,0 +1,45 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+	"webook/pkg/logger"
+)
+
+// LogMailer is for local development: it writes each mail to the log and,
+// when path is set, appends it to that file instead of sending it.
+type LogMailer struct {
+	logger logger.Logger
+	path   string
+	mu     sync.Mutex
+}
+
+func NewLogMailer(l logger.Logger, path string) ports.Mailer {
+	return &LogMailer{logger: l, path: path}
+}
+
+func (m *LogMailer) Send(ctx context.Context, mail domain.Mail) error {
+	m.logger.Info("mail not sent, log mailer in use",
+		logger.String("to", mail.To),
+		logger.String("subject", mail.Subject),
+		logger.String("body", mail.Body))
+	if m.path == "" {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	f, err := os.OpenFile(m.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = fmt.Fprintf(f, "Date: %s\nTo: %s\nSubject: %s\n\n%s\n\n----\n\n",
+		time.Now().Format(time.RFC1123Z), mail.To, mail.Subject, mail.Body)
+	return err
+}
@@ -0,0 +1,72 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net"
+	netmail "net/mail"
+	"net/smtp"
+	"time"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+)
+
+// SMTPMailer sends mail through an SMTP server. net/smtp upgrades the connection
+// with STARTTLS when the server offers it, so use the submission port (587);
+// implicit TLS on port 465 is not supported.
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPMailer creates an SMTPMailer. addr is host:port; an empty username
+// sends without authentication.
+func NewSMTPMailer(addr, username, password, from string) ports.Mailer {
+	m := &SMTPMailer{addr: addr, from: from}
+	if username != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		m.auth = smtp.PlainAuth("", username, password, host)
+	}
+	return m
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, mail domain.Mail) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return smtp.SendMail(m.addr, m.auth, envelopeAddress(m.from), []string{mail.To}, m.message(mail))
+}
+
+// message builds a UTF-8 plain text message with a base64 body.
+func (m *SMTPMailer) message(mail domain.Mail) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", m.from)
+	fmt.Fprintf(&buf, "To: %s\r\n", mail.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", mail.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+
+	body := base64.StdEncoding.EncodeToString([]byte(mail.Body))
+	for len(body) > 76 {
+		buf.WriteString(body[:76] + "\r\n")
+		body = body[76:]
+	}
+	buf.WriteString(body + "\r\n")
+	return buf.Bytes()
+}
+
+// envelopeAddress extracts the bare address from a From header such as
+// "webook <no-reply@example.com>".
+func envelopeAddress(from string) string {
+	addr, err := netmail.ParseAddress(from)
+	if err != nil {
+		return from
+	}
+	return addr.Address
+}
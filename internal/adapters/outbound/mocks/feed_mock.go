@@ -0,0 +1,76 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/ports/output/feed.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/ports/output/feed.go -destination=internal/adapters/outbound/mocks/feed_mock.go -package=repomocks
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeedCache is a mock of FeedCache interface.
+type MockFeedCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedCacheMockRecorder
+	isgomock struct{}
+}
+
+// MockFeedCacheMockRecorder is the mock recorder for MockFeedCache.
+type MockFeedCacheMockRecorder struct {
+	mock *MockFeedCache
+}
+
+// NewMockFeedCache creates a new mock instance.
+func NewMockFeedCache(ctrl *gomock.Controller) *MockFeedCache {
+	mock := &MockFeedCache{ctrl: ctrl}
+	mock.recorder = &MockFeedCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedCache) EXPECT() *MockFeedCacheMockRecorder {
+	return m.recorder
+}
+
+// Inbox mocks base method.
+func (m *MockFeedCache) Inbox(ctx context.Context, userId int64, cursor domain.FeedCursor, limit int) ([]domain.FeedItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Inbox", ctx, userId, cursor, limit)
+	ret0, _ := ret[0].([]domain.FeedItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Inbox indicates an expected call of Inbox.
+func (mr *MockFeedCacheMockRecorder) Inbox(ctx, userId, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inbox", reflect.TypeOf((*MockFeedCache)(nil).Inbox), ctx, userId, cursor, limit)
+}
+
+// Push mocks base method.
+func (m *MockFeedCache) Push(ctx context.Context, userIds []int64, items ...domain.FeedItem) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, userIds}
+	for _, a := range items {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Push", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Push indicates an expected call of Push.
+func (mr *MockFeedCacheMockRecorder) Push(ctx, userIds any, items ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, userIds}, items...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockFeedCache)(nil).Push), varargs...)
+}
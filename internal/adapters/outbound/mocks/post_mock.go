@@ -1,5 +1,10 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: internal/repository/post.go
+// Source: internal/ports/output/post_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/ports/output/post_repository.go -destination=internal/adapters/outbound/mocks/post_mock.go -package=repomocks
+//
 
 // Package repomocks is a generated GoMock package.
 package repomocks
@@ -16,6 +21,7 @@ import (
 type MockPostRepository struct {
 	ctrl     *gomock.Controller
 	recorder *MockPostRepositoryMockRecorder
+	isgomock struct{}
 }
 
 // MockPostRepositoryMockRecorder is the mock recorder for MockPostRepository.
@@ -35,6 +41,35 @@ func (m *MockPostRepository) EXPECT() *MockPostRepositoryMockRecorder {
 	return m.recorder
 }
 
+// CancelSchedule mocks base method.
+func (m *MockPostRepository) CancelSchedule(ctx context.Context, id, authorId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelSchedule", ctx, id, authorId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelSchedule indicates an expected call of CancelSchedule.
+func (mr *MockPostRepositoryMockRecorder) CancelSchedule(ctx, id, authorId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelSchedule", reflect.TypeOf((*MockPostRepository)(nil).CancelSchedule), ctx, id, authorId)
+}
+
+// CountByAuthor mocks base method.
+func (m *MockPostRepository) CountByAuthor(ctx context.Context, authorId int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByAuthor", ctx, authorId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByAuthor indicates an expected call of CountByAuthor.
+func (mr *MockPostRepositoryMockRecorder) CountByAuthor(ctx, authorId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByAuthor", reflect.TypeOf((*MockPostRepository)(nil).CountByAuthor), ctx, authorId)
+}
+
 // Create mocks base method.
 func (m *MockPostRepository) Create(ctx context.Context, p domain.Post) (int64, error) {
 	m.ctrl.T.Helper()
@@ -50,18 +85,19 @@ func (mr *MockPostRepositoryMockRecorder) Create(ctx, p any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPostRepository)(nil).Create), ctx, p)
 }
 
-// Update mocks base method.
-func (m *MockPostRepository) Update(ctx context.Context, p domain.Post) error {
+// FindByAuthor mocks base method.
+func (m *MockPostRepository) FindByAuthor(ctx context.Context, authorId int64, offset, limit int) ([]domain.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, p)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "FindByAuthor", ctx, authorId, offset, limit)
+	ret0, _ := ret[0].([]domain.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockPostRepositoryMockRecorder) Update(ctx, p any) *gomock.Call {
+// FindByAuthor indicates an expected call of FindByAuthor.
+func (mr *MockPostRepositoryMockRecorder) FindByAuthor(ctx, authorId, offset, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPostRepository)(nil).Update), ctx, p)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAuthor", reflect.TypeOf((*MockPostRepository)(nil).FindByAuthor), ctx, authorId, offset, limit)
 }
 
 // FindById mocks base method.
@@ -79,19 +115,62 @@ func (mr *MockPostRepositoryMockRecorder) FindById(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockPostRepository)(nil).FindById), ctx, id)
 }
 
-// FindByAuthor mocks base method.
-func (m *MockPostRepository) FindByAuthor(ctx context.Context, authorId int64, offset, limit int) ([]domain.Post, error) {
+// FindDueScheduled mocks base method.
+func (m *MockPostRepository) FindDueScheduled(ctx context.Context, now int64, limit int) ([]domain.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByAuthor", ctx, authorId, offset, limit)
+	ret := m.ctrl.Call(m, "FindDueScheduled", ctx, now, limit)
 	ret0, _ := ret[0].([]domain.Post)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// FindByAuthor indicates an expected call of FindByAuthor.
-func (mr *MockPostRepositoryMockRecorder) FindByAuthor(ctx, authorId, offset, limit any) *gomock.Call {
+// FindDueScheduled indicates an expected call of FindDueScheduled.
+func (mr *MockPostRepositoryMockRecorder) FindDueScheduled(ctx, now, limit any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAuthor", reflect.TypeOf((*MockPostRepository)(nil).FindByAuthor), ctx, authorId, offset, limit)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDueScheduled", reflect.TypeOf((*MockPostRepository)(nil).FindDueScheduled), ctx, now, limit)
+}
+
+// PublishScheduled mocks base method.
+func (m *MockPostRepository) PublishScheduled(ctx context.Context, id, now int64) (domain.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishScheduled", ctx, id, now)
+	ret0, _ := ret[0].(domain.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishScheduled indicates an expected call of PublishScheduled.
+func (mr *MockPostRepositoryMockRecorder) PublishScheduled(ctx, id, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishScheduled", reflect.TypeOf((*MockPostRepository)(nil).PublishScheduled), ctx, id, now)
+}
+
+// Reschedule mocks base method.
+func (m *MockPostRepository) Reschedule(ctx context.Context, id, authorId, scheduledAt int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reschedule", ctx, id, authorId, scheduledAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reschedule indicates an expected call of Reschedule.
+func (mr *MockPostRepositoryMockRecorder) Reschedule(ctx, id, authorId, scheduledAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reschedule", reflect.TypeOf((*MockPostRepository)(nil).Reschedule), ctx, id, authorId, scheduledAt)
+}
+
+// Schedule mocks base method.
+func (m *MockPostRepository) Schedule(ctx context.Context, id, authorId, scheduledAt int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Schedule", ctx, id, authorId, scheduledAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Schedule indicates an expected call of Schedule.
+func (mr *MockPostRepositoryMockRecorder) Schedule(ctx, id, authorId, scheduledAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Schedule", reflect.TypeOf((*MockPostRepository)(nil).Schedule), ctx, id, authorId, scheduledAt)
 }
 
 // Sync mocks base method.
@@ -110,7 +189,7 @@ func (mr *MockPostRepositoryMockRecorder) Sync(ctx, p any) *gomock.Call {
 }
 
 // SyncStatus mocks base method.
-func (m *MockPostRepository) SyncStatus(ctx context.Context, id int64, authorId int64, status uint8) error {
+func (m *MockPostRepository) SyncStatus(ctx context.Context, id, authorId int64, status uint8) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SyncStatus", ctx, id, authorId, status)
 	ret0, _ := ret[0].(error)
@@ -123,25 +202,39 @@ func (mr *MockPostRepositoryMockRecorder) SyncStatus(ctx, id, authorId, status a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncStatus", reflect.TypeOf((*MockPostRepository)(nil).SyncStatus), ctx, id, authorId, status)
 }
 
-// CountByAuthor mocks base method.
-func (m *MockPostRepository) CountByAuthor(ctx context.Context, authorId int64) (int64, error) {
+// Unhide mocks base method.
+func (m *MockPostRepository) Unhide(ctx context.Context, id int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CountByAuthor", ctx, authorId)
-	ret0, _ := ret[0].(int64)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Unhide", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// CountByAuthor indicates an expected call of CountByAuthor.
-func (mr *MockPostRepositoryMockRecorder) CountByAuthor(ctx, authorId any) *gomock.Call {
+// Unhide indicates an expected call of Unhide.
+func (mr *MockPostRepositoryMockRecorder) Unhide(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByAuthor", reflect.TypeOf((*MockPostRepository)(nil).CountByAuthor), ctx, authorId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unhide", reflect.TypeOf((*MockPostRepository)(nil).Unhide), ctx, id)
+}
+
+// Update mocks base method.
+func (m *MockPostRepository) Update(ctx context.Context, p domain.Post) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, p)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockPostRepositoryMockRecorder) Update(ctx, p any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPostRepository)(nil).Update), ctx, p)
 }
 
 // MockPublishedPostRepository is a mock of PublishedPostRepository interface.
 type MockPublishedPostRepository struct {
 	ctrl     *gomock.Controller
 	recorder *MockPublishedPostRepositoryMockRecorder
+	isgomock struct{}
 }
 
 // MockPublishedPostRepositoryMockRecorder is the mock recorder for MockPublishedPostRepository.
@@ -161,6 +254,81 @@ func (m *MockPublishedPostRepository) EXPECT() *MockPublishedPostRepositoryMockR
 	return m.recorder
 }
 
+// Count mocks base method.
+func (m *MockPublishedPostRepository) Count(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockPublishedPostRepositoryMockRecorder) Count(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockPublishedPostRepository)(nil).Count), ctx)
+}
+
+// CountByAuthor mocks base method.
+func (m *MockPublishedPostRepository) CountByAuthor(ctx context.Context, authorId int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByAuthor", ctx, authorId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByAuthor indicates an expected call of CountByAuthor.
+func (mr *MockPublishedPostRepositoryMockRecorder) CountByAuthor(ctx, authorId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByAuthor", reflect.TypeOf((*MockPublishedPostRepository)(nil).CountByAuthor), ctx, authorId)
+}
+
+// CountByTag mocks base method.
+func (m *MockPublishedPostRepository) CountByTag(ctx context.Context, tag string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByTag", ctx, tag)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByTag indicates an expected call of CountByTag.
+func (mr *MockPublishedPostRepositoryMockRecorder) CountByTag(ctx, tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByTag", reflect.TypeOf((*MockPublishedPostRepository)(nil).CountByTag), ctx, tag)
+}
+
+// CountCollectedBy mocks base method.
+func (m *MockPublishedPostRepository) CountCollectedBy(ctx context.Context, userId int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCollectedBy", ctx, userId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCollectedBy indicates an expected call of CountCollectedBy.
+func (mr *MockPublishedPostRepositoryMockRecorder) CountCollectedBy(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCollectedBy", reflect.TypeOf((*MockPublishedPostRepository)(nil).CountCollectedBy), ctx, userId)
+}
+
+// CountLikedBy mocks base method.
+func (m *MockPublishedPostRepository) CountLikedBy(ctx context.Context, userId int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountLikedBy", ctx, userId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountLikedBy indicates an expected call of CountLikedBy.
+func (mr *MockPublishedPostRepositoryMockRecorder) CountLikedBy(ctx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountLikedBy", reflect.TypeOf((*MockPublishedPostRepository)(nil).CountLikedBy), ctx, userId)
+}
+
 // FindById mocks base method.
 func (m *MockPublishedPostRepository) FindById(ctx context.Context, id int64) (domain.Post, error) {
 	m.ctrl.T.Helper()
@@ -176,6 +344,21 @@ func (mr *MockPublishedPostRepositoryMockRecorder) FindById(ctx, id any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockPublishedPostRepository)(nil).FindById), ctx, id)
 }
 
+// FindByIds mocks base method.
+func (m *MockPublishedPostRepository) FindByIds(ctx context.Context, ids []int64) ([]domain.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIds", ctx, ids)
+	ret0, _ := ret[0].([]domain.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIds indicates an expected call of FindByIds.
+func (mr *MockPublishedPostRepositoryMockRecorder) FindByIds(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIds", reflect.TypeOf((*MockPublishedPostRepository)(nil).FindByIds), ctx, ids)
+}
+
 // List mocks base method.
 func (m *MockPublishedPostRepository) List(ctx context.Context, offset, limit int) ([]domain.Post, error) {
 	m.ctrl.T.Helper()
@@ -191,17 +374,92 @@ func (mr *MockPublishedPostRepositoryMockRecorder) List(ctx, offset, limit any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockPublishedPostRepository)(nil).List), ctx, offset, limit)
 }
 
-// Count mocks base method.
-func (m *MockPublishedPostRepository) Count(ctx context.Context) (int64, error) {
+// ListAfterId mocks base method.
+func (m *MockPublishedPostRepository) ListAfterId(ctx context.Context, afterId int64, limit int) ([]domain.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Count", ctx)
+	ret := m.ctrl.Call(m, "ListAfterId", ctx, afterId, limit)
+	ret0, _ := ret[0].([]domain.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAfterId indicates an expected call of ListAfterId.
+func (mr *MockPublishedPostRepositoryMockRecorder) ListAfterId(ctx, afterId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAfterId", reflect.TypeOf((*MockPublishedPostRepository)(nil).ListAfterId), ctx, afterId, limit)
+}
+
+// ListByAuthors mocks base method.
+func (m *MockPublishedPostRepository) ListByAuthors(ctx context.Context, authorIds []int64, cursor domain.FeedCursor, limit int) ([]domain.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByAuthors", ctx, authorIds, cursor, limit)
+	ret0, _ := ret[0].([]domain.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByAuthors indicates an expected call of ListByAuthors.
+func (mr *MockPublishedPostRepositoryMockRecorder) ListByAuthors(ctx, authorIds, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByAuthors", reflect.TypeOf((*MockPublishedPostRepository)(nil).ListByAuthors), ctx, authorIds, cursor, limit)
+}
+
+// ListByTag mocks base method.
+func (m *MockPublishedPostRepository) ListByTag(ctx context.Context, tag string, offset, limit int) ([]domain.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByTag", ctx, tag, offset, limit)
+	ret0, _ := ret[0].([]domain.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByTag indicates an expected call of ListByTag.
+func (mr *MockPublishedPostRepositoryMockRecorder) ListByTag(ctx, tag, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByTag", reflect.TypeOf((*MockPublishedPostRepository)(nil).ListByTag), ctx, tag, offset, limit)
+}
+
+// ListCollectedBy mocks base method.
+func (m *MockPublishedPostRepository) ListCollectedBy(ctx context.Context, userId int64, offset, limit int) ([]domain.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCollectedBy", ctx, userId, offset, limit)
+	ret0, _ := ret[0].([]domain.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCollectedBy indicates an expected call of ListCollectedBy.
+func (mr *MockPublishedPostRepositoryMockRecorder) ListCollectedBy(ctx, userId, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCollectedBy", reflect.TypeOf((*MockPublishedPostRepository)(nil).ListCollectedBy), ctx, userId, offset, limit)
+}
+
+// ListLikedBy mocks base method.
+func (m *MockPublishedPostRepository) ListLikedBy(ctx context.Context, userId int64, offset, limit int) ([]domain.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLikedBy", ctx, userId, offset, limit)
+	ret0, _ := ret[0].([]domain.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLikedBy indicates an expected call of ListLikedBy.
+func (mr *MockPublishedPostRepositoryMockRecorder) ListLikedBy(ctx, userId, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLikedBy", reflect.TypeOf((*MockPublishedPostRepository)(nil).ListLikedBy), ctx, userId, offset, limit)
+}
+
+// SumLikesByAuthor mocks base method.
+func (m *MockPublishedPostRepository) SumLikesByAuthor(ctx context.Context, authorId int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumLikesByAuthor", ctx, authorId)
 	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Count indicates an expected call of Count.
-func (mr *MockPublishedPostRepositoryMockRecorder) Count(ctx any) *gomock.Call {
+// SumLikesByAuthor indicates an expected call of SumLikesByAuthor.
+func (mr *MockPublishedPostRepositoryMockRecorder) SumLikesByAuthor(ctx, authorId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockPublishedPostRepository)(nil).Count), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumLikesByAuthor", reflect.TypeOf((*MockPublishedPostRepository)(nil).SumLikesByAuthor), ctx, authorId)
 }
@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/ports/output/post_revision.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/ports/output/post_revision.go -destination=internal/adapters/outbound/mocks/post_revision_mock.go -package=repomocks
+//
+
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPostRevisionRepository is a mock of PostRevisionRepository interface.
+type MockPostRevisionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPostRevisionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockPostRevisionRepositoryMockRecorder is the mock recorder for MockPostRevisionRepository.
+type MockPostRevisionRepositoryMockRecorder struct {
+	mock *MockPostRevisionRepository
+}
+
+// NewMockPostRevisionRepository creates a new mock instance.
+func NewMockPostRevisionRepository(ctrl *gomock.Controller) *MockPostRevisionRepository {
+	mock := &MockPostRevisionRepository{ctrl: ctrl}
+	mock.recorder = &MockPostRevisionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPostRevisionRepository) EXPECT() *MockPostRevisionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByPostId mocks base method.
+func (m *MockPostRevisionRepository) CountByPostId(ctx context.Context, postId int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByPostId", ctx, postId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByPostId indicates an expected call of CountByPostId.
+func (mr *MockPostRevisionRepositoryMockRecorder) CountByPostId(ctx, postId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByPostId", reflect.TypeOf((*MockPostRevisionRepository)(nil).CountByPostId), ctx, postId)
+}
+
+// FindByPostId mocks base method.
+func (m *MockPostRevisionRepository) FindByPostId(ctx context.Context, postId int64, offset, limit int) ([]domain.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByPostId", ctx, postId, offset, limit)
+	ret0, _ := ret[0].([]domain.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByPostId indicates an expected call of FindByPostId.
+func (mr *MockPostRevisionRepositoryMockRecorder) FindByPostId(ctx, postId, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByPostId", reflect.TypeOf((*MockPostRevisionRepository)(nil).FindByPostId), ctx, postId, offset, limit)
+}
+
+// FindByVersion mocks base method.
+func (m *MockPostRevisionRepository) FindByVersion(ctx context.Context, postId, version int64) (domain.PostRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByVersion", ctx, postId, version)
+	ret0, _ := ret[0].(domain.PostRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByVersion indicates an expected call of FindByVersion.
+func (mr *MockPostRevisionRepositoryMockRecorder) FindByVersion(ctx, postId, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByVersion", reflect.TypeOf((*MockPostRevisionRepository)(nil).FindByVersion), ctx, postId, version)
+}
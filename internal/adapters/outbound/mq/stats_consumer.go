@@ -3,42 +3,67 @@ package mq
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"time"
 	"webook/internal/domain"
+	input "webook/internal/ports/input"
 	output "webook/internal/ports/output"
 	"webook/pkg/logger"
 
 	 amqp"github.com/rabbitmq/amqp091-go"
 )
 
+const (
+	headerError    = "x-error"
+	headerAttempts = "x-attempts"
+)
+
+// errInvalidPayload marks messages that can never succeed and go straight to the DLQ.
+var errInvalidPayload = errors.New("invalid post stats payload")
+
 type RabbitMQStatsConsumer struct {
-	ch        *amqp.Channel
-	queue     string
-	prefetch  int
-	cache     output.PostStatsCache
-	logger    logger.Logger
-	eventTTL  time.Duration
-	closeChan chan struct{}
+	ch          *amqp.Channel
+	topo        StatsTopology
+	prefetch    int
+	maxAttempts int
+	cache       output.PostStatsCache
+	rank        output.PostRankCache
+	feed        input.FeedService
+	notifier    input.NotificationService
+	stream      input.StreamService
+	logger      logger.Logger
+	eventTTL    time.Duration
+	closeChan   chan struct{}
 }
 
 func NewRabbitMQStatsConsumer(
 	ch ConsumerChannel,
-	exchange, queue, routingKey string,
+	topo StatsTopology,
 	prefetch int,
+	maxAttempts int,
 	cache output.PostStatsCache,
+	rank output.PostRankCache,
+	feed input.FeedService,
+	notifier input.NotificationService,
+	stream input.StreamService,
 	l logger.Logger,
 ) (*RabbitMQStatsConsumer, error) {
-	if err := ensureStatsTopology(ch.Channel, exchange, queue, routingKey); err != nil {
+	if err := ensureStatsTopology(ch.Channel, topo); err != nil {
 		return nil, err
 	}
 	return &RabbitMQStatsConsumer{
-		ch:        ch.Channel,
-		queue:     queue,
-		prefetch:  prefetch,
-		cache:     cache,
-		logger:    l,
-		eventTTL:  24 * time.Hour,
-		closeChan: make(chan struct{}),
+		ch:          ch.Channel,
+		topo:        topo,
+		prefetch:    prefetch,
+		maxAttempts: maxAttempts,
+		cache:       cache,
+		rank:        rank,
+		feed:        feed,
+		notifier:    notifier,
+		stream:      stream,
+		logger:      l,
+		eventTTL:    24 * time.Hour,
+		closeChan:   make(chan struct{}),
 	}, nil
 }
 
@@ -46,7 +71,12 @@ func (c *RabbitMQStatsConsumer) Start(ctx context.Context) {
 	if c.prefetch > 0 {
 		_ = c.ch.Qos(c.prefetch, 0, false)
 	}
-	msgs, err := c.ch.Consume(c.queue, "", false, false, false, false, nil)
+	msgs, err := c.ch.Consume(c.topo.MainQueue(), "", false, false, false, false, nil)
+	if err != nil {
+		c.logger.Error("post stats consumer start failed", logger.Error(err))
+		return
+	}
+	legacy, err := c.ch.Consume(c.topo.LegacyQueue(), "", false, false, false, false, nil)
 	if err != nil {
 		c.logger.Error("post stats consumer start failed", logger.Error(err))
 		return
@@ -63,14 +93,39 @@ func (c *RabbitMQStatsConsumer) Start(ctx context.Context) {
 				return
 			}
 			if err := c.handleMessage(ctx, msg); err != nil {
-				_ = msg.Nack(false, true)
+				c.retryOrDeadLetter(ctx, msg, err)
 				continue
 			}
 			_ = msg.Ack(false)
+		case msg, ok := <-legacy:
+			if !ok {
+				legacy = nil
+				continue
+			}
+			c.moveLegacy(ctx, msg)
 		}
 	}
 }
 
+// moveLegacy moves a message left in the legacy queue into the main queue, where
+// it is handled with retries like any other. It is published to the main queue
+// directly rather than through the exchange, which older versions still running
+// during an upgrade may have bound the legacy queue to again.
+func (c *RabbitMQStatsConsumer) moveLegacy(ctx context.Context, msg amqp.Delivery) {
+	err := c.ch.PublishWithContext(ctx, "", c.topo.MainQueue(), false, false, amqp.Publishing{
+		ContentType:  msg.ContentType,
+		DeliveryMode: amqp.Persistent,
+		Timestamp:    msg.Timestamp,
+		Body:         msg.Body,
+	})
+	if err != nil {
+		c.logger.Error("post stats consumer move legacy message failed", logger.Error(err))
+		_ = msg.Nack(false, true)
+		return
+	}
+	_ = msg.Ack(false)
+}
+
 func (c *RabbitMQStatsConsumer) Stop() {
 	close(c.closeChan)
 }
@@ -78,8 +133,7 @@ func (c *RabbitMQStatsConsumer) Stop() {
 func (c *RabbitMQStatsConsumer) handleMessage(ctx context.Context, msg amqp.Delivery) error {
 	var event domain.PostStatsEvent
 	if err := json.Unmarshal(msg.Body, &event); err != nil {
-		c.logger.Warn("post stats consumer invalid payload", logger.Error(err))
-		return nil
+		return errors.Join(errInvalidPayload, err)
 	}
 
 	ok, err := c.cache.SetEventProcessed(ctx, event.EventId, c.eventTTL)
@@ -89,7 +143,89 @@ func (c *RabbitMQStatsConsumer) handleMessage(ctx context.Context, msg amqp.Deli
 	if !ok {
 		return nil
 	}
+	if event.Type == domain.PostStatsEventPublish {
+		// Publish events change no counter. Fan-out is idempotent, so a failed
+		// one is simply retried as a whole.
+		if err = c.feed.FanOut(ctx, event.PostId); err != nil {
+			_ = c.cache.UnsetEventProcessed(ctx, event.EventId)
+			return err
+		}
+		return nil
+	}
+	c.notify(ctx, event)
+	if err = c.apply(ctx, event); err != nil {
+		// The counter was not changed, let the retried delivery through the dedupe check again.
+		_ = c.cache.UnsetEventProcessed(ctx, event.EventId)
+		return err
+	}
+	c.updateRank(ctx, event)
+	c.updateDaily(ctx, event)
+	c.pushStats(ctx, event)
+	return c.cache.MarkDirty(ctx, event.PostId)
+}
 
+// notify records the notification for the event. It is best effort: a failed
+// notification must not hold back or retry the counters. It runs before apply so
+// that a retry after a failed apply gets another chance; notifications are
+// idempotent by EventId, so that retry does not notify twice.
+func (c *RabbitMQStatsConsumer) notify(ctx context.Context, event domain.PostStatsEvent) {
+	if err := c.notifier.Handle(ctx, event); err != nil {
+		c.logger.Warn("post stats consumer notify failed",
+			logger.Int64("post_id", event.PostId),
+			logger.String("event_id", event.EventId),
+			logger.Error(err))
+	}
+}
+
+// pushStats pushes the new counters to the clients watching the post. Like
+// updateRank it is best effort; the next change pushes fresh values again.
+func (c *RabbitMQStatsConsumer) pushStats(ctx context.Context, event domain.PostStatsEvent) {
+	if err := c.stream.PublishStats(ctx, event.PostId); err != nil {
+		c.logger.Warn("post stats consumer push stats failed",
+			logger.Int64("post_id", event.PostId),
+			logger.Error(err))
+	}
+}
+
+// updateRank feeds the event into the hot ranking. The counter is already applied,
+// so a failure is only logged: retrying would count the event twice, and the
+// periodic rebuild from post_stats repairs the all-time scores.
+func (c *RabbitMQStatsConsumer) updateRank(ctx context.Context, event domain.PostStatsEvent) {
+	delta := domain.HotScoreDelta(event.Type)
+	if delta == 0 {
+		return
+	}
+	if err := c.rank.IncrScore(ctx, event.PostId, delta, eventTime(event)); err != nil {
+		c.logger.Warn("post stats consumer update hot rank failed",
+			logger.Int64("post_id", event.PostId),
+			logger.Error(err))
+	}
+}
+
+// updateDaily buffers the event in the daily time series. Like updateRank it is
+// best effort, since the counter is already applied.
+func (c *RabbitMQStatsConsumer) updateDaily(ctx context.Context, event domain.PostStatsEvent) {
+	delta, ok := domain.NewPostDailyDelta(event, eventTime(event))
+	if !ok {
+		return
+	}
+	if err := c.cache.IncrDaily(ctx, delta); err != nil {
+		c.logger.Warn("post stats consumer update daily stats failed",
+			logger.Int64("post_id", event.PostId),
+			logger.Error(err))
+	}
+}
+
+// eventTime is when the event happened, so retried or replayed events land in the right day.
+func eventTime(event domain.PostStatsEvent) time.Time {
+	if event.Ts > 0 {
+		return time.Unix(event.Ts, 0)
+	}
+	return time.Now()
+}
+
+func (c *RabbitMQStatsConsumer) apply(ctx context.Context, event domain.PostStatsEvent) error {
+	var err error
 	switch event.Type {
 	case domain.PostStatsEventLike:
 		_, err = c.cache.IncrLike(ctx, event.PostId, 1)
@@ -101,12 +237,71 @@ func (c *RabbitMQStatsConsumer) handleMessage(ctx context.Context, msg amqp.Deli
 		_, err = c.cache.IncrCollect(ctx, event.PostId, -1)
 	case domain.PostStatsEventRead:
 		_, err = c.cache.IncrRead(ctx, event.PostId, 1)
+	case domain.PostStatsEventComment:
+		_, err = c.cache.IncrComment(ctx, event.PostId, 1)
+	case domain.PostStatsEventUncomment:
+		_, err = c.cache.IncrComment(ctx, event.PostId, -1)
 	default:
 		c.logger.Warn("post stats consumer unknown event type", logger.String("type", string(event.Type)))
-		return nil
 	}
+	return err
+}
+
+// retryOrDeadLetter rejects the message into the retry queue, or moves it to the
+// DLQ once it is invalid or has failed maxAttempts times.
+func (c *RabbitMQStatsConsumer) retryOrDeadLetter(ctx context.Context, msg amqp.Delivery, cause error) {
+	attempts, deadLetter := c.nextAttempt(msg, cause)
+	if !deadLetter {
+		c.logger.Warn("post stats consumer handle failed, retrying",
+			logger.Int("attempts", attempts),
+			logger.Error(cause))
+		_ = msg.Nack(false, false)
+		return
+	}
+
+	c.logger.Error("post stats consumer moving message to DLQ",
+		logger.Int("attempts", attempts),
+		logger.Error(cause))
+	err := c.ch.PublishWithContext(ctx, c.topo.DeadLetterExchange(), c.topo.RoutingKey, false, false, amqp.Publishing{
+		ContentType:  msg.ContentType,
+		DeliveryMode: amqp.Persistent,
+		Timestamp:    time.Now(),
+		Headers: amqp.Table{
+			headerError:    cause.Error(),
+			headerAttempts: int64(attempts),
+		},
+		Body: msg.Body,
+	})
 	if err != nil {
-		return err
+		c.logger.Error("post stats consumer publish to DLQ failed", logger.Error(err))
+		_ = msg.Nack(false, false)
+		return
 	}
-	return c.cache.MarkDirty(ctx, event.PostId)
+	_ = msg.Ack(false)
+}
+
+// nextAttempt returns the number of the attempt that just failed and whether the
+// message should go to the DLQ instead of back through the retry queue.
+func (c *RabbitMQStatsConsumer) nextAttempt(msg amqp.Delivery, cause error) (int, bool) {
+	attempts := c.deathCount(msg) + 1
+	return attempts, errors.Is(cause, errInvalidPayload) || attempts >= c.maxAttempts
+}
+
+// deathCount returns how many times the message was rejected from the main queue,
+// as recorded by the broker in the x-death header.
+func (c *RabbitMQStatsConsumer) deathCount(msg amqp.Delivery) int {
+	deaths, ok := msg.Headers["x-death"].([]any)
+	if !ok {
+		return 0
+	}
+	for _, d := range deaths {
+		table, ok := d.(amqp.Table)
+		if !ok || table["queue"] != c.topo.MainQueue() || table["reason"] != "rejected" {
+			continue
+		}
+		if n, ok := table["count"].(int64); ok {
+			return int(n)
+		}
+	}
+	return 0
 }
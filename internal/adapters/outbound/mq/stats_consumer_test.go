@@ -0,0 +1,108 @@
+package mq
+
+import (
+	"errors"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func xDeath(entries ...amqp.Table) amqp.Table {
+	deaths := make([]any, len(entries))
+	for i, e := range entries {
+		deaths[i] = e
+	}
+	return amqp.Table{"x-death": deaths}
+}
+
+func TestRabbitMQStatsConsumer_DeathCount(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers amqp.Table
+		want    int
+	}{
+		{
+			name: "首次投递-没有 x-death",
+			want: 0,
+		},
+		{
+			name: "主队列拒绝次数",
+			headers: xDeath(
+				// 重试队列过期的记录不计入
+				amqp.Table{"queue": "post_stats.retry", "reason": "expired", "count": int64(2)},
+				amqp.Table{"queue": "post_stats.main", "reason": "rejected", "count": int64(2)},
+			),
+			want: 2,
+		},
+		{
+			name:    "其他队列的拒绝记录",
+			headers: xDeath(amqp.Table{"queue": "other", "reason": "rejected", "count": int64(5)}),
+			want:    0,
+		},
+		{
+			name:    "x-death 格式不对",
+			headers: amqp.Table{"x-death": "rejected"},
+			want:    0,
+		},
+		{
+			name:    "count 类型不对",
+			headers: xDeath(amqp.Table{"queue": "post_stats.main", "reason": "rejected", "count": "2"}),
+			want:    0,
+		},
+	}
+
+	c := &RabbitMQStatsConsumer{topo: StatsTopology{Queue: "post_stats"}, maxAttempts: 3}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, c.deathCount(amqp.Delivery{Headers: tt.headers}))
+		})
+	}
+}
+
+func TestRabbitMQStatsConsumer_NextAttempt(t *testing.T) {
+	rejected := func(n int64) amqp.Table {
+		return xDeath(amqp.Table{"queue": "post_stats.main", "reason": "rejected", "count": n})
+	}
+	tests := []struct {
+		name           string
+		headers        amqp.Table
+		cause          error
+		wantAttempts   int
+		wantDeadLetter bool
+	}{
+		{
+			name:         "第一次失败-重试",
+			cause:        errors.New("redis down"),
+			wantAttempts: 1,
+		},
+		{
+			name:         "未到最大次数-重试",
+			headers:      rejected(1),
+			cause:        errors.New("redis down"),
+			wantAttempts: 2,
+		},
+		{
+			name:           "达到最大次数-进入死信队列",
+			headers:        rejected(2),
+			cause:          errors.New("redis down"),
+			wantAttempts:   3,
+			wantDeadLetter: true,
+		},
+		{
+			name:           "消息格式错误-直接进入死信队列",
+			cause:          errors.Join(errInvalidPayload, errors.New("unexpected end of JSON input")),
+			wantAttempts:   1,
+			wantDeadLetter: true,
+		},
+	}
+
+	c := &RabbitMQStatsConsumer{topo: StatsTopology{Queue: "post_stats"}, maxAttempts: 3}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts, deadLetter := c.nextAttempt(amqp.Delivery{Headers: tt.headers}, tt.cause)
+			assert.Equal(t, tt.wantAttempts, attempts)
+			assert.Equal(t, tt.wantDeadLetter, deadLetter)
+		})
+	}
+}
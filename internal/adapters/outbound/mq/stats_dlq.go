@@ -0,0 +1,102 @@
+package mq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"webook/internal/domain"
+	output "webook/internal/ports/output"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+type RabbitMQStatsDLQ struct {
+	ch   *amqp.Channel
+	topo StatsTopology
+}
+
+func NewRabbitMQStatsDLQ(ch ProducerChannel, topo StatsTopology) (output.PostStatsDeadLetterQueue, error) {
+	if err := ensureStatsTopology(ch.Channel, topo); err != nil {
+		return nil, err
+	}
+	if err := ch.Confirm(false); err != nil {
+		return nil, err
+	}
+	return &RabbitMQStatsDLQ{ch: ch.Channel, topo: topo}, nil
+}
+
+func (q *RabbitMQStatsDLQ) Peek(ctx context.Context, limit int) ([]domain.PostStatsDeadLetter, error) {
+	letters := make([]domain.PostStatsDeadLetter, 0, limit)
+	var lastTag uint64
+	for len(letters) < limit {
+		d, ok, err := q.ch.Get(q.topo.DeadLetterQueue(), false)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			break
+		}
+		letters = append(letters, toDeadLetter(d))
+		lastTag = d.DeliveryTag
+	}
+	if lastTag > 0 {
+		// Put everything we looked at back into the DLQ.
+		if err := q.ch.Nack(lastTag, true, true); err != nil {
+			return nil, err
+		}
+	}
+	return letters, nil
+}
+
+func (q *RabbitMQStatsDLQ) Replay(ctx context.Context, limit int) (int, error) {
+	replayed := 0
+	for replayed < limit {
+		d, ok, err := q.ch.Get(q.topo.DeadLetterQueue(), false)
+		if err != nil {
+			return replayed, err
+		}
+		if !ok {
+			break
+		}
+		// Published without the x-death history, so the consumer starts counting attempts again.
+		confirm, err := q.ch.PublishWithDeferredConfirmWithContext(ctx, q.topo.Exchange, q.topo.RoutingKey, false, false, amqp.Publishing{
+			ContentType:  d.ContentType,
+			DeliveryMode: amqp.Persistent,
+			Body:         d.Body,
+		})
+		if err == nil {
+			var acked bool
+			acked, err = confirm.WaitContext(ctx)
+			if err == nil && !acked {
+				err = errors.New("replayed post stats event nacked by broker")
+			}
+		}
+		if err != nil {
+			_ = d.Nack(false, true)
+			return replayed, err
+		}
+		if err := d.Ack(false); err != nil {
+			return replayed, err
+		}
+		replayed++
+	}
+	return replayed, nil
+}
+
+func toDeadLetter(d amqp.Delivery) domain.PostStatsDeadLetter {
+	letter := domain.PostStatsDeadLetter{
+		Body:     string(d.Body),
+		FailedAt: d.Timestamp.UnixMilli(),
+	}
+	_ = json.Unmarshal(d.Body, &letter.Event)
+	if reason, ok := d.Headers[headerError].(string); ok {
+		letter.Error = reason
+	}
+	switch n := d.Headers[headerAttempts].(type) {
+	case int64:
+		letter.Attempts = int(n)
+	case int32:
+		letter.Attempts = int(n)
+	}
+	return letter
+}
@@ -3,6 +3,7 @@ package mq
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"webook/internal/domain"
 	output "webook/internal/ports/output"
 
@@ -15,14 +16,18 @@ type RabbitMQStatsPublisher struct {
 	routingKey string
 }
 
-func NewRabbitMQStatsPublisher(ch ProducerChannel, exchange, queue, routingKey string) (output.PostStatsEventPublisher, error) {
-	if err := ensureStatsTopology(ch.Channel, exchange, queue, routingKey); err != nil {
+func NewRabbitMQStatsPublisher(ch ProducerChannel, topo StatsTopology) (output.PostStatsEventPublisher, error) {
+	if err := ensureStatsTopology(ch.Channel, topo); err != nil {
+		return nil, err
+	}
+	// Publisher confirms let callers (the outbox relay) know the broker really took the message.
+	if err := ch.Confirm(false); err != nil {
 		return nil, err
 	}
 	return &RabbitMQStatsPublisher{
 		ch:         ch.Channel,
-		exchange:   exchange,
-		routingKey: routingKey,
+		exchange:   topo.Exchange,
+		routingKey: topo.RoutingKey,
 	}, nil
 }
 
@@ -31,20 +36,20 @@ func (p *RabbitMQStatsPublisher) Publish(ctx context.Context, event domain.PostS
 	if err != nil {
 		return err
 	}
-	return p.ch.PublishWithContext(ctx, p.exchange, p.routingKey, false, false, amqp.Publishing{
+	confirm, err := p.ch.PublishWithDeferredConfirmWithContext(ctx, p.exchange, p.routingKey, false, false, amqp.Publishing{
 		ContentType:  "application/json",
 		DeliveryMode: amqp.Persistent,
 		Body:         body,
 	})
-}
-
-func ensureStatsTopology(ch *amqp.Channel, exchange, queue, routingKey string) error {
-	if err := ch.ExchangeDeclare(exchange, "direct", true, false, false, false, nil); err != nil {
+	if err != nil {
 		return err
 	}
-	q, err := ch.QueueDeclare(queue, true, false, false, false, nil)
+	acked, err := confirm.WaitContext(ctx)
 	if err != nil {
 		return err
 	}
-	return ch.QueueBind(q.Name, routingKey, exchange, false, nil)
+	if !acked {
+		return errors.New("post stats event nacked by broker")
+	}
+	return nil
 }
@@ -0,0 +1,81 @@
+package mq
+
+import (
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// StatsTopology describes the stats exchange and the main, retry and dead-letter
+// queues derived from the configured queue name:
+//
+//	<exchange> --rk--> <queue>.main --reject--> <exchange>.retry --rk--> <queue>.retry
+//	    ^                                                                   | ttl
+//	    +-------------------------------------------------------------------+
+//	<exchange>.dlx --rk--> <queue>.dlq   (published by the consumer after MaxAttempts)
+//
+// The configured queue itself is the legacy queue, declared without arguments
+// before retries existed. A queue's arguments cannot change once declared, so the
+// main queue got a new name; the legacy queue is unbound and drained into it.
+type StatsTopology struct {
+	Exchange   string
+	Queue      string
+	RoutingKey string
+	RetryDelay time.Duration
+}
+
+func (t StatsTopology) MainQueue() string { return t.Queue + ".main" }
+
+func (t StatsTopology) LegacyQueue() string { return t.Queue }
+
+func (t StatsTopology) RetryExchange() string { return t.Exchange + ".retry" }
+
+func (t StatsTopology) RetryQueue() string { return t.Queue + ".retry" }
+
+func (t StatsTopology) DeadLetterExchange() string { return t.Exchange + ".dlx" }
+
+func (t StatsTopology) DeadLetterQueue() string { return t.Queue + ".dlq" }
+
+// ensureStatsTopology declares all exchanges and queues. Queue arguments must match
+// what is already declared on the broker, so changing them requires a new queue
+// name and draining the old queue, as done for the legacy queue.
+func ensureStatsTopology(ch *amqp.Channel, t StatsTopology) error {
+	for _, ex := range []string{t.Exchange, t.RetryExchange(), t.DeadLetterExchange()} {
+		if err := ch.ExchangeDeclare(ex, "direct", true, false, false, false, nil); err != nil {
+			return err
+		}
+	}
+
+	// Rejected messages go to the retry exchange, keeping their routing key.
+	if err := declareAndBind(ch, t.MainQueue(), t.Exchange, t.RoutingKey, amqp.Table{
+		"x-dead-letter-exchange": t.RetryExchange(),
+	}); err != nil {
+		return err
+	}
+	// Messages wait RetryDelay in the retry queue, then expire back to the main exchange.
+	if err := declareAndBind(ch, t.RetryQueue(), t.RetryExchange(), t.RoutingKey, amqp.Table{
+		"x-message-ttl":          t.RetryDelay.Milliseconds(),
+		"x-dead-letter-exchange": t.Exchange,
+	}); err != nil {
+		return err
+	}
+	if err := declareAndBind(ch, t.DeadLetterQueue(), t.DeadLetterExchange(), t.RoutingKey, nil); err != nil {
+		return err
+	}
+	// Declared with the same nil arguments as before so that the declaration matches
+	// on upgraded brokers. Unbinding stops new events from landing in it; the consumer
+	// moves what is left into the main queue. Once no older version is running, it
+	// stays empty and may be deleted.
+	if _, err := ch.QueueDeclare(t.LegacyQueue(), true, false, false, false, nil); err != nil {
+		return err
+	}
+	return ch.QueueUnbind(t.LegacyQueue(), t.RoutingKey, t.Exchange, nil)
+}
+
+func declareAndBind(ch *amqp.Channel, queue, exchange, routingKey string, args amqp.Table) error {
+	q, err := ch.QueueDeclare(queue, true, false, false, false, args)
+	if err != nil {
+		return err
+	}
+	return ch.QueueBind(q.Name, routingKey, exchange, false, nil)
+}
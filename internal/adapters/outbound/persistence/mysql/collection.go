@@ -0,0 +1,105 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var ErrDuplicateFolder = errors.New("收藏夹已存在")
+
+// CollectionFolder 收藏夹实体，帖子归属记录在 PostCollectRelation.FolderId
+type CollectionFolder struct {
+	Id          int64  `gorm:"primarykey,autoIncrement"`
+	UserId      int64  `gorm:"uniqueIndex:idx_folder_user_name"`
+	Name        string `gorm:"size:32;uniqueIndex:idx_folder_user_name"`
+	Description string `gorm:"size:256"`
+	Public      bool
+	Ctime       int64
+	Utime       int64
+}
+
+// FolderCount 收藏夹ID及其中的帖子数
+type FolderCount struct {
+	FolderId int64
+	Cnt      int64
+}
+
+// CollectionFolderDAO 收藏夹数据访问对象
+type CollectionFolderDAO struct {
+	db *gorm.DB
+}
+
+// NewCollectionFolderDAO 创建 CollectionFolderDAO 实例
+func NewCollectionFolderDAO(db *gorm.DB) *CollectionFolderDAO {
+	return &CollectionFolderDAO{db: db}
+}
+
+// Insert 创建收藏夹
+func (d *CollectionFolderDAO) Insert(ctx context.Context, f CollectionFolder) (int64, error) {
+	now := time.Now().UnixMilli()
+	f.Ctime = now
+	f.Utime = now
+	err := d.db.WithContext(ctx).Create(&f).Error
+	if isDuplicateKey(err) {
+		return 0, ErrDuplicateFolder
+	}
+	return f.Id, err
+}
+
+// Update 修改收藏夹名称、描述与公开状态，只能修改自己的收藏夹
+func (d *CollectionFolderDAO) Update(ctx context.Context, f CollectionFolder) error {
+	res := d.db.WithContext(ctx).Model(&CollectionFolder{}).
+		Where("id = ? AND user_id = ?", f.Id, f.UserId).
+		Updates(map[string]any{
+			"name":        f.Name,
+			"description": f.Description,
+			"public":      f.Public,
+			"utime":       time.Now().UnixMilli(),
+		})
+	if isDuplicateKey(res.Error) {
+		return ErrDuplicateFolder
+	}
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Delete 删除收藏夹，其中的帖子移回默认收藏夹，收藏状态不变
+func (d *CollectionFolderDAO) Delete(ctx context.Context, id, userId int64) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Delete(&CollectionFolder{}, "id = ? AND user_id = ?", id, userId)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Model(&PostCollectRelation{}).
+			Where("user_id = ? AND folder_id = ?", userId, id).
+			Updates(map[string]any{
+				"folder_id": 0,
+				"utime":     time.Now().UnixMilli(),
+			}).Error
+	})
+}
+
+// FindById 根据ID查找收藏夹
+func (d *CollectionFolderDAO) FindById(ctx context.Context, id int64) (CollectionFolder, error) {
+	var f CollectionFolder
+	err := d.db.WithContext(ctx).Where("id = ?", id).First(&f).Error
+	return f, err
+}
+
+// FindByUser 获取用户的全部收藏夹，按创建顺序排列
+func (d *CollectionFolderDAO) FindByUser(ctx context.Context, userId int64) ([]CollectionFolder, error) {
+	var folders []CollectionFolder
+	err := d.db.WithContext(ctx).Where("user_id = ?", userId).Order("id ASC").Find(&folders).Error
+	return folders, err
+}
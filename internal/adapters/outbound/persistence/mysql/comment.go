@@ -0,0 +1,170 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Comment 评论实体
+type Comment struct {
+	Id            int64  `gorm:"primarykey,autoIncrement"`
+	PostId        int64  `gorm:"index:idx_comment_post_root"`
+	UserId        int64  `gorm:"index"`
+	RootId        int64  `gorm:"index:idx_comment_post_root;index:idx_comment_root"`
+	ParentId      int64  // 被回复的评论ID
+	ReplyToUserId int64  // 被回复的用户ID
+	Content       string `gorm:"type:text"`
+	Ctime         int64
+	Utime         int64
+}
+
+// ErrCommentDeleteConflict 删除的评论数与加锁读出的不一致
+var ErrCommentDeleteConflict = errors.New("评论删除冲突")
+
+// CommentReplyCount 根评论的回复数
+type CommentReplyCount struct {
+	RootId int64
+	Cnt    int64
+}
+
+// CommentDAO 评论数据访问对象
+type CommentDAO struct {
+	db *gorm.DB
+}
+
+// NewCommentDAO 创建 CommentDAO 实例
+func NewCommentDAO(db *gorm.DB) *CommentDAO {
+	return &CommentDAO{db: db}
+}
+
+// Insert 新增评论，并在同一事务内写入评论数变更的 outbox 事件
+func (d *CommentDAO) Insert(ctx context.Context, c Comment, event PostStatsOutbox) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&c).Error; err != nil {
+			return err
+		}
+		return insertOutbox(tx, event)
+	})
+	return c.Id, err
+}
+
+// FindById 根据ID查询评论
+func (d *CommentDAO) FindById(ctx context.Context, id int64) (Comment, error) {
+	var c Comment
+	err := d.db.WithContext(ctx).Where("id = ?", id).First(&c).Error
+	return c, err
+}
+
+// UpdateContent 修改评论内容，只能修改自己的评论
+func (d *CommentDAO) UpdateContent(ctx context.Context, id, userId int64, content string) error {
+	res := d.db.WithContext(ctx).Model(&Comment{}).
+		Where("id = ? AND user_id = ?", id, userId).
+		Updates(map[string]any{
+			"content": content,
+			"utime":   time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Delete 删除评论，删除根评论时一并删除其下所有回复，返回实际删除的评论
+// 每条被删除的评论通过 toEvent 生成一条 outbox 事件，与删除操作在同一事务内写入。
+// 要删除的评论先加锁读出，并发删除重叠的评论（如根评论和它的回复）时后到的事务
+// 读不到已删除的行，每条评论只会生成一次评论数减一的事件
+func (d *CommentDAO) Delete(ctx context.Context, id int64, toEvent func(Comment) PostStatsOutbox) ([]Comment, error) {
+	var deleted []Comment
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? OR root_id = ?", id, id).
+			Find(&deleted).Error
+		if err != nil {
+			return err
+		}
+		if len(deleted) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		ids := make([]int64, len(deleted))
+		events := make([]PostStatsOutbox, len(deleted))
+		for i, c := range deleted {
+			ids[i] = c.Id
+			events[i] = toEvent(c)
+		}
+		res := tx.Where("id IN ?", ids).Delete(&Comment{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected != int64(len(ids)) {
+			// 行已经锁住，不应发生；回滚而不是写入与实际不符的事件
+			return ErrCommentDeleteConflict
+		}
+		return insertOutbox(tx, events...)
+	})
+	return deleted, err
+}
+
+// FindRoots 按ID倒序游标分页获取帖子的根评论，cursor 为上一页最后一条评论的ID，0 表示第一页
+func (d *CommentDAO) FindRoots(ctx context.Context, postId, cursor int64, limit int) ([]Comment, error) {
+	var comments []Comment
+	query := d.db.WithContext(ctx).Where("post_id = ? AND root_id = 0", postId)
+	if cursor > 0 {
+		query = query.Where("id < ?", cursor)
+	}
+	err := query.Order("id DESC").Limit(limit).Find(&comments).Error
+	return comments, err
+}
+
+// FindReplies 按ID正序游标分页获取根评论下的回复，cursor 为上一页最后一条回复的ID
+func (d *CommentDAO) FindReplies(ctx context.Context, rootId, cursor int64, limit int) ([]Comment, error) {
+	var comments []Comment
+	err := d.db.WithContext(ctx).
+		Where("root_id = ? AND id > ?", rootId, cursor).
+		Order("id ASC").
+		Limit(limit).
+		Find(&comments).Error
+	return comments, err
+}
+
+// FindRepliesPreview 批量获取每条根评论最早的 n 条回复
+func (d *CommentDAO) FindRepliesPreview(ctx context.Context, rootIds []int64, n int) ([]Comment, error) {
+	var comments []Comment
+	if len(rootIds) == 0 || n <= 0 {
+		return comments, nil
+	}
+	// 使用窗口函数（MySQL 8.0+）一次取出每个分组的前 n 条
+	ranked := d.db.Model(&Comment{}).
+		Select("*, ROW_NUMBER() OVER (PARTITION BY root_id ORDER BY id ASC) AS rn").
+		Where("root_id IN ?", rootIds)
+	err := d.db.WithContext(ctx).
+		Table("(?) AS t", ranked).
+		Select("id, post_id, user_id, root_id, parent_id, reply_to_user_id, content, ctime, utime").
+		Where("rn <= ?", n).
+		Order("root_id, id").
+		Find(&comments).Error
+	return comments, err
+}
+
+// CountReplies 批量统计根评论的回复数
+func (d *CommentDAO) CountReplies(ctx context.Context, rootIds []int64) ([]CommentReplyCount, error) {
+	var counts []CommentReplyCount
+	if len(rootIds) == 0 {
+		return counts, nil
+	}
+	err := d.db.WithContext(ctx).Model(&Comment{}).
+		Select("root_id, COUNT(*) AS cnt").
+		Where("root_id IN ?", rootIds).
+		Group("root_id").
+		Find(&counts).Error
+	return counts, err
+}
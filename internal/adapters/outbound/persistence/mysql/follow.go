@@ -0,0 +1,166 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FollowRelation 关注关系实体，取消关注只把 Status 置 0，Utime 即最近一次关注/取消的时间
+type FollowRelation struct {
+	Id         int64 `gorm:"primarykey,autoIncrement"`
+	FollowerId int64 `gorm:"uniqueIndex:idx_follow_follower_followee;index:idx_follow_follower_status_utime,priority:1"`
+	FolloweeId int64 `gorm:"uniqueIndex:idx_follow_follower_followee;index:idx_follow_followee_status_utime,priority:1;index:idx_follow_followee_status_follower,priority:1"`
+	Status     uint8 `gorm:"index:idx_follow_follower_status_utime,priority:2;index:idx_follow_followee_status_utime,priority:2;index:idx_follow_followee_status_follower,priority:2"` // 1 已关注 0 已取消
+	Ctime      int64
+	Utime      int64 `gorm:"index:idx_follow_follower_status_utime,priority:3;index:idx_follow_followee_status_utime,priority:3"`
+}
+
+// FollowStats 用户的粉丝数与关注数，随关注关系在同一事务内更新
+type FollowStats struct {
+	UserId      int64 `gorm:"primarykey"`
+	FollowerCnt int64
+	FolloweeCnt int64
+	Utime       int64
+}
+
+// FollowDAO 关注关系数据访问对象
+type FollowDAO struct {
+	db *gorm.DB
+}
+
+// NewFollowDAO 创建 FollowDAO 实例
+func NewFollowDAO(db *gorm.DB) *FollowDAO {
+	return &FollowDAO{db: db}
+}
+
+// SetStatus 关注或取消关注，状态确实发生变化时同步更新双方的计数，返回状态是否变化
+func (d *FollowDAO) SetStatus(ctx context.Context, followerId, followeeId int64, status uint8) (bool, error) {
+	changed := false
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		var rel FollowRelation
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("follower_id = ? AND followee_id = ?", followerId, followeeId).
+			First(&rel).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if status == 0 {
+				return nil
+			}
+			err = tx.Create(&FollowRelation{
+				FollowerId: followerId,
+				FolloweeId: followeeId,
+				Status:     status,
+				Ctime:      now,
+				Utime:      now,
+			}).Error
+		case err != nil:
+			return err
+		case rel.Status == status:
+			return nil
+		default:
+			err = tx.Model(&FollowRelation{}).Where("id = ?", rel.Id).Updates(map[string]any{
+				"status": status,
+				"utime":  now,
+			}).Error
+		}
+		if err != nil {
+			return err
+		}
+		changed = true
+
+		delta := int64(1)
+		if status == 0 {
+			delta = -1
+		}
+		if err = incrFollowStats(tx, followeeId, "follower_cnt", delta, now); err != nil {
+			return err
+		}
+		return incrFollowStats(tx, followerId, "followee_cnt", delta, now)
+	})
+	return changed, err
+}
+
+// incrFollowStats 累加一个计数列，用户没有计数行时先插入
+func incrFollowStats(tx *gorm.DB, userId int64, column string, delta int64, now int64) error {
+	stats := FollowStats{UserId: userId, Utime: now}
+	if column == "follower_cnt" {
+		stats.FollowerCnt = delta
+	} else {
+		stats.FolloweeCnt = delta
+	}
+	return tx.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			column:  gorm.Expr(column+" + ?", delta),
+			"utime": now,
+		}),
+	}).Create(&stats).Error
+}
+
+// FindRelation 查询关注关系，不存在时返回 gorm.ErrRecordNotFound
+func (d *FollowDAO) FindRelation(ctx context.Context, followerId, followeeId int64) (FollowRelation, error) {
+	var rel FollowRelation
+	err := d.db.WithContext(ctx).
+		Where("follower_id = ? AND followee_id = ?", followerId, followeeId).
+		First(&rel).Error
+	return rel, err
+}
+
+// FindFollowees 按关注时间倒序获取用户关注的人，cursor 为上一页最后一条的关注时间，0 表示第一页
+func (d *FollowDAO) FindFollowees(ctx context.Context, followerId, cursor int64, limit int) ([]FollowRelation, error) {
+	query := d.db.WithContext(ctx).Where("follower_id = ? AND status = 1", followerId)
+	if cursor > 0 {
+		query = query.Where("utime < ?", cursor)
+	}
+	var rels []FollowRelation
+	err := query.Order("utime DESC").Limit(limit).Find(&rels).Error
+	return rels, err
+}
+
+// FindFollowers 按关注时间倒序获取用户的粉丝，cursor 含义同 FindFollowees
+func (d *FollowDAO) FindFollowers(ctx context.Context, followeeId, cursor int64, limit int) ([]FollowRelation, error) {
+	query := d.db.WithContext(ctx).Where("followee_id = ? AND status = 1", followeeId)
+	if cursor > 0 {
+		query = query.Where("utime < ?", cursor)
+	}
+	var rels []FollowRelation
+	err := query.Order("utime DESC").Limit(limit).Find(&rels).Error
+	return rels, err
+}
+
+// FindFolloweeIds 获取用户关注的全部用户ID，最多 limit 个，最近关注的优先
+func (d *FollowDAO) FindFolloweeIds(ctx context.Context, followerId int64, limit int) ([]int64, error) {
+	var ids []int64
+	err := d.db.WithContext(ctx).Model(&FollowRelation{}).
+		Where("follower_id = ? AND status = 1", followerId).
+		Order("utime DESC").
+		Limit(limit).
+		Pluck("followee_id", &ids).Error
+	return ids, err
+}
+
+// FindFollowerIds 按粉丝ID升序分批获取粉丝，afterId 为上一批最后一个粉丝ID，0 表示第一批
+func (d *FollowDAO) FindFollowerIds(ctx context.Context, followeeId, afterId int64, limit int) ([]int64, error) {
+	var ids []int64
+	err := d.db.WithContext(ctx).Model(&FollowRelation{}).
+		Where("followee_id = ? AND status = 1 AND follower_id > ?", followeeId, afterId).
+		Order("follower_id ASC").
+		Limit(limit).
+		Pluck("follower_id", &ids).Error
+	return ids, err
+}
+
+// FindStatsByUserIds 批量获取计数，没有计数行的用户不出现在结果中
+func (d *FollowDAO) FindStatsByUserIds(ctx context.Context, userIds []int64) ([]FollowStats, error) {
+	var stats []FollowStats
+	if len(userIds) == 0 {
+		return stats, nil
+	}
+	err := d.db.WithContext(ctx).Where("user_id IN ?", userIds).Find(&stats).Error
+	return stats, err
+}
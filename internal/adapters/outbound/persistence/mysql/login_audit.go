@@ -0,0 +1,46 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LoginAudit 登录尝试审计日志，只追加不修改
+type LoginAudit struct {
+	Id        int64  `gorm:"primarykey,autoIncrement"`
+	UserId    int64  `gorm:"index"`
+	Email     string `gorm:"size:128;index:idx_login_audit_email_ctime,priority:1"`
+	IP        string `gorm:"size:64;index:idx_login_audit_ip_ctime,priority:1"`
+	UserAgent string `gorm:"size:255"`
+	Result    string `gorm:"size:32"`
+	Ctime     int64  `gorm:"index:idx_login_audit_email_ctime,priority:2;index:idx_login_audit_ip_ctime,priority:2"`
+}
+
+// LoginAuditDAO 登录审计日志数据访问对象
+type LoginAuditDAO struct {
+	db *gorm.DB
+}
+
+// NewLoginAuditDAO 创建 LoginAuditDAO 实例
+func NewLoginAuditDAO(db *gorm.DB) *LoginAuditDAO {
+	return &LoginAuditDAO{db: db}
+}
+
+// Insert 写入一条审计记录
+func (d *LoginAuditDAO) Insert(ctx context.Context, a LoginAudit) error {
+	a.Ctime = time.Now().UnixMilli()
+	return d.db.WithContext(ctx).Create(&a).Error
+}
+
+// FindByEmail 按时间倒序获取该邮箱的登录尝试
+func (d *LoginAuditDAO) FindByEmail(ctx context.Context, email string, limit int) ([]LoginAudit, error) {
+	var audits []LoginAudit
+	err := d.db.WithContext(ctx).
+		Where("email = ?", email).
+		Order("ctime DESC, id DESC").
+		Limit(limit).
+		Find(&audits).Error
+	return audits, err
+}
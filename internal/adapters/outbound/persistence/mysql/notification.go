@@ -0,0 +1,170 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Notification 通知实体，ReadAt 为 0 表示未读。
+// 未读通知按 (recipient_id, kind, post_id) 聚合，Utime 为最近一次聚合的时间。
+// 聚合键加上 read_at 是唯一索引，并发的消费者不会为同一组建出两条未读通知
+type Notification struct {
+	Id            int64  `gorm:"primarykey,autoIncrement"`
+	RecipientId   int64  `gorm:"index:idx_notify_recipient_utime,priority:1;uniqueIndex:idx_notify_unread_group,priority:1"`
+	Kind          string `gorm:"size:16;uniqueIndex:idx_notify_unread_group,priority:2"`
+	PostId        int64  `gorm:"uniqueIndex:idx_notify_unread_group,priority:3"`
+	ReadAt        int64  `gorm:"uniqueIndex:idx_notify_unread_group,priority:4"`
+	LatestActorId int64
+	ActorCnt      int64
+	Ctime         int64
+	Utime         int64 `gorm:"index:idx_notify_recipient_utime,priority:2"`
+}
+
+// NotificationActor 聚合通知中的用户，同一用户在一条通知中只计一次
+type NotificationActor struct {
+	Id             int64 `gorm:"primarykey,autoIncrement"`
+	NotificationId int64 `gorm:"uniqueIndex:idx_notify_actor"`
+	ActorId        int64 `gorm:"uniqueIndex:idx_notify_actor"`
+	Ctime          int64
+}
+
+// NotificationEvent 已处理的事件ID，保证同一事件重复投递时只产生一次通知。
+// 只需覆盖事件可能被重复投递的时间，过期的由 DeleteEventsBefore 清理
+type NotificationEvent struct {
+	Id      int64  `gorm:"primarykey,autoIncrement"`
+	EventId string `gorm:"size:64;uniqueIndex"`
+	Ctime   int64  `gorm:"index"`
+}
+
+// NotificationDAO 通知数据访问对象
+type NotificationDAO struct {
+	db *gorm.DB
+}
+
+// NewNotificationDAO 创建 NotificationDAO 实例
+func NewNotificationDAO(db *gorm.DB) *NotificationDAO {
+	return &NotificationDAO{db: db}
+}
+
+// Record 在一个事务内记录事件并聚合到接收人的未读通知中，actorId 为触发通知的用户，
+// 返回聚合后的通知。事件已处理过时不做任何修改，返回 false
+func (d *NotificationDAO) Record(ctx context.Context, eventId string, n Notification, actorId int64) (Notification, bool, error) {
+	recorded := false
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		err := tx.Create(&NotificationEvent{EventId: eventId, Ctime: now}).Error
+		if isDuplicateKey(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		recorded = true
+
+		var agg Notification
+		err = d.findUnreadForUpdate(tx, n, &agg)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			created := n
+			created.LatestActorId = actorId
+			created.ActorCnt = 1
+			created.ReadAt = 0
+			created.Ctime = now
+			created.Utime = now
+			err = tx.Create(&created).Error
+			if err == nil {
+				n = created
+				return tx.Create(&NotificationActor{NotificationId: n.Id, ActorId: actorId, Ctime: now}).Error
+			}
+			if !isDuplicateKey(err) {
+				return err
+			}
+			// 另一个消费者刚建好了同一组的未读通知，聚合到那一条上
+			err = d.findUnreadForUpdate(tx, n, &agg)
+		}
+		if err != nil {
+			return err
+		}
+		n = agg
+		n.LatestActorId = actorId
+		n.Utime = now
+
+		updates := map[string]any{
+			"latest_actor_id": actorId,
+			"utime":           now,
+		}
+		err = tx.Create(&NotificationActor{NotificationId: agg.Id, ActorId: actorId, Ctime: now}).Error
+		switch {
+		case isDuplicateKey(err):
+			// 同一用户重复操作（如取消后再点赞），只刷新时间
+		case err != nil:
+			return err
+		default:
+			updates["actor_cnt"] = gorm.Expr("actor_cnt + 1")
+			n.ActorCnt++
+		}
+		return tx.Model(&Notification{}).Where("id = ?", agg.Id).Updates(updates).Error
+	})
+	return n, recorded, err
+}
+
+// findUnreadForUpdate 锁定与 n 同一组的未读通知
+func (d *NotificationDAO) findUnreadForUpdate(tx *gorm.DB, n Notification, agg *Notification) error {
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("recipient_id = ? AND kind = ? AND post_id = ? AND read_at = 0", n.RecipientId, n.Kind, n.PostId).
+		First(agg).Error
+}
+
+// DeleteEventsBefore 删除 before 之前记录的事件ID，每次最多删除 limit 条，返回删除的条数
+func (d *NotificationDAO) DeleteEventsBefore(ctx context.Context, before int64, limit int) (int64, error) {
+	res := d.db.WithContext(ctx).
+		Where("ctime < ?", before).
+		Limit(limit).
+		Delete(&NotificationEvent{})
+	return res.RowsAffected, res.Error
+}
+
+// FindByRecipient 按最近聚合时间倒序获取通知，cursor 为上一页最后一条的 Utime，0 表示第一页
+func (d *NotificationDAO) FindByRecipient(ctx context.Context, recipientId, cursor int64, limit int, unreadOnly bool) ([]Notification, error) {
+	query := d.db.WithContext(ctx).Where("recipient_id = ?", recipientId)
+	if cursor > 0 {
+		query = query.Where("utime < ?", cursor)
+	}
+	if unreadOnly {
+		query = query.Where("read_at = 0")
+	}
+	var ns []Notification
+	err := query.Order("utime DESC").Limit(limit).Find(&ns).Error
+	return ns, err
+}
+
+// CountUnread 统计未读通知数（聚合后的条数）
+func (d *NotificationDAO) CountUnread(ctx context.Context, recipientId int64) (int64, error) {
+	var count int64
+	err := d.db.WithContext(ctx).Model(&Notification{}).
+		Where("recipient_id = ? AND read_at = 0", recipientId).
+		Count(&count).Error
+	return count, err
+}
+
+// MarkRead 标记一条通知为已读，通知不存在或不属于该用户时返回 gorm.ErrRecordNotFound
+func (d *NotificationDAO) MarkRead(ctx context.Context, id, recipientId int64) error {
+	var n Notification
+	err := d.db.WithContext(ctx).Where("id = ? AND recipient_id = ?", id, recipientId).First(&n).Error
+	if err != nil || n.ReadAt > 0 {
+		return err
+	}
+	return d.db.WithContext(ctx).Model(&Notification{}).
+		Where("id = ? AND read_at = 0", id).
+		Update("read_at", time.Now().UnixMilli()).Error
+}
+
+// MarkAllRead 标记用户的全部通知为已读
+func (d *NotificationDAO) MarkAllRead(ctx context.Context, recipientId int64) error {
+	return d.db.WithContext(ctx).Model(&Notification{}).
+		Where("recipient_id = ? AND read_at = 0", recipientId).
+		Update("read_at", time.Now().UnixMilli()).Error
+}
@@ -0,0 +1,79 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PersonalAccessToken 个人访问令牌实体，只保存令牌的哈希；Scopes 为逗号分隔的权限范围
+type PersonalAccessToken struct {
+	Id        int64  `gorm:"primarykey,autoIncrement"`
+	UserId    int64  `gorm:"index"`
+	Name      string `gorm:"size:64"`
+	TokenHash string `gorm:"size:64;uniqueIndex"`
+	Hint      string `gorm:"size:8"`
+	Scopes    string `gorm:"size:255"`
+	ExpiresAt int64
+	LastUsed  int64
+	Ctime     int64
+}
+
+// PersonalAccessTokenDAO 个人访问令牌数据访问对象
+type PersonalAccessTokenDAO struct {
+	db *gorm.DB
+}
+
+// NewPersonalAccessTokenDAO 创建 PersonalAccessTokenDAO 实例
+func NewPersonalAccessTokenDAO(db *gorm.DB) *PersonalAccessTokenDAO {
+	return &PersonalAccessTokenDAO{db: db}
+}
+
+// Insert 写入令牌，返回带 Id 和 Ctime 的记录
+func (d *PersonalAccessTokenDAO) Insert(ctx context.Context, t PersonalAccessToken) (PersonalAccessToken, error) {
+	t.Ctime = time.Now().UnixMilli()
+	err := d.db.WithContext(ctx).Create(&t).Error
+	return t, err
+}
+
+// FindByHash 按令牌哈希查询
+func (d *PersonalAccessTokenDAO) FindByHash(ctx context.Context, hash string) (PersonalAccessToken, error) {
+	var t PersonalAccessToken
+	err := d.db.WithContext(ctx).Where("token_hash = ?", hash).First(&t).Error
+	return t, err
+}
+
+// FindByUser 按创建时间倒序获取用户的令牌
+func (d *PersonalAccessTokenDAO) FindByUser(ctx context.Context, userId int64) ([]PersonalAccessToken, error) {
+	var tokens []PersonalAccessToken
+	err := d.db.WithContext(ctx).
+		Where("user_id = ?", userId).
+		Order("id DESC").
+		Find(&tokens).Error
+	return tokens, err
+}
+
+// Delete 删除用户的一个令牌，不存在时返回 gorm.ErrRecordNotFound
+func (d *PersonalAccessTokenDAO) Delete(ctx context.Context, userId, id int64) error {
+	res := d.db.WithContext(ctx).Delete(&PersonalAccessToken{}, "id = ? AND user_id = ?", id, userId)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteByUser 删除用户的全部令牌
+func (d *PersonalAccessTokenDAO) DeleteByUser(ctx context.Context, userId int64) error {
+	return d.db.WithContext(ctx).Delete(&PersonalAccessToken{}, "user_id = ?", userId).Error
+}
+
+// UpdateLastUsed 更新最近使用时间
+func (d *PersonalAccessTokenDAO) UpdateLastUsed(ctx context.Context, id, lastUsed int64) error {
+	return d.db.WithContext(ctx).Model(&PersonalAccessToken{}).
+		Where("id = ?", id).
+		Update("last_used", lastUsed).Error
+}
@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"gorm.io/gorm"
@@ -10,23 +11,41 @@ import (
 
 // Post 制作库实体（作者编辑用）
 type Post struct {
-	Id       int64  `gorm:"primarykey,autoIncrement"`
-	Title    string `gorm:"size:256"`
-	Content  string `gorm:"type:text"`
-	AuthorId int64  `gorm:"index"`
-	Status   uint8  // 0-未发布，1-已发布，2-仅自己可见
-	Ctime    int64
-	Utime    int64
+	Id          int64  `gorm:"primarykey,autoIncrement"`
+	Title       string `gorm:"size:256"`
+	Content     string `gorm:"type:text"`
+	AuthorId    int64  `gorm:"index"`
+	Status      uint8  `gorm:"index:idx_post_status_scheduled"` // 0-未发布，1-已发布，2-仅自己可见，3-定时发布，4-被管理员隐藏
+	ScheduledAt int64  `gorm:"index:idx_post_status_scheduled"` // 定时发布时间（毫秒）
+	Ctime       int64
+	Utime       int64
+
+	TagIds []int64 `gorm:"-"` // 标签ID列表，nil 表示不修改标签
 }
 
+// 帖子状态，与 domain 中的 PostStatus 常量保持一致
+const (
+	postStatusDraft     uint8 = 0
+	postStatusPrivate   uint8 = 2
+	postStatusScheduled uint8 = 3
+	postStatusHidden    uint8 = 4
+)
+
+var (
+	// ErrPostNotDraft 帖子不是草稿，不能设置定时发布
+	ErrPostNotDraft = errors.New("帖子不是草稿")
+	// ErrPostHidden 帖子被管理员隐藏，作者不能发布或修改状态
+	ErrPostHidden = errors.New("帖子已被管理员隐藏")
+)
+
 // PublishedPost 线上库实体（读者阅读用）
 type PublishedPost struct {
 	Id       int64  `gorm:"primarykey"` // 与 Post.Id 相同
 	Title    string `gorm:"size:256"`
 	Content  string `gorm:"type:text"`
-	AuthorId int64  `gorm:"index"`
-	Ctime    int64
-	Utime    int64 // 发布时间
+	AuthorId int64  `gorm:"index:idx_published_author_ctime,priority:1"`
+	Ctime    int64  `gorm:"index:idx_published_author_ctime,priority:2"` // 首次发布时间
+	Utime    int64  // 发布时间
 }
 
 // PostDAO 帖子数据访问对象（制作库）
@@ -39,33 +58,52 @@ func NewPostDAO(db *gorm.DB) *PostDAO {
 	return &PostDAO{db: db}
 }
 
-// Insert 创建帖子
+// Insert 创建帖子（同时记录第一个修订版本）
 func (d *PostDAO) Insert(ctx context.Context, p Post) (int64, error) {
 	now := time.Now().UnixMilli()
 	p.Ctime = now
 	p.Utime = now
-	err := d.db.WithContext(ctx).Create(&p).Error
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&p).Error; err != nil {
+			return err
+		}
+		if p.TagIds != nil {
+			if err := replacePostTags(tx, p.Id, p.TagIds); err != nil {
+				return err
+			}
+		}
+		return insertRevision(tx, p)
+	})
 	return p.Id, err
 }
 
-// UpdateById 根据ID更新帖子（只能更新自己的帖子）
+// UpdateById 根据ID更新帖子（只能更新自己的帖子，同时记录修订版本）
+// 已设置定时发布的帖子保存草稿时保留定时状态，到点发布的是最新内容；
+// 被管理员隐藏的帖子可以继续编辑，但保持隐藏状态
 func (d *PostDAO) UpdateById(ctx context.Context, p Post) error {
 	now := time.Now().UnixMilli()
-	res := d.db.WithContext(ctx).Model(&Post{}).
-		Where("id = ? AND author_id = ?", p.Id, p.AuthorId).
-		Updates(map[string]any{
-			"title":   p.Title,
-			"content": p.Content,
-			"status":  p.Status,
-			"utime":   now,
-		})
-	if res.Error != nil {
-		return res.Error
-	}
-	if res.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
-	}
-	return nil
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Post{}).
+			Where("id = ? AND author_id = ?", p.Id, p.AuthorId).
+			Updates(map[string]any{
+				"title":   p.Title,
+				"content": p.Content,
+				"status":  gorm.Expr("CASE WHEN status IN (?, ?) THEN status ELSE ? END", postStatusScheduled, postStatusHidden, p.Status),
+				"utime":   now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if p.TagIds != nil {
+			if err := replacePostTags(tx, p.Id, p.TagIds); err != nil {
+				return err
+			}
+		}
+		return insertRevision(tx, p)
+	})
 }
 
 // FindById 根据ID查找帖子
@@ -94,8 +132,10 @@ func (d *PostDAO) CountByAuthor(ctx context.Context, authorId int64) (int64, err
 	return count, err
 }
 
-// Sync 发布帖子（同步到线上库，事务操作）
-func (d *PostDAO) Sync(ctx context.Context, p Post) (int64, error) {
+// Sync 发布帖子（同步到线上库，事务操作）。
+// 帖子首次上线时，在同一事务内写入 toEvent 生成的发布事件，驱动关注流的分发。
+// 被管理员隐藏的帖子返回 ErrPostHidden
+func (d *PostDAO) Sync(ctx context.Context, p Post, toEvent func(PublishedPost) PostStatsOutbox) (int64, error) {
 	var id int64
 	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var err error
@@ -106,6 +146,7 @@ func (d *PostDAO) Sync(ctx context.Context, p Post) (int64, error) {
 			p.Ctime = now
 			p.Utime = now
 			p.Status = 1 // 已发布
+			p.ScheduledAt = 0
 			err = tx.Create(&p).Error
 			if err != nil {
 				return err
@@ -113,47 +154,120 @@ func (d *PostDAO) Sync(ctx context.Context, p Post) (int64, error) {
 			id = p.Id
 		} else {
 			// 更新现有帖子并设置为已发布
-			err = tx.Model(&Post{}).
-				Where("id = ? AND author_id = ?", p.Id, p.AuthorId).
+			res := tx.Model(&Post{}).
+				Where("id = ? AND author_id = ? AND status <> ?", p.Id, p.AuthorId, postStatusHidden).
 				Updates(map[string]any{
-					"title":   p.Title,
-					"content": p.Content,
-					"status":  1, // 已发布
-					"utime":   time.Now().UnixMilli(),
-				}).Error
-			if err != nil {
-				return err
+					"title":        p.Title,
+					"content":      p.Content,
+					"status":       1, // 已发布
+					"scheduled_at": 0, // 发布后清除定时
+					"utime":        time.Now().UnixMilli(),
+				})
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return missReason(tx, p.Id, p.AuthorId, gorm.ErrRecordNotFound)
 			}
 			id = p.Id
 		}
 
-		// 2. Upsert 到线上库
-		now := time.Now().UnixMilli()
-		pubPost := PublishedPost{
-			Id:       id,
-			Title:    p.Title,
-			Content:  p.Content,
-			AuthorId: p.AuthorId,
-			Ctime:    now,
-			Utime:    now,
-		}
-		return tx.Clauses(clause.OnConflict{
-			Columns:   []clause.Column{{Name: "id"}},
-			DoUpdates: clause.AssignmentColumns([]string{"title", "content", "utime"}),
-		}).Create(&pubPost).Error
+		p.Id = id
+		p.Status = 1
+		return syncOnline(tx, p, toEvent)
 	})
 	return id, err
 }
 
-// SyncStatus 同步状态（设为仅自己可见时，需同时删除线上库）
-func (d *PostDAO) SyncStatus(ctx context.Context, id int64, authorId int64, status uint8) error {
-	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// 1. 更新制作库状态
+// syncOnline 在调用方事务内记录修订版本、同步标签并把帖子写入线上库，
+// 帖子首次上线时写入 toEvent 生成的发布事件
+func syncOnline(tx *gorm.DB, p Post, toEvent func(PublishedPost) PostStatsOutbox) error {
+	// 1. 记录修订版本
+	if err := insertRevision(tx, p); err != nil {
+		return err
+	}
+
+	// 2. 更新制作库标签，并同步到线上库
+	if p.TagIds != nil {
+		if err := replacePostTags(tx, p.Id, p.TagIds); err != nil {
+			return err
+		}
+	}
+	if err := syncPublishedTags(tx, p.Id); err != nil {
+		return err
+	}
+
+	// 3. Upsert 到线上库，已在线的帖子只更新内容
+	var online int64
+	if err := tx.Model(&PublishedPost{}).Where("id = ?", p.Id).Count(&online).Error; err != nil {
+		return err
+	}
+	now := time.Now().UnixMilli()
+	pubPost := PublishedPost{
+		Id:       p.Id,
+		Title:    p.Title,
+		Content:  p.Content,
+		AuthorId: p.AuthorId,
+		Ctime:    now,
+		Utime:    now,
+	}
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "content", "utime"}),
+	}).Create(&pubPost).Error
+	if err != nil || online > 0 {
+		return err
+	}
+
+	// 4. 首次上线，写入发布事件
+	return insertOutbox(tx, toEvent(pubPost))
+}
+
+// Schedule 设置定时发布（只能操作自己的草稿）。
+// 帖子被管理员隐藏时返回 ErrPostHidden，存在但不是草稿时返回 ErrPostNotDraft
+func (d *PostDAO) Schedule(ctx context.Context, id int64, authorId int64, scheduledAt int64) error {
+	res := d.db.WithContext(ctx).Model(&Post{}).
+		Where("id = ? AND author_id = ? AND status = ?", id, authorId, postStatusDraft).
+		Updates(map[string]any{
+			"status":       postStatusScheduled,
+			"scheduled_at": scheduledAt,
+			"utime":        time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected > 0 {
+		return nil
+	}
+	return missReason(d.db.WithContext(ctx), id, authorId, ErrPostNotDraft)
+}
+
+// missReason 条件更新没有命中时查明原因：帖子不存在返回 gorm.ErrRecordNotFound，
+// 被管理员隐藏返回 ErrPostHidden，其余情况返回 other
+func missReason(db *gorm.DB, id int64, authorId int64, other error) error {
+	var p Post
+	err := db.Select("status").Where("id = ? AND author_id = ?", id, authorId).First(&p).Error
+	if err != nil {
+		return err
+	}
+	if p.Status == postStatusHidden {
+		return ErrPostHidden
+	}
+	return other
+}
+
+// PublishScheduled 发布到点的定时帖子，发布的是制作库中的最新内容。
+// 只有仍处于定时状态且已到发布时间的帖子会被发布，帖子在查询之后被取消、改期、删除
+// 或被管理员隐藏时条件更新不会命中，返回 gorm.ErrRecordNotFound
+func (d *PostDAO) PublishScheduled(ctx context.Context, id int64, now int64, toEvent func(PublishedPost) PostStatsOutbox) (Post, error) {
+	var p Post
+	err := d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		res := tx.Model(&Post{}).
-			Where("id = ? AND author_id = ?", id, authorId).
+			Where("id = ? AND status = ? AND scheduled_at <= ?", id, postStatusScheduled, now).
 			Updates(map[string]any{
-				"status": status,
-				"utime":  time.Now().UnixMilli(),
+				"status":       1, // 已发布
+				"scheduled_at": 0,
+				"utime":        time.Now().UnixMilli(),
 			})
 		if res.Error != nil {
 			return res.Error
@@ -161,15 +275,110 @@ func (d *PostDAO) SyncStatus(ctx context.Context, id int64, authorId int64, stat
 		if res.RowsAffected == 0 {
 			return gorm.ErrRecordNotFound
 		}
+		// 该行已被本事务的更新锁住，读到的就是要发布的内容
+		if err := tx.Where("id = ?", id).First(&p).Error; err != nil {
+			return err
+		}
+		return syncOnline(tx, p, toEvent)
+	})
+	return p, err
+}
+
+// Reschedule 修改定时发布时间（仅对处于定时状态的帖子生效）
+func (d *PostDAO) Reschedule(ctx context.Context, id int64, authorId int64, scheduledAt int64) error {
+	res := d.db.WithContext(ctx).Model(&Post{}).
+		Where("id = ? AND author_id = ? AND status = ?", id, authorId, postStatusScheduled).
+		Updates(map[string]any{
+			"scheduled_at": scheduledAt,
+			"utime":        time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CancelSchedule 取消定时发布，帖子回到草稿状态
+func (d *PostDAO) CancelSchedule(ctx context.Context, id int64, authorId int64) error {
+	res := d.db.WithContext(ctx).Model(&Post{}).
+		Where("id = ? AND author_id = ? AND status = ?", id, authorId, postStatusScheduled).
+		Updates(map[string]any{
+			"status":       0,
+			"scheduled_at": 0,
+			"utime":        time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// FindDueScheduled 查找到达发布时间的定时帖子
+func (d *PostDAO) FindDueScheduled(ctx context.Context, now int64, limit int) ([]Post, error) {
+	var posts []Post
+	err := d.db.WithContext(ctx).
+		Where("status = ? AND scheduled_at <= ?", postStatusScheduled, now).
+		Order("scheduled_at ASC").
+		Limit(limit).
+		Find(&posts).Error
+	return posts, err
+}
+
+// SyncStatus 同步状态（设为仅自己可见或隐藏时，需同时删除线上库）。
+// 被管理员隐藏的帖子只能通过 Unhide 解除，改为其他状态时返回 ErrPostHidden
+func (d *PostDAO) SyncStatus(ctx context.Context, id int64, authorId int64, status uint8) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 1. 更新制作库状态
+		query := tx.Model(&Post{}).Where("id = ? AND author_id = ?", id, authorId)
+		if status != postStatusHidden {
+			query = query.Where("status <> ?", postStatusHidden)
+		}
+		res := query.Updates(map[string]any{
+			"status": status,
+			"utime":  time.Now().UnixMilli(),
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return missReason(tx, id, authorId, gorm.ErrRecordNotFound)
+		}
 
-		// 2. 如果设为仅自己可见，删除线上库
-		if status == 2 {
-			return tx.Delete(&PublishedPost{}, "id = ?", id).Error
+		// 2. 如果设为仅自己可见或隐藏，删除线上库及其标签关联
+		if status == postStatusPrivate || status == postStatusHidden {
+			if err := tx.Delete(&PublishedPost{}, "id = ?", id).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&PublishedPostTag{}, "post_id = ?", id).Error
 		}
 		return nil
 	})
 }
 
+// Unhide 解除管理员隐藏，帖子回到仅自己可见状态，作者可以重新发布。
+// 帖子不存在或未被隐藏时返回 gorm.ErrRecordNotFound
+func (d *PostDAO) Unhide(ctx context.Context, id int64) error {
+	res := d.db.WithContext(ctx).Model(&Post{}).
+		Where("id = ? AND status = ?", id, postStatusHidden).
+		Updates(map[string]any{
+			"status": postStatusPrivate,
+			"utime":  time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
 // PublishedPostDAO 线上库数据访问对象（读者用）
 type PublishedPostDAO struct {
 	db *gorm.DB
@@ -187,6 +396,16 @@ func (d *PublishedPostDAO) FindById(ctx context.Context, id int64) (PublishedPos
 	return p, err
 }
 
+// FindByIds 批量获取已发布帖子
+func (d *PublishedPostDAO) FindByIds(ctx context.Context, ids []int64) ([]PublishedPost, error) {
+	var posts []PublishedPost
+	if len(ids) == 0 {
+		return posts, nil
+	}
+	err := d.db.WithContext(ctx).Where("id IN ?", ids).Find(&posts).Error
+	return posts, err
+}
+
 // List 获取已发布帖子列表
 func (d *PublishedPostDAO) List(ctx context.Context, offset, limit int) ([]PublishedPost, error) {
 	var posts []PublishedPost
@@ -198,6 +417,115 @@ func (d *PublishedPostDAO) List(ctx context.Context, offset, limit int) ([]Publi
 	return posts, err
 }
 
+// ListAfterId 按 ID 升序获取 ID 大于 afterId 的已发布帖子，用于全量遍历；
+// 遍历期间帖子被更新不会改变其位置，不会漏掉或重复
+func (d *PublishedPostDAO) ListAfterId(ctx context.Context, afterId int64, limit int) ([]PublishedPost, error) {
+	var posts []PublishedPost
+	err := d.db.WithContext(ctx).
+		Where("id > ?", afterId).
+		Order("id ASC").
+		Limit(limit).
+		Find(&posts).Error
+	return posts, err
+}
+
+// ListByTag 获取带有指定标签的已发布帖子列表
+func (d *PublishedPostDAO) ListByTag(ctx context.Context, tagId int64, offset, limit int) ([]PublishedPost, error) {
+	var posts []PublishedPost
+	err := d.db.WithContext(ctx).
+		Joins("JOIN published_post_tags ON published_post_tags.post_id = published_posts.id").
+		Where("published_post_tags.tag_id = ?", tagId).
+		Order("published_posts.utime DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&posts).Error
+	return posts, err
+}
+
+// CountByTag 统计带有指定标签的已发布帖子数
+func (d *PublishedPostDAO) CountByTag(ctx context.Context, tagId int64) (int64, error) {
+	var count int64
+	err := d.db.WithContext(ctx).Model(&PublishedPostTag{}).Where("tag_id = ?", tagId).Count(&count).Error
+	return count, err
+}
+
+// ListLikedBy 获取用户点赞的已发布帖子，按点赞时间倒序
+func (d *PublishedPostDAO) ListLikedBy(ctx context.Context, userId int64, offset, limit int) ([]PublishedPost, error) {
+	return d.listByRelation(ctx, "post_like_relations", userId, offset, limit)
+}
+
+// CountLikedBy 统计用户点赞的已发布帖子数
+func (d *PublishedPostDAO) CountLikedBy(ctx context.Context, userId int64) (int64, error) {
+	return d.countByRelation(ctx, "post_like_relations", userId)
+}
+
+// ListCollectedBy 获取用户收藏的已发布帖子（不区分收藏夹），按收藏时间倒序
+func (d *PublishedPostDAO) ListCollectedBy(ctx context.Context, userId int64, offset, limit int) ([]PublishedPost, error) {
+	return d.listByRelation(ctx, "post_collect_relations", userId, offset, limit)
+}
+
+// CountCollectedBy 统计用户收藏的已发布帖子数
+func (d *PublishedPostDAO) CountCollectedBy(ctx context.Context, userId int64) (int64, error) {
+	return d.countByRelation(ctx, "post_collect_relations", userId)
+}
+
+// listByRelation 通过点赞/收藏关系表查询帖子，按点赞/收藏时间（关系表的 ctime）倒序，
+// 走关系表的 (user_id, status, ctime) 索引；移动收藏夹只改 utime，不影响顺序。
+// 已删除或下线的帖子被 JOIN 过滤掉
+func (d *PublishedPostDAO) listByRelation(ctx context.Context, table string, userId int64, offset, limit int) ([]PublishedPost, error) {
+	var posts []PublishedPost
+	err := d.db.WithContext(ctx).
+		Joins("JOIN "+table+" r ON r.post_id = published_posts.id").
+		Where("r.user_id = ? AND r.status = 1", userId).
+		Order("r.ctime DESC, r.id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&posts).Error
+	return posts, err
+}
+
+func (d *PublishedPostDAO) countByRelation(ctx context.Context, table string, userId int64) (int64, error) {
+	var count int64
+	err := d.db.WithContext(ctx).Model(&PublishedPost{}).
+		Joins("JOIN "+table+" r ON r.post_id = published_posts.id").
+		Where("r.user_id = ? AND r.status = 1", userId).
+		Count(&count).Error
+	return count, err
+}
+
+// ListByAuthors 按首次发布时间倒序获取多个作者的帖子，发布时间相同时按ID倒序；
+// cursorCtime、cursorId 为上一页最后一条的发布时间和ID，cursorCtime 为 0 表示第一页
+func (d *PublishedPostDAO) ListByAuthors(ctx context.Context, authorIds []int64, cursorCtime, cursorId int64, limit int) ([]PublishedPost, error) {
+	var posts []PublishedPost
+	if len(authorIds) == 0 {
+		return posts, nil
+	}
+	query := d.db.WithContext(ctx).Where("author_id IN ?", authorIds)
+	if cursorCtime > 0 {
+		query = query.Where("ctime < ? OR (ctime = ? AND id < ?)", cursorCtime, cursorCtime, cursorId)
+	}
+	err := query.Order("ctime DESC, id DESC").Limit(limit).Find(&posts).Error
+	return posts, err
+}
+
+// CountByAuthor 统计作者的已发布帖子数
+func (d *PublishedPostDAO) CountByAuthor(ctx context.Context, authorId int64) (int64, error) {
+	var count int64
+	err := d.db.WithContext(ctx).Model(&PublishedPost{}).Where("author_id = ?", authorId).Count(&count).Error
+	return count, err
+}
+
+// SumLikesByAuthor 统计作者已发布帖子的点赞总数，读取的是已刷入 post_stats 的计数
+func (d *PublishedPostDAO) SumLikesByAuthor(ctx context.Context, authorId int64) (int64, error) {
+	var sum int64
+	err := d.db.WithContext(ctx).Model(&PublishedPost{}).
+		Joins("JOIN post_stats s ON s.post_id = published_posts.id").
+		Where("published_posts.author_id = ?", authorId).
+		Select("COALESCE(SUM(s.like_cnt), 0)").
+		Scan(&sum).Error
+	return sum, err
+}
+
 // Count 统计已发布帖子总数
 func (d *PublishedPostDAO) Count(ctx context.Context) (int64, error) {
 	var count int64
@@ -0,0 +1,109 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PostDailyStats stores the interactions a post received on one day.
+type PostDailyStats struct {
+	PostId       int64  `gorm:"primaryKey;autoIncrement:false"`
+	Date         string `gorm:"primaryKey;type:char(10);index"`
+	ReadCnt      int64
+	LikeCnt      int64
+	UnlikeCnt    int64
+	CollectCnt   int64
+	UncollectCnt int64
+	// Overwritten by reader snapshots, not incremented.
+	UniqueReadCnt int64
+	Ctime         int64
+	Utime         int64
+}
+
+// AuthorPostDailySum is one post's counters summed over a date range.
+type AuthorPostDailySum struct {
+	PostId       int64
+	Title        string
+	ReadCnt      int64
+	LikeCnt      int64
+	UnlikeCnt    int64
+	CollectCnt   int64
+	UncollectCnt int64
+}
+
+// dailySumColumns leaves out unique_read_cnt: distinct readers of different days or
+// posts overlap, so their sum is not a distinct count.
+const dailySumColumns = "SUM(d.read_cnt) AS read_cnt, SUM(d.like_cnt) AS like_cnt, SUM(d.unlike_cnt) AS unlike_cnt, " +
+	"SUM(d.collect_cnt) AS collect_cnt, SUM(d.uncollect_cnt) AS uncollect_cnt"
+
+type PostDailyStatsDAO struct {
+	db *gorm.DB
+}
+
+func NewPostDailyStatsDAO(db *gorm.DB) *PostDailyStatsDAO {
+	return &PostDailyStatsDAO{db: db}
+}
+
+// IncrBatch adds the given deltas to the stored counters, creating missing rows.
+func (dao *PostDailyStatsDAO) IncrBatch(ctx context.Context, deltas []PostDailyStats) error {
+	if len(deltas) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	for i := range deltas {
+		deltas[i].Ctime = now
+		deltas[i].Utime = now
+	}
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "post_id"}, {Name: "date"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"read_cnt":      gorm.Expr("read_cnt + VALUES(read_cnt)"),
+			"like_cnt":      gorm.Expr("like_cnt + VALUES(like_cnt)"),
+			"unlike_cnt":    gorm.Expr("unlike_cnt + VALUES(unlike_cnt)"),
+			"collect_cnt":   gorm.Expr("collect_cnt + VALUES(collect_cnt)"),
+			"uncollect_cnt": gorm.Expr("uncollect_cnt + VALUES(uncollect_cnt)"),
+			"utime":         now,
+		}),
+	}).Create(&deltas).Error
+}
+
+// FindByPost returns the rows of a post with date in [from, to], oldest first.
+func (dao *PostDailyStatsDAO) FindByPost(ctx context.Context, postId int64, from, to string) ([]PostDailyStats, error) {
+	var rows []PostDailyStats
+	err := dao.db.WithContext(ctx).
+		Where("post_id = ? AND date BETWEEN ? AND ?", postId, from, to).
+		Order("date ASC").
+		Find(&rows).Error
+	return rows, err
+}
+
+// SumByAuthorPerDay sums the rows of all posts of an author per day, oldest first.
+func (dao *PostDailyStatsDAO) SumByAuthorPerDay(ctx context.Context, authorId int64, from, to string) ([]PostDailyStats, error) {
+	var rows []PostDailyStats
+	err := dao.db.WithContext(ctx).
+		Table("post_daily_stats AS d").
+		Select("d.date AS date, "+dailySumColumns).
+		Joins("JOIN posts AS p ON p.id = d.post_id").
+		Where("p.author_id = ? AND d.date BETWEEN ? AND ?", authorId, from, to).
+		Group("d.date").
+		Order("d.date ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// SumByAuthorPerPost sums the rows of each post of an author over the range, most read first.
+func (dao *PostDailyStatsDAO) SumByAuthorPerPost(ctx context.Context, authorId int64, from, to string) ([]AuthorPostDailySum, error) {
+	var rows []AuthorPostDailySum
+	err := dao.db.WithContext(ctx).
+		Table("post_daily_stats AS d").
+		Select("d.post_id AS post_id, p.title AS title, "+dailySumColumns).
+		Joins("JOIN posts AS p ON p.id = d.post_id").
+		Where("p.author_id = ? AND d.date BETWEEN ? AND ?", authorId, from, to).
+		Group("d.post_id, p.title").
+		Order("read_cnt DESC, d.post_id DESC").
+		Scan(&rows).Error
+	return rows, err
+}
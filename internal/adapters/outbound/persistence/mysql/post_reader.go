@@ -0,0 +1,84 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PostReaderSketch stores the lifetime unique reader HyperLogLog of a post as
+// dumped from Redis, so it can be restored after Redis loses it.
+type PostReaderSketch struct {
+	PostId int64  `gorm:"primaryKey;autoIncrement:false"`
+	Sketch []byte `gorm:"type:blob"`
+	Ctime  int64
+	Utime  int64
+}
+
+// PostReaderSnapshot is one post's reader snapshot as written by SaveSnapshots.
+type PostReaderSnapshot struct {
+	PostId   int64
+	Sketch   []byte
+	Count    int64
+	Date     string
+	DayCount int64
+}
+
+type PostReaderDAO struct {
+	db *gorm.DB
+}
+
+func NewPostReaderDAO(db *gorm.DB) *PostReaderDAO {
+	return &PostReaderDAO{db: db}
+}
+
+// FindSketch returns nil if the post has no saved sketch.
+func (dao *PostReaderDAO) FindSketch(ctx context.Context, postId int64) ([]byte, error) {
+	var row PostReaderSketch
+	err := dao.db.WithContext(ctx).Where("post_id = ?", postId).First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return row.Sketch, err
+}
+
+// SaveSnapshots stores the sketches and copies the counts into post_stats and
+// post_daily_stats, in one transaction.
+func (dao *PostReaderDAO) SaveSnapshots(ctx context.Context, snaps []PostReaderSnapshot) error {
+	if len(snaps) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	sketches := make([]PostReaderSketch, 0, len(snaps))
+	stats := make([]PostStats, 0, len(snaps))
+	daily := make([]PostDailyStats, 0, len(snaps))
+	for _, s := range snaps {
+		sketches = append(sketches, PostReaderSketch{PostId: s.PostId, Sketch: s.Sketch, Ctime: now, Utime: now})
+		stats = append(stats, PostStats{PostId: s.PostId, UniqueReadCnt: s.Count, Ctime: now, Utime: now})
+		daily = append(daily, PostDailyStats{PostId: s.PostId, Date: s.Date, UniqueReadCnt: s.DayCount, Ctime: now, Utime: now})
+	}
+
+	return dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "post_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"sketch", "utime"}),
+		}).Create(&sketches).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "post_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"unique_read_cnt", "utime"}),
+		}).Create(&stats).Error
+		if err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "post_id"}, {Name: "date"}},
+			DoUpdates: clause.AssignmentColumns([]string{"unique_read_cnt", "utime"}),
+		}).Create(&daily).Error
+	})
+}
@@ -0,0 +1,78 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PostRevision 帖子修订版本实体（只追加，不更新）
+type PostRevision struct {
+	Id       int64  `gorm:"primarykey,autoIncrement"`
+	PostId   int64  `gorm:"uniqueIndex:idx_revision_post_version"`
+	Version  int64  `gorm:"uniqueIndex:idx_revision_post_version"`
+	Title    string `gorm:"size:256"`
+	Content  string `gorm:"type:text"`
+	AuthorId int64  `gorm:"index"`
+	Status   uint8  // 生成该版本时帖子的状态
+	Ctime    int64
+}
+
+// PostRevisionDAO 帖子修订版本数据访问对象
+type PostRevisionDAO struct {
+	db *gorm.DB
+}
+
+// NewPostRevisionDAO 创建 PostRevisionDAO 实例
+func NewPostRevisionDAO(db *gorm.DB) *PostRevisionDAO {
+	return &PostRevisionDAO{db: db}
+}
+
+// FindByPostId 按版本号倒序获取帖子的修订列表
+func (d *PostRevisionDAO) FindByPostId(ctx context.Context, postId int64, offset, limit int) ([]PostRevision, error) {
+	var revisions []PostRevision
+	err := d.db.WithContext(ctx).
+		Where("post_id = ?", postId).
+		Order("version DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&revisions).Error
+	return revisions, err
+}
+
+// CountByPostId 统计帖子的修订数量
+func (d *PostRevisionDAO) CountByPostId(ctx context.Context, postId int64) (int64, error) {
+	var count int64
+	err := d.db.WithContext(ctx).Model(&PostRevision{}).Where("post_id = ?", postId).Count(&count).Error
+	return count, err
+}
+
+// FindByVersion 获取帖子的指定版本
+func (d *PostRevisionDAO) FindByVersion(ctx context.Context, postId, version int64) (PostRevision, error) {
+	var r PostRevision
+	err := d.db.WithContext(ctx).Where("post_id = ? AND version = ?", postId, version).First(&r).Error
+	return r, err
+}
+
+// insertRevision 在调用方事务内为帖子追加一个新版本
+// 调用前制作库中的帖子行已被本事务更新（持有行锁），因此同一帖子的版本号不会并发冲突
+func insertRevision(tx *gorm.DB, p Post) error {
+	var maxVersion int64
+	err := tx.Model(&PostRevision{}).
+		Where("post_id = ?", p.Id).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&maxVersion).Error
+	if err != nil {
+		return err
+	}
+	return tx.Create(&PostRevision{
+		PostId:   p.Id,
+		Version:  maxVersion + 1,
+		Title:    p.Title,
+		Content:  p.Content,
+		AuthorId: p.AuthorId,
+		Status:   p.Status,
+		Ctime:    time.Now().UnixMilli(),
+	}).Error
+}
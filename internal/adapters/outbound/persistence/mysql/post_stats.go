@@ -2,6 +2,7 @@ package mysql
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"gorm.io/gorm"
@@ -14,28 +15,41 @@ type PostStats struct {
 	LikeCnt    int64
 	CollectCnt int64
 	ReadCnt    int64
-	Ctime      int64
-	Utime      int64
+	CommentCnt int64
+	// Written by reader snapshots only, never by the counter flush.
+	UniqueReadCnt int64
+	Ctime         int64
+	Utime         int64
 }
 
 // PostLikeRelation stores like status per user and post.
 type PostLikeRelation struct {
 	Id     int64 `gorm:"primaryKey,autoIncrement"`
 	PostId int64 `gorm:"uniqueIndex:idx_like_post_user"`
-	UserId int64 `gorm:"uniqueIndex:idx_like_post_user"`
-	Status uint8
-	Ctime  int64
-	Utime  int64
+	UserId int64 `gorm:"uniqueIndex:idx_like_post_user;index:idx_like_user_status_ctime,priority:1"`
+	Status uint8 `gorm:"index:idx_like_user_status_ctime,priority:2"`
+	// Reset whenever the user likes again, so it is the time of the current like.
+	Ctime int64 `gorm:"index:idx_like_user_status_ctime,priority:3"`
+	Utime int64
 }
 
-// PostCollectRelation stores collect status per user and post.
+// PostCollectRelation stores collect status per user and post. A post sits in
+// exactly one of the user's folders, so CollectCnt still counts it once.
 type PostCollectRelation struct {
-	Id     int64 `gorm:"primaryKey,autoIncrement"`
-	PostId int64 `gorm:"uniqueIndex:idx_collect_post_user"`
-	UserId int64 `gorm:"uniqueIndex:idx_collect_post_user"`
-	Status uint8
-	Ctime  int64
-	Utime  int64
+	Id       int64 `gorm:"primaryKey,autoIncrement"`
+	PostId   int64 `gorm:"uniqueIndex:idx_collect_post_user"`
+	UserId   int64 `gorm:"uniqueIndex:idx_collect_post_user;index:idx_collect_user_folder,priority:1;index:idx_collect_user_status_ctime,priority:1"`
+	FolderId int64 `gorm:"index:idx_collect_user_folder,priority:2"` // 0 is the default folder
+	Status   uint8 `gorm:"index:idx_collect_user_status_ctime,priority:2"`
+	// Reset whenever the user collects again, moving folders only touches Utime.
+	Ctime int64 `gorm:"index:idx_collect_user_status_ctime,priority:3"`
+	Utime int64
+}
+
+// PostCount is a per-post row count.
+type PostCount struct {
+	PostId int64
+	Cnt    int64
 }
 
 type PostStatsDAO struct {
@@ -62,10 +76,46 @@ func (dao *PostStatsDAO) Upsert(ctx context.Context, stats []PostStats) error {
 	}
 	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
 		Columns:   []clause.Column{{Name: "post_id"}},
-		DoUpdates: clause.AssignmentColumns([]string{"like_cnt", "collect_cnt", "read_cnt", "utime"}),
+		DoUpdates: clause.AssignmentColumns([]string{"like_cnt", "collect_cnt", "read_cnt", "comment_cnt", "utime"}),
 	}).Create(&stats).Error
 }
 
+// FindByPostRange returns stats rows with post_id in [from, to).
+func (dao *PostStatsDAO) FindByPostRange(ctx context.Context, from, to int64) ([]PostStats, error) {
+	var stats []PostStats
+	err := dao.db.WithContext(ctx).Where("post_id >= ? AND post_id < ?", from, to).Find(&stats).Error
+	return stats, err
+}
+
+// MaxPostId returns the largest post id referenced by the stats or relation tables.
+func (dao *PostStatsDAO) MaxPostId(ctx context.Context) (int64, error) {
+	var maxId int64
+	for _, model := range []any{&PostStats{}, &PostLikeRelation{}, &PostCollectRelation{}} {
+		var id int64
+		err := dao.db.WithContext(ctx).Model(model).Select("COALESCE(MAX(post_id), 0)").Scan(&id).Error
+		if err != nil {
+			return 0, err
+		}
+		maxId = max(maxId, id)
+	}
+	return maxId, nil
+}
+
+// UpdateCounts overwrites like/collect counters, leaving the other counters untouched.
+func (dao *PostStatsDAO) UpdateCounts(ctx context.Context, postId, likeCnt, collectCnt int64) error {
+	now := time.Now().UnixMilli()
+	return dao.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "post_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"like_cnt", "collect_cnt", "utime"}),
+	}).Create(&PostStats{
+		PostId:     postId,
+		LikeCnt:    likeCnt,
+		CollectCnt: collectCnt,
+		Ctime:      now,
+		Utime:      now,
+	}).Error
+}
+
 type PostLikeDAO struct {
 	db *gorm.DB
 }
@@ -80,18 +130,60 @@ func (dao *PostLikeDAO) FindByPostIdUserId(ctx context.Context, postId, userId i
 	return rel, err
 }
 
-func (dao *PostLikeDAO) Insert(ctx context.Context, rel *PostLikeRelation) error {
-	now := time.Now().UnixMilli()
-	rel.Ctime = now
-	rel.Utime = now
-	return dao.db.WithContext(ctx).Create(rel).Error
+// SetStatus changes the relation status and, if it actually changed, writes the
+// outbox event in the same transaction. It reports whether the status changed.
+func (dao *PostLikeDAO) SetStatus(ctx context.Context, postId, userId int64, status uint8, event PostStatsOutbox) (bool, error) {
+	changed := false
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		var rel PostLikeRelation
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("post_id = ? AND user_id = ?", postId, userId).
+			First(&rel).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if status == 0 {
+				return nil
+			}
+			err = tx.Create(&PostLikeRelation{
+				PostId: postId,
+				UserId: userId,
+				Status: status,
+				Ctime:  now,
+				Utime:  now,
+			}).Error
+		case err != nil:
+			return err
+		case rel.Status == status:
+			return nil
+		default:
+			updates := map[string]any{
+				"status": status,
+				"utime":  now,
+			}
+			if status == 1 {
+				updates["ctime"] = now
+			}
+			err = tx.Model(&PostLikeRelation{}).Where("id = ?", rel.Id).Updates(updates).Error
+		}
+		if err != nil {
+			return err
+		}
+		changed = true
+		return insertOutbox(tx, event)
+	})
+	return changed, err
 }
 
-func (dao *PostLikeDAO) UpdateStatus(ctx context.Context, id int64, status uint8) error {
-	return dao.db.WithContext(ctx).Model(&PostLikeRelation{}).Where("id = ?", id).Updates(map[string]any{
-		"status": status,
-		"utime":  time.Now().UnixMilli(),
-	}).Error
+// CountActiveByPostRange counts status=1 relations per post for post_id in [from, to).
+func (dao *PostLikeDAO) CountActiveByPostRange(ctx context.Context, from, to int64) ([]PostCount, error) {
+	var counts []PostCount
+	err := dao.db.WithContext(ctx).Model(&PostLikeRelation{}).
+		Select("post_id, COUNT(*) AS cnt").
+		Where("post_id >= ? AND post_id < ? AND status = 1", from, to).
+		Group("post_id").
+		Find(&counts).Error
+	return counts, err
 }
 
 func (dao *PostLikeDAO) FindByPostIds(ctx context.Context, postIds []int64, userId int64) ([]PostLikeRelation, error) {
@@ -114,18 +206,62 @@ func (dao *PostCollectDAO) FindByPostIdUserId(ctx context.Context, postId, userI
 	return rel, err
 }
 
-func (dao *PostCollectDAO) Insert(ctx context.Context, rel *PostCollectRelation) error {
-	now := time.Now().UnixMilli()
-	rel.Ctime = now
-	rel.Utime = now
-	return dao.db.WithContext(ctx).Create(rel).Error
+// SetStatus changes the relation status and, if it actually changed, writes the
+// outbox event in the same transaction. It reports whether the status changed.
+func (dao *PostCollectDAO) SetStatus(ctx context.Context, postId, userId int64, status uint8, event PostStatsOutbox) (bool, error) {
+	changed := false
+	err := dao.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now().UnixMilli()
+		var rel PostCollectRelation
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("post_id = ? AND user_id = ?", postId, userId).
+			First(&rel).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			if status == 0 {
+				return nil
+			}
+			err = tx.Create(&PostCollectRelation{
+				PostId: postId,
+				UserId: userId,
+				Status: status,
+				Ctime:  now,
+				Utime:  now,
+			}).Error
+		case err != nil:
+			return err
+		case rel.Status == status:
+			return nil
+		default:
+			// A collect always starts in the default folder, uncollecting forgets the folder.
+			updates := map[string]any{
+				"status":    status,
+				"folder_id": 0,
+				"utime":     now,
+			}
+			if status == 1 {
+				updates["ctime"] = now
+			}
+			err = tx.Model(&PostCollectRelation{}).Where("id = ?", rel.Id).Updates(updates).Error
+		}
+		if err != nil {
+			return err
+		}
+		changed = true
+		return insertOutbox(tx, event)
+	})
+	return changed, err
 }
 
-func (dao *PostCollectDAO) UpdateStatus(ctx context.Context, id int64, status uint8) error {
-	return dao.db.WithContext(ctx).Model(&PostCollectRelation{}).Where("id = ?", id).Updates(map[string]any{
-		"status": status,
-		"utime":  time.Now().UnixMilli(),
-	}).Error
+// CountActiveByPostRange counts status=1 relations per post for post_id in [from, to).
+func (dao *PostCollectDAO) CountActiveByPostRange(ctx context.Context, from, to int64) ([]PostCount, error) {
+	var counts []PostCount
+	err := dao.db.WithContext(ctx).Model(&PostCollectRelation{}).
+		Select("post_id, COUNT(*) AS cnt").
+		Where("post_id >= ? AND post_id < ? AND status = 1", from, to).
+		Group("post_id").
+		Find(&counts).Error
+	return counts, err
 }
 
 func (dao *PostCollectDAO) FindByPostIds(ctx context.Context, postIds []int64, userId int64) ([]PostCollectRelation, error) {
@@ -133,3 +269,43 @@ func (dao *PostCollectDAO) FindByPostIds(ctx context.Context, postIds []int64, u
 	err := dao.db.WithContext(ctx).Where("user_id = ? AND post_id IN ?", userId, postIds).Find(&rels).Error
 	return rels, err
 }
+
+// MoveToFolder moves a collected post into another folder of the same user.
+func (dao *PostCollectDAO) MoveToFolder(ctx context.Context, postId, userId, folderId int64) error {
+	res := dao.db.WithContext(ctx).Model(&PostCollectRelation{}).
+		Where("post_id = ? AND user_id = ? AND status = 1", postId, userId).
+		Updates(map[string]any{
+			"folder_id": folderId,
+			"utime":     time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CountByFolder counts collected posts per folder of a user.
+func (dao *PostCollectDAO) CountByFolder(ctx context.Context, userId int64) ([]FolderCount, error) {
+	var counts []FolderCount
+	err := dao.db.WithContext(ctx).Model(&PostCollectRelation{}).
+		Select("folder_id, COUNT(*) AS cnt").
+		Where("user_id = ? AND status = 1", userId).
+		Group("folder_id").
+		Find(&counts).Error
+	return counts, err
+}
+
+// FindByFolder returns the collected relations of a folder, most recently collected or moved first.
+func (dao *PostCollectDAO) FindByFolder(ctx context.Context, userId, folderId int64, offset, limit int) ([]PostCollectRelation, error) {
+	var rels []PostCollectRelation
+	err := dao.db.WithContext(ctx).
+		Where("user_id = ? AND folder_id = ? AND status = 1", userId, folderId).
+		Order("utime DESC, id DESC").
+		Offset(offset).
+		Limit(limit).
+		Find(&rels).Error
+	return rels, err
+}
@@ -0,0 +1,114 @@
+package mysql
+
+import (
+	"context"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+)
+
+const (
+	outboxStatusPending uint8 = 0
+	outboxStatusSent    uint8 = 1
+	outboxStatusFailed  uint8 = 2
+)
+
+// PostStatsOutbox stores stats events that still have to be shipped to MQ.
+// Rows are inserted inside the business transaction and relayed asynchronously.
+type PostStatsOutbox struct {
+	Id          int64  `gorm:"primaryKey,autoIncrement"`
+	EventId     string `gorm:"size:64;uniqueIndex"`
+	Type        string `gorm:"size:16"`
+	PostId      int64
+	UserId      int64
+	Ts          int64
+	Status      uint8 `gorm:"index:idx_outbox_status_next"`
+	NextRetryAt int64 `gorm:"index:idx_outbox_status_next"`
+	Retries     int
+	LastError   string `gorm:"size:512"`
+	Ctime       int64
+	Utime       int64
+}
+
+type PostStatsOutboxDAO struct {
+	db *gorm.DB
+}
+
+func NewPostStatsOutboxDAO(db *gorm.DB) *PostStatsOutboxDAO {
+	return &PostStatsOutboxDAO{db: db}
+}
+
+// FindPending returns pending rows that are due for (re)delivery, oldest first.
+func (dao *PostStatsOutboxDAO) FindPending(ctx context.Context, now int64, limit int) ([]PostStatsOutbox, error) {
+	var rows []PostStatsOutbox
+	err := dao.db.WithContext(ctx).
+		Where("status = ? AND next_retry_at <= ?", outboxStatusPending, now).
+		Order("id ASC").
+		Limit(limit).
+		Find(&rows).Error
+	return rows, err
+}
+
+func (dao *PostStatsOutboxDAO) MarkSent(ctx context.Context, ids []int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return dao.db.WithContext(ctx).Model(&PostStatsOutbox{}).Where("id IN ?", ids).Updates(map[string]any{
+		"status": outboxStatusSent,
+		"utime":  time.Now().UnixMilli(),
+	}).Error
+}
+
+func (dao *PostStatsOutboxDAO) MarkRetry(ctx context.Context, id int64, nextRetryAt int64, reason string) error {
+	return dao.db.WithContext(ctx).Model(&PostStatsOutbox{}).Where("id = ?", id).Updates(map[string]any{
+		"retries":       gorm.Expr("retries + 1"),
+		"next_retry_at": nextRetryAt,
+		"last_error":    truncate(reason, 512),
+		"utime":         time.Now().UnixMilli(),
+	}).Error
+}
+
+func (dao *PostStatsOutboxDAO) MarkFailed(ctx context.Context, id int64, reason string) error {
+	return dao.db.WithContext(ctx).Model(&PostStatsOutbox{}).Where("id = ?", id).Updates(map[string]any{
+		"status":     outboxStatusFailed,
+		"retries":    gorm.Expr("retries + 1"),
+		"last_error": truncate(reason, 512),
+		"utime":      time.Now().UnixMilli(),
+	}).Error
+}
+
+// DeleteSentBefore purges delivered rows older than before, at most limit rows per call.
+func (dao *PostStatsOutboxDAO) DeleteSentBefore(ctx context.Context, before int64, limit int) (int64, error) {
+	res := dao.db.WithContext(ctx).
+		Where("status = ? AND utime < ?", outboxStatusSent, before).
+		Limit(limit).
+		Delete(&PostStatsOutbox{})
+	return res.RowsAffected, res.Error
+}
+
+// insertOutbox writes outbox rows inside the caller's transaction.
+func insertOutbox(tx *gorm.DB, rows ...PostStatsOutbox) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	for i := range rows {
+		rows[i].Status = outboxStatusPending
+		rows[i].NextRetryAt = now
+		rows[i].Ctime = now
+		rows[i].Utime = now
+	}
+	return tx.Create(&rows).Error
+}
+
+// truncate cuts s to at most n bytes without splitting a UTF-8 sequence.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
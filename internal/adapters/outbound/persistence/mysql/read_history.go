@@ -0,0 +1,79 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReadHistory 阅读记录实体，Utime 即最近一次阅读时间
+type ReadHistory struct {
+	Id       int64 `gorm:"primarykey,autoIncrement"`
+	UserId   int64 `gorm:"uniqueIndex:idx_history_user_post;index:idx_history_user_utime,priority:1"`
+	PostId   int64 `gorm:"uniqueIndex:idx_history_user_post"`
+	Progress uint8
+	Ctime    int64
+	Utime    int64 `gorm:"index:idx_history_user_utime,priority:2"`
+}
+
+// ReadHistoryDAO 阅读记录数据访问对象
+type ReadHistoryDAO struct {
+	db *gorm.DB
+}
+
+// NewReadHistoryDAO 创建 ReadHistoryDAO 实例
+func NewReadHistoryDAO(db *gorm.DB) *ReadHistoryDAO {
+	return &ReadHistoryDAO{db: db}
+}
+
+// Upsert 记录一次阅读，updateProgress 为 false 时保留原有进度
+func (d *ReadHistoryDAO) Upsert(ctx context.Context, h ReadHistory, updateProgress bool) error {
+	now := time.Now().UnixMilli()
+	h.Ctime = now
+	h.Utime = now
+	columns := []string{"utime"}
+	if updateProgress {
+		columns = append(columns, "progress")
+	}
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "post_id"}},
+		DoUpdates: clause.AssignmentColumns(columns),
+	}).Create(&h).Error
+}
+
+// FindByUser 按 (阅读时间, ID) 倒序获取阅读记录，cursorUtime/cursorId 为上一页最后一条的阅读时间与 ID，
+// cursorUtime 为 0 表示第一页。只返回仍处于发布状态的帖子；unfinished 为 true 时只返回未读完的记录
+func (d *ReadHistoryDAO) FindByUser(ctx context.Context, userId, cursorUtime, cursorId int64, limit int, unfinished bool) ([]ReadHistory, error) {
+	query := d.db.WithContext(ctx).
+		Joins("JOIN published_posts ON published_posts.id = read_histories.post_id").
+		Where("read_histories.user_id = ?", userId)
+	if cursorUtime > 0 {
+		query = query.Where("read_histories.utime < ? OR (read_histories.utime = ? AND read_histories.id < ?)",
+			cursorUtime, cursorUtime, cursorId)
+	}
+	if unfinished {
+		query = query.Where("read_histories.progress < ?", 100)
+	}
+	var histories []ReadHistory
+	err := query.Order("read_histories.utime DESC, read_histories.id DESC").Limit(limit).Find(&histories).Error
+	return histories, err
+}
+
+// Delete 删除一条阅读记录
+func (d *ReadHistoryDAO) Delete(ctx context.Context, userId, postId int64) error {
+	res := d.db.WithContext(ctx).Delete(&ReadHistory{}, "user_id = ? AND post_id = ?", userId, postId)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteByUser 清空用户的阅读记录
+func (d *ReadHistoryDAO) DeleteByUser(ctx context.Context, userId int64) error {
+	return d.db.WithContext(ctx).Delete(&ReadHistory{}, "user_id = ?", userId).Error
+}
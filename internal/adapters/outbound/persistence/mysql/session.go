@@ -0,0 +1,68 @@
+package mysql
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserSession 登录会话实体，Ssid 为会话族ID，Utime 为最近一次签发 token 的时间
+type UserSession struct {
+	Id        int64  `gorm:"primarykey,autoIncrement"`
+	Ssid      string `gorm:"size:64;uniqueIndex"`
+	UserId    int64  `gorm:"index:idx_session_user_utime,priority:1"`
+	UserAgent string `gorm:"size:255"`
+	IP        string `gorm:"size:64"`
+	Ctime     int64
+	Utime     int64 `gorm:"index:idx_session_user_utime,priority:2"`
+}
+
+// SessionDAO 登录会话数据访问对象
+type SessionDAO struct {
+	db *gorm.DB
+}
+
+// NewSessionDAO 创建 SessionDAO 实例
+func NewSessionDAO(db *gorm.DB) *SessionDAO {
+	return &SessionDAO{db: db}
+}
+
+// Upsert 新建会话，已存在时更新 User-Agent、IP 和最近使用时间
+func (d *SessionDAO) Upsert(ctx context.Context, s UserSession) error {
+	now := time.Now().UnixMilli()
+	s.Ctime = now
+	s.Utime = now
+	return d.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "ssid"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_agent", "ip", "utime"}),
+	}).Create(&s).Error
+}
+
+// FindByUser 按最近使用时间倒序获取 since 之后使用过的会话
+func (d *SessionDAO) FindByUser(ctx context.Context, userId, since int64) ([]UserSession, error) {
+	var sessions []UserSession
+	err := d.db.WithContext(ctx).
+		Where("user_id = ? AND utime >= ?", userId, since).
+		Order("utime DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// Delete 删除用户的一个会话，不存在时返回 gorm.ErrRecordNotFound
+func (d *SessionDAO) Delete(ctx context.Context, userId int64, ssid string) error {
+	res := d.db.WithContext(ctx).Delete(&UserSession{}, "user_id = ? AND ssid = ?", userId, ssid)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// DeleteByUser 删除用户的全部会话
+func (d *SessionDAO) DeleteByUser(ctx context.Context, userId int64) error {
+	return d.db.WithContext(ctx).Delete(&UserSession{}, "user_id = ?", userId).Error
+}
@@ -0,0 +1,210 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"gorm.io/gorm"
+)
+
+var ErrDuplicateTag = errors.New("标签已存在")
+
+// Tag 标签实体
+type Tag struct {
+	Id    int64  `gorm:"primarykey,autoIncrement"`
+	Name  string `gorm:"size:32;uniqueIndex"`
+	Ctime int64
+	Utime int64
+}
+
+// PostTag 制作库帖子与标签的关联
+type PostTag struct {
+	Id     int64 `gorm:"primarykey,autoIncrement"`
+	PostId int64 `gorm:"uniqueIndex:idx_post_tag"`
+	TagId  int64 `gorm:"uniqueIndex:idx_post_tag;index"`
+	Ctime  int64
+}
+
+// PublishedPostTag 线上库帖子与标签的关联，发布时由 PostDAO.Sync 从制作库同步
+type PublishedPostTag struct {
+	Id     int64 `gorm:"primarykey,autoIncrement"`
+	PostId int64 `gorm:"uniqueIndex:idx_published_post_tag"`
+	TagId  int64 `gorm:"uniqueIndex:idx_published_post_tag;index"`
+	Ctime  int64
+}
+
+// TagWithCount 标签及其已发布帖子数
+type TagWithCount struct {
+	Tag
+	PostCnt int64
+}
+
+// PostTagName 帖子ID与标签名的对应关系
+type PostTagName struct {
+	PostId int64
+	Name   string
+}
+
+// TagDAO 标签数据访问对象
+type TagDAO struct {
+	db *gorm.DB
+}
+
+// NewTagDAO 创建 TagDAO 实例
+func NewTagDAO(db *gorm.DB) *TagDAO {
+	return &TagDAO{db: db}
+}
+
+// Insert 创建标签
+func (d *TagDAO) Insert(ctx context.Context, t Tag) (int64, error) {
+	now := time.Now().UnixMilli()
+	t.Ctime = now
+	t.Utime = now
+	err := d.db.WithContext(ctx).Create(&t).Error
+	if isDuplicateKey(err) {
+		return 0, ErrDuplicateTag
+	}
+	return t.Id, err
+}
+
+// UpdateName 修改标签名
+func (d *TagDAO) UpdateName(ctx context.Context, id int64, name string) error {
+	res := d.db.WithContext(ctx).Model(&Tag{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"name":  name,
+			"utime": time.Now().UnixMilli(),
+		})
+	if isDuplicateKey(res.Error) {
+		return ErrDuplicateTag
+	}
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Delete 删除标签及其在制作库、线上库中的关联
+func (d *TagDAO) Delete(ctx context.Context, id int64) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Delete(&Tag{}, "id = ?", id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := tx.Delete(&PostTag{}, "tag_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&PublishedPostTag{}, "tag_id = ?", id).Error
+	})
+}
+
+// FindById 根据ID查找标签
+func (d *TagDAO) FindById(ctx context.Context, id int64) (Tag, error) {
+	var t Tag
+	err := d.db.WithContext(ctx).Where("id = ?", id).First(&t).Error
+	return t, err
+}
+
+// FindByName 根据标签名查找标签
+func (d *TagDAO) FindByName(ctx context.Context, name string) (Tag, error) {
+	var t Tag
+	err := d.db.WithContext(ctx).Where("name = ?", name).First(&t).Error
+	return t, err
+}
+
+// FindByNames 批量根据标签名查找标签
+func (d *TagDAO) FindByNames(ctx context.Context, names []string) ([]Tag, error) {
+	var tags []Tag
+	if len(names) == 0 {
+		return tags, nil
+	}
+	err := d.db.WithContext(ctx).Where("name IN ?", names).Find(&tags).Error
+	return tags, err
+}
+
+// ListWithPostCount 列出所有标签及其已发布帖子数
+func (d *TagDAO) ListWithPostCount(ctx context.Context) ([]TagWithCount, error) {
+	var tags []TagWithCount
+	err := d.db.WithContext(ctx).
+		Table("tags").
+		Select("tags.*, COUNT(published_post_tags.post_id) AS post_cnt").
+		Joins("LEFT JOIN published_post_tags ON published_post_tags.tag_id = tags.id").
+		Group("tags.id").
+		Order("post_cnt DESC, tags.id ASC").
+		Scan(&tags).Error
+	return tags, err
+}
+
+// FindNamesByPostIds 批量获取制作库帖子的标签名
+func (d *TagDAO) FindNamesByPostIds(ctx context.Context, postIds []int64) ([]PostTagName, error) {
+	return findTagNames(d.db.WithContext(ctx), "post_tags", postIds)
+}
+
+// FindPublishedNamesByPostIds 批量获取线上库帖子的标签名
+func (d *TagDAO) FindPublishedNamesByPostIds(ctx context.Context, postIds []int64) ([]PostTagName, error) {
+	return findTagNames(d.db.WithContext(ctx), "published_post_tags", postIds)
+}
+
+func findTagNames(db *gorm.DB, relTable string, postIds []int64) ([]PostTagName, error) {
+	var result []PostTagName
+	if len(postIds) == 0 {
+		return result, nil
+	}
+	err := db.Table(relTable).
+		Select(relTable+".post_id, tags.name").
+		Joins("JOIN tags ON tags.id = "+relTable+".tag_id").
+		Where(relTable+".post_id IN ?", postIds).
+		Order("tags.name ASC").
+		Scan(&result).Error
+	return result, err
+}
+
+// replacePostTags 在调用方事务内重置制作库帖子的标签
+func replacePostTags(tx *gorm.DB, postId int64, tagIds []int64) error {
+	if err := tx.Delete(&PostTag{}, "post_id = ?", postId).Error; err != nil {
+		return err
+	}
+	if len(tagIds) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	rels := make([]PostTag, len(tagIds))
+	for i, tagId := range tagIds {
+		rels[i] = PostTag{PostId: postId, TagId: tagId, Ctime: now}
+	}
+	return tx.Create(&rels).Error
+}
+
+// syncPublishedTags 在调用方事务内把制作库帖子的标签复制到线上库
+func syncPublishedTags(tx *gorm.DB, postId int64) error {
+	if err := tx.Delete(&PublishedPostTag{}, "post_id = ?", postId).Error; err != nil {
+		return err
+	}
+	var rels []PostTag
+	if err := tx.Where("post_id = ?", postId).Find(&rels).Error; err != nil {
+		return err
+	}
+	if len(rels) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	pubRels := make([]PublishedPostTag, len(rels))
+	for i, rel := range rels {
+		pubRels[i] = PublishedPostTag{PostId: postId, TagId: rel.TagId, Ctime: now}
+	}
+	return tx.Create(&pubRels).Error
+}
+
+// isDuplicateKey 判断是否为 MySQL 唯一约束冲突
+func isDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1062
+}
@@ -12,14 +12,20 @@ import (
 var ErrDuplicateEmail = errors.New("邮箱已被注册")
 
 type User struct {
-	Id       int64  `gorm:"primarykey, autoIncrement"`
-	Email    string `gorm:"unique"`
-	Password string
-	Ctime    int64
-	Utime    int64
+	Id            int64  `gorm:"primarykey, autoIncrement"`
+	Email         string `gorm:"unique"`
+	Password      string
+	EmailVerified bool
+	Nickname      string `gorm:"size:64"`
+	Avatar        string `gorm:"size:512"`
+	Bio           string `gorm:"size:1024"`
+	Birthday      string `gorm:"size:10"` // 2006-01-02
+	Role          string `gorm:"size:16;default:user"`
+	Status        uint8  `gorm:"default:0"` // 0 正常 1 停用 2 封禁
+	Ctime         int64
+	Utime         int64
 }
 
-
 type UserDAO struct {
 	db *gorm.DB
 }
@@ -67,3 +73,59 @@ func (dao *UserDAO) Update(ctx context.Context, u User) error {
 	}).Error
 }
 
+func (dao *UserDAO) MarkEmailVerified(ctx context.Context, id int64) error {
+	return dao.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Updates(map[string]any{
+		"email_verified": true,
+		"utime":          time.Now().UnixMilli(),
+	}).Error
+}
+
+// UpdateProfile 只更新资料字段，不影响密码
+func (dao *UserDAO) UpdateProfile(ctx context.Context, u User) error {
+	return dao.db.WithContext(ctx).Model(&User{}).Where("id = ?", u.Id).Updates(map[string]any{
+		"nickname": u.Nickname,
+		"avatar":   u.Avatar,
+		"bio":      u.Bio,
+		"birthday": u.Birthday,
+		"utime":    time.Now().UnixMilli(),
+	}).Error
+}
+
+// List 按注册时间倒序分页获取用户
+func (dao *UserDAO) List(ctx context.Context, offset, limit int) ([]User, error) {
+	var users []User
+	err := dao.db.WithContext(ctx).Order("id DESC").Offset(offset).Limit(limit).Find(&users).Error
+	return users, err
+}
+
+// Count 用户总数
+func (dao *UserDAO) Count(ctx context.Context) (int64, error) {
+	var count int64
+	err := dao.db.WithContext(ctx).Model(&User{}).Count(&count).Error
+	return count, err
+}
+
+// UpdateStatus 修改账号状态，用户不存在时返回 gorm.ErrRecordNotFound
+func (dao *UserDAO) UpdateStatus(ctx context.Context, id int64, status uint8) error {
+	return dao.updateColumn(ctx, id, "status", status)
+}
+
+// UpdateRole 修改角色，用户不存在时返回 gorm.ErrRecordNotFound
+func (dao *UserDAO) UpdateRole(ctx context.Context, id int64, role string) error {
+	return dao.updateColumn(ctx, id, "role", role)
+}
+
+func (dao *UserDAO) updateColumn(ctx context.Context, id int64, column string, value any) error {
+	// 值未变化时 MySQL 的 RowsAffected 为 0，同时更新 utime 保证存在的用户总能命中
+	res := dao.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Updates(map[string]any{
+		column:  value,
+		"utime": time.Now().UnixMilli(),
+	})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
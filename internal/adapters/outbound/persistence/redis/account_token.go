@@ -0,0 +1,50 @@
+package redis
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisAccountTokenStore keys tokens by their SHA-256, so the tokens in the
+// emails cannot be read back from Redis.
+type RedisAccountTokenStore struct {
+	client redis.Cmdable
+}
+
+func NewAccountTokenStore(client redis.Cmdable) ports.AccountTokenStore {
+	return &RedisAccountTokenStore{client: client}
+}
+
+func (s *RedisAccountTokenStore) key(purpose domain.AccountTokenPurpose, token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return fmt.Sprintf("account:token:%s:%s", purpose, hex.EncodeToString(hash[:]))
+}
+
+func (s *RedisAccountTokenStore) Save(ctx context.Context, purpose domain.AccountTokenPurpose, token string, userId int64, ttl time.Duration) error {
+	return s.client.Set(ctx, s.key(purpose, token), userId, ttl).Err()
+}
+
+func (s *RedisAccountTokenStore) Consume(ctx context.Context, purpose domain.AccountTokenPurpose, token string) (int64, error) {
+	// GETDEL makes the read and the delete atomic, so concurrent uses cannot both succeed.
+	val, err := s.client.GetDel(ctx, s.key(purpose, token)).Result()
+	if err == redis.Nil {
+		return 0, domain.ErrInvalidAccountToken
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(val, 10, 64)
+}
+
+func (s *RedisAccountTokenStore) Throttle(ctx context.Context, purpose domain.AccountTokenPurpose, userId int64, interval time.Duration) (bool, error) {
+	key := fmt.Sprintf("account:mail:throttle:%s:%d", purpose, userId)
+	return s.client.SetNX(ctx, key, 1, interval).Result()
+}
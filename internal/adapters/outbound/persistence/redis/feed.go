@@ -0,0 +1,126 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// FeedInboxSize is how many items each inbox keeps; older ones are trimmed on push.
+type FeedInboxSize int
+
+// RedisFeedCache keeps one sorted set per user, scored by publish time.
+type RedisFeedCache struct {
+	client redis.Cmdable
+	size   int64
+}
+
+func NewFeedCache(client redis.Cmdable, size FeedInboxSize) ports.FeedCache {
+	return &RedisFeedCache{client: client, size: int64(size)}
+}
+
+func (c *RedisFeedCache) key(userId int64) string {
+	return fmt.Sprintf("feed:inbox:%d", userId)
+}
+
+func (c *RedisFeedCache) Push(ctx context.Context, userIds []int64, items ...domain.FeedItem) error {
+	if len(userIds) == 0 || len(items) == 0 {
+		return nil
+	}
+	members := make([]redis.Z, len(items))
+	for i, item := range items {
+		members[i] = redis.Z{Score: float64(item.Ts), Member: strconv.FormatInt(item.PostId, 10)}
+	}
+	pipe := c.client.Pipeline()
+	for _, uid := range userIds {
+		key := c.key(uid)
+		pipe.ZAdd(ctx, key, members...)
+		pipe.ZRemRangeByRank(ctx, key, 0, -c.size-1)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Inbox pages by (score, post id). Members with the same score are ordered by
+// their string form rather than numerically, so the ties at the page edges are
+// read in full and sorted here.
+func (c *RedisFeedCache) Inbox(ctx context.Context, userId int64, cursor domain.FeedCursor, limit int) ([]domain.FeedItem, error) {
+	key := c.key(userId)
+	var items []domain.FeedItem
+	maxScore := "+inf"
+	if cursor.Ts > 0 {
+		// Posts published in the same millisecond as the cursor with a smaller id come first.
+		tied, err := c.rangeByScore(ctx, key, cursor.Ts, cursor.Ts)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range tied {
+			if item.PostId < cursor.PostId {
+				items = append(items, item)
+			}
+		}
+		maxScore = "(" + strconv.FormatInt(cursor.Ts, 10)
+	}
+
+	members, err := c.client.ZRevRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+		Max:   maxScore,
+		Min:   "-inf",
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	older := toFeedItems(members)
+	if len(older) == limit && limit > 0 {
+		// The lowest score may have been cut off in the middle of its ties.
+		last := older[len(older)-1].Ts
+		tied, err := c.rangeByScore(ctx, key, last, last)
+		if err != nil {
+			return nil, err
+		}
+		older = slices.DeleteFunc(older, func(item domain.FeedItem) bool { return item.Ts == last })
+		older = append(older, tied...)
+	}
+	items = append(items, older...)
+
+	slices.SortFunc(items, func(a, b domain.FeedItem) int {
+		return b.Cursor().Compare(a.Cursor())
+	})
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+// rangeByScore returns every member whose score is between from and to.
+func (c *RedisFeedCache) rangeByScore(ctx context.Context, key string, from, to int64) ([]domain.FeedItem, error) {
+	members, err := c.client.ZRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+		Min: strconv.FormatInt(from, 10),
+		Max: strconv.FormatInt(to, 10),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	return toFeedItems(members), nil
+}
+
+func toFeedItems(members []redis.Z) []domain.FeedItem {
+	result := make([]domain.FeedItem, 0, len(members))
+	for _, z := range members {
+		s, ok := z.Member.(string)
+		if !ok {
+			continue
+		}
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			continue
+		}
+		result = append(result, domain.FeedItem{PostId: id, Ts: int64(z.Score)})
+	}
+	return result
+}
@@ -0,0 +1,56 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisLoginAttemptCache keeps a failure counter and a lock key per scope and value.
+type RedisLoginAttemptCache struct {
+	client redis.Cmdable
+}
+
+func NewLoginAttemptCache(client redis.Cmdable) ports.LoginAttemptCache {
+	return &RedisLoginAttemptCache{client: client}
+}
+
+func (c *RedisLoginAttemptCache) failKey(scope domain.LoginScope, value string) string {
+	return fmt.Sprintf("login:fail:%s:%s", scope, value)
+}
+
+func (c *RedisLoginAttemptCache) lockKey(scope domain.LoginScope, value string) string {
+	return fmt.Sprintf("login:lock:%s:%s", scope, value)
+}
+
+func (c *RedisLoginAttemptCache) LockedFor(ctx context.Context, scope domain.LoginScope, value string) (time.Duration, error) {
+	ttl, err := c.client.PTTL(ctx, c.lockKey(scope, value)).Result()
+	if err != nil {
+		return 0, err
+	}
+	// -2 means no key; a lock is always set with a TTL, so -1 does not occur.
+	return max(ttl, 0), nil
+}
+
+func (c *RedisLoginAttemptCache) IncrFailure(ctx context.Context, scope domain.LoginScope, value string, window time.Duration) (int64, error) {
+	key := c.failKey(scope, value)
+	pipe := c.client.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+func (c *RedisLoginAttemptCache) Lock(ctx context.Context, scope domain.LoginScope, value string, d time.Duration) error {
+	return c.client.Set(ctx, c.lockKey(scope, value), 1, d).Err()
+}
+
+func (c *RedisLoginAttemptCache) Reset(ctx context.Context, scope domain.LoginScope, value string) error {
+	return c.client.Del(ctx, c.failKey(scope, value), c.lockKey(scope, value)).Err()
+}
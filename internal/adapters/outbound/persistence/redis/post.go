@@ -49,3 +49,7 @@ func (c *RedisPostCache) Set(ctx context.Context, p domain.Post) error {
 func (c *RedisPostCache) Delete(ctx context.Context, id int64) error {
 	return c.client.Del(ctx, c.key(id)).Err()
 }
+
+func (c *RedisPostCache) TryLock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return c.client.SetNX(ctx, key, 1, ttl).Result()
+}
@@ -0,0 +1,148 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	hotTotalKey = "post:hot:total"
+	// Buckets only feed the daily/weekly windows, a week plus a day of margin is enough.
+	hotBucketTTL = 8 * 24 * time.Hour
+	// Members per ZADD when replacing the all-time set.
+	hotReplaceChunk = 500
+)
+
+// RedisPostRankCache keeps the hot ranking in sorted sets.
+type RedisPostRankCache struct {
+	client redis.Cmdable
+}
+
+func NewPostRankCache(client redis.Cmdable) ports.PostRankCache {
+	return &RedisPostRankCache{client: client}
+}
+
+func (c *RedisPostRankCache) bucketKey(day time.Time) string {
+	return "post:hot:day:" + day.Format("20060102")
+}
+
+func (c *RedisPostRankCache) windowKey(window domain.HotWindow) string {
+	return fmt.Sprintf("post:hot:%s", window)
+}
+
+func (c *RedisPostRankCache) IncrScore(ctx context.Context, postId int64, delta float64, at time.Time) error {
+	member := strconv.FormatInt(postId, 10)
+	bucket := c.bucketKey(at)
+	pipe := c.client.Pipeline()
+	pipe.ZIncrBy(ctx, hotTotalKey, delta, member)
+	pipe.ZIncrBy(ctx, bucket, delta, member)
+	pipe.Expire(ctx, bucket, hotBucketTTL)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *RedisPostRankCache) ReplaceTotal(ctx context.Context, scores []domain.PostScore) error {
+	if len(scores) == 0 {
+		return c.client.Del(ctx, hotTotalKey).Err()
+	}
+	tmp := hotTotalKey + ":tmp"
+	if err := c.client.Del(ctx, tmp).Err(); err != nil {
+		return err
+	}
+	for start := 0; start < len(scores); start += hotReplaceChunk {
+		chunk := scores[start:min(start+hotReplaceChunk, len(scores))]
+		members := make([]redis.Z, len(chunk))
+		for i, s := range chunk {
+			members[i] = redis.Z{Score: s.Score, Member: strconv.FormatInt(s.PostId, 10)}
+		}
+		if err := c.client.ZAdd(ctx, tmp, members...).Err(); err != nil {
+			return err
+		}
+	}
+	return c.client.Rename(ctx, tmp, hotTotalKey).Err()
+}
+
+func (c *RedisPostRankCache) RefreshWindow(ctx context.Context, window domain.HotWindow, now time.Time, dayWeights []float64, topN int) error {
+	store := redis.ZStore{Keys: []string{hotTotalKey}}
+	if len(dayWeights) > 0 {
+		store = redis.ZStore{
+			Keys:    make([]string, len(dayWeights)),
+			Weights: dayWeights,
+		}
+		for i := range dayWeights {
+			store.Keys[i] = c.bucketKey(now.AddDate(0, 0, -i))
+		}
+	}
+
+	key := c.windowKey(window)
+	tmp := key + ":tmp"
+	if err := c.client.ZUnionStore(ctx, tmp, &store).Err(); err != nil {
+		return err
+	}
+	return publishWindowScript.Run(ctx, c.client, []string{tmp, key}, topN).Err()
+}
+
+// publishWindowScript trims the freshly merged set to the top N positive scores and
+// swaps it in. An empty result removes the window instead of leaving a stale one.
+var publishWindowScript = redis.NewScript(`
+redis.call("ZREMRANGEBYSCORE", KEYS[1], "-inf", "0")
+redis.call("ZREMRANGEBYRANK", KEYS[1], 0, -tonumber(ARGV[1]) - 1)
+if redis.call("EXISTS", KEYS[1]) == 1 then
+	redis.call("RENAME", KEYS[1], KEYS[2])
+	return 1
+end
+redis.call("DEL", KEYS[2])
+return 0
+`)
+
+func (c *RedisPostRankCache) Remove(ctx context.Context, postIds ...int64) error {
+	if len(postIds) == 0 {
+		return nil
+	}
+	members := make([]any, len(postIds))
+	for i, id := range postIds {
+		members[i] = strconv.FormatInt(id, 10)
+	}
+	pipe := c.client.Pipeline()
+	pipe.ZRem(ctx, hotTotalKey, members...)
+	for _, w := range domain.HotWindows {
+		pipe.ZRem(ctx, c.windowKey(w), members...)
+	}
+	now := time.Now()
+	for i := 0; i < int(hotBucketTTL/(24*time.Hour)); i++ {
+		pipe.ZRem(ctx, c.bucketKey(now.AddDate(0, 0, -i)), members...)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (c *RedisPostRankCache) Top(ctx context.Context, window domain.HotWindow, offset, limit int) ([]domain.PostScore, int64, error) {
+	key := c.windowKey(window)
+	pipe := c.client.Pipeline()
+	rangeCmd := pipe.ZRevRangeWithScores(ctx, key, int64(offset), int64(offset+limit-1))
+	cardCmd := pipe.ZCard(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, 0, err
+	}
+
+	members := rangeCmd.Val()
+	result := make([]domain.PostScore, 0, len(members))
+	for _, z := range members {
+		s, ok := z.Member.(string)
+		if !ok {
+			continue
+		}
+		id, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			continue
+		}
+		result = append(result, domain.PostScore{PostId: id, Score: z.Score})
+	}
+	return result, cardCmd.Val(), nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 	"webook/internal/domain"
 	ports "webook/internal/ports/output"
@@ -67,6 +68,7 @@ func (c *RedisPostStatsCache) Set(ctx context.Context, stats domain.PostStats) e
 		"like_cnt":    stats.LikeCnt,
 		"collect_cnt": stats.CollectCnt,
 		"read_cnt":    stats.ReadCnt,
+		"comment_cnt": stats.CommentCnt,
 	}).Err()
 }
 
@@ -80,12 +82,27 @@ func (c *RedisPostStatsCache) BatchSet(ctx context.Context, stats []domain.PostS
 			"like_cnt":    st.LikeCnt,
 			"collect_cnt": st.CollectCnt,
 			"read_cnt":    st.ReadCnt,
+			"comment_cnt": st.CommentCnt,
 		})
 	}
 	_, err := pipe.Exec(ctx)
 	return err
 }
 
+// setCountsIfPresentScript only touches hashes that already exist, so a repair never
+// creates a partial hash that would hide the other counters stored in MySQL.
+var setCountsIfPresentScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 1 then
+	redis.call("HSET", KEYS[1], "like_cnt", ARGV[1], "collect_cnt", ARGV[2])
+	return 1
+end
+return 0
+`)
+
+func (c *RedisPostStatsCache) SetCountsIfPresent(ctx context.Context, stats domain.PostStats) error {
+	return setCountsIfPresentScript.Run(ctx, c.client, []string{c.key(stats.PostId)}, stats.LikeCnt, stats.CollectCnt).Err()
+}
+
 func (c *RedisPostStatsCache) IncrLike(ctx context.Context, postId int64, delta int64) (int64, error) {
 	return c.client.HIncrBy(ctx, c.key(postId), "like_cnt", delta).Result()
 }
@@ -98,6 +115,10 @@ func (c *RedisPostStatsCache) IncrRead(ctx context.Context, postId int64, delta
 	return c.client.HIncrBy(ctx, c.key(postId), "read_cnt", delta).Result()
 }
 
+func (c *RedisPostStatsCache) IncrComment(ctx context.Context, postId int64, delta int64) (int64, error) {
+	return c.client.HIncrBy(ctx, c.key(postId), "comment_cnt", delta).Result()
+}
+
 func (c *RedisPostStatsCache) MarkDirty(ctx context.Context, postId int64) error {
 	return c.client.SAdd(ctx, "post:stats:dirty", postId).Err()
 }
@@ -124,6 +145,228 @@ func (c *RedisPostStatsCache) PopDirty(ctx context.Context, count int64) ([]int6
 	return result, nil
 }
 
+const dailyDirtyKey = "post:stats:daily:dirty"
+
+func (c *RedisPostStatsCache) dailyKey(member string) string {
+	return "post:stats:daily:" + member
+}
+
+func (c *RedisPostStatsCache) IncrDaily(ctx context.Context, delta domain.PostDailyStats) error {
+	member := fmt.Sprintf("%d:%s", delta.PostId, delta.Date)
+	key := c.dailyKey(member)
+	pipe := c.client.Pipeline()
+	for field, n := range map[string]int64{
+		"read_cnt":      delta.ReadCnt,
+		"like_cnt":      delta.LikeCnt,
+		"unlike_cnt":    delta.UnlikeCnt,
+		"collect_cnt":   delta.CollectCnt,
+		"uncollect_cnt": delta.UncollectCnt,
+	} {
+		if n != 0 {
+			pipe.HIncrBy(ctx, key, field, n)
+		}
+	}
+	// Only a safety net for deltas whose dirty member got lost; the flusher drains them within seconds.
+	pipe.Expire(ctx, key, 7*24*time.Hour)
+	pipe.SAdd(ctx, dailyDirtyKey, member)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// takeHashScript reads and deletes a hash atomically, so increments that land
+// after the read stay buffered for the next flush.
+var takeHashScript = redis.NewScript(`
+local vals = redis.call("HGETALL", KEYS[1])
+redis.call("DEL", KEYS[1])
+return vals
+`)
+
+func (c *RedisPostStatsCache) TakeDaily(ctx context.Context, count int64) ([]domain.PostDailyStats, error) {
+	if count <= 0 {
+		return nil, nil
+	}
+	members, err := c.client.SPopN(ctx, dailyDirtyKey, count).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.Cmd, len(members))
+	for i, member := range members {
+		// EVAL rather than Run: the EVALSHA fallback does not work inside a pipeline.
+		cmds[i] = takeHashScript.Eval(ctx, pipe, []string{c.dailyKey(member)})
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	result := make([]domain.PostDailyStats, 0, len(members))
+	for i, member := range members {
+		postIdStr, date, ok := strings.Cut(member, ":")
+		if !ok {
+			continue
+		}
+		postId, err := strconv.ParseInt(postIdStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		vals, err := cmds[i].StringSlice()
+		if err != nil || len(vals) == 0 {
+			continue
+		}
+		m := make(map[string]string, len(vals)/2)
+		for j := 0; j+1 < len(vals); j += 2 {
+			m[vals[j]] = vals[j+1]
+		}
+		result = append(result, domain.PostDailyStats{
+			PostId:       postId,
+			Date:         date,
+			ReadCnt:      parseInt64(m["read_cnt"]),
+			LikeCnt:      parseInt64(m["like_cnt"]),
+			UnlikeCnt:    parseInt64(m["unlike_cnt"]),
+			CollectCnt:   parseInt64(m["collect_cnt"]),
+			UncollectCnt: parseInt64(m["uncollect_cnt"]),
+		})
+	}
+	return result, nil
+}
+
+const (
+	readersDirtyKey = "post:uv:dirty"
+	// Daily HyperLogLogs only need to outlive the snapshot of their last day.
+	dailyReadersTTL = 3 * 24 * time.Hour
+)
+
+func (c *RedisPostStatsCache) readersKey(postId int64) string {
+	return fmt.Sprintf("post:uv:%d", postId)
+}
+
+func (c *RedisPostStatsCache) dailyReadersKey(postId int64, date string) string {
+	return fmt.Sprintf("post:uv:%d:%s", postId, date)
+}
+
+var addReaderScript = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 0 then
+	return 0
+end
+redis.call("PFADD", KEYS[1], ARGV[1])
+redis.call("PFADD", KEYS[2], ARGV[1])
+redis.call("EXPIRE", KEYS[2], ARGV[2])
+redis.call("SADD", KEYS[3], ARGV[3])
+return 1
+`)
+
+func (c *RedisPostStatsCache) AddReader(ctx context.Context, postId int64, reader string, date string) (bool, error) {
+	keys := []string{c.readersKey(postId), c.dailyReadersKey(postId, date), readersDirtyKey}
+	member := fmt.Sprintf("%d:%s", postId, date)
+	n, err := addReaderScript.Run(ctx, c.client, keys, reader, int64(dailyReadersTTL.Seconds()), member).Int()
+	return n == 1, err
+}
+
+func (c *RedisPostStatsCache) RestoreReaders(ctx context.Context, postId int64, sketch []byte) error {
+	if len(sketch) == 0 {
+		// PFADD without elements creates an empty HyperLogLog and keeps an existing one.
+		return c.client.PFAdd(ctx, c.readersKey(postId)).Err()
+	}
+	return c.client.SetNX(ctx, c.readersKey(postId), sketch, 0).Err()
+}
+
+func (c *RedisPostStatsCache) CountReaders(ctx context.Context, postIds []int64) (map[int64]int64, error) {
+	if len(postIds) == 0 {
+		return map[int64]int64{}, nil
+	}
+	pipe := c.client.Pipeline()
+	cmds := make(map[int64]*redis.IntCmd, len(postIds))
+	for _, id := range postIds {
+		cmds[id] = pipe.PFCount(ctx, c.readersKey(id))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+	result := make(map[int64]int64, len(postIds))
+	for id, cmd := range cmds {
+		if n, err := cmd.Result(); err == nil && n > 0 {
+			result[id] = n
+		}
+	}
+	return result, nil
+}
+
+func (c *RedisPostStatsCache) TakeReaderSnapshots(ctx context.Context, count int64) ([]domain.PostReaderSnapshot, error) {
+	if count <= 0 {
+		return nil, nil
+	}
+	members, err := c.client.SPopN(ctx, readersDirtyKey, count).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	type snapshotCmds struct {
+		sketch   *redis.StringCmd
+		count    *redis.IntCmd
+		dayCount *redis.IntCmd
+	}
+	snaps := make([]domain.PostReaderSnapshot, 0, len(members))
+	cmds := make([]snapshotCmds, 0, len(members))
+	pipe := c.client.Pipeline()
+	for _, member := range members {
+		postIdStr, date, ok := strings.Cut(member, ":")
+		if !ok {
+			continue
+		}
+		postId, err := strconv.ParseInt(postIdStr, 10, 64)
+		if err != nil {
+			continue
+		}
+		snaps = append(snaps, domain.PostReaderSnapshot{PostId: postId, Date: date})
+		cmds = append(cmds, snapshotCmds{
+			sketch:   pipe.Get(ctx, c.readersKey(postId)),
+			count:    pipe.PFCount(ctx, c.readersKey(postId)),
+			dayCount: pipe.PFCount(ctx, c.dailyReadersKey(postId, date)),
+		})
+	}
+	if len(snaps) == 0 {
+		return nil, nil
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	result := make([]domain.PostReaderSnapshot, 0, len(snaps))
+	for i, snap := range snaps {
+		sketch, err := cmds[i].sketch.Bytes()
+		if err != nil {
+			// The HyperLogLog was lost after the read, the saved snapshot stays as is.
+			continue
+		}
+		snap.Sketch = sketch
+		snap.Count = cmds[i].count.Val()
+		snap.DayCount = cmds[i].dayCount.Val()
+		result = append(result, snap)
+	}
+	return result, nil
+}
+
+func (c *RedisPostStatsCache) RequeueReaderSnapshots(ctx context.Context, snaps []domain.PostReaderSnapshot) error {
+	if len(snaps) == 0 {
+		return nil
+	}
+	members := make([]any, len(snaps))
+	for i, snap := range snaps {
+		members[i] = fmt.Sprintf("%d:%s", snap.PostId, snap.Date)
+	}
+	return c.client.SAdd(ctx, readersDirtyKey, members...).Err()
+}
+
 func (c *RedisPostStatsCache) SetReadDedupe(ctx context.Context, key string, ttl time.Duration) (bool, error) {
 	return c.client.SetNX(ctx, key, 1, ttl).Result()
 }
@@ -133,6 +376,11 @@ func (c *RedisPostStatsCache) SetEventProcessed(ctx context.Context, eventId str
 	return c.client.SetNX(ctx, key, 1, ttl).Result()
 }
 
+func (c *RedisPostStatsCache) UnsetEventProcessed(ctx context.Context, eventId string) error {
+	key := fmt.Sprintf("post:stats:event:%s", eventId)
+	return c.client.Del(ctx, key).Err()
+}
+
 func (c *RedisPostStatsCache) TryLock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
 	return c.client.SetNX(ctx, key, 1, ttl).Result()
 }
@@ -143,6 +391,7 @@ func parseStats(postId int64, m map[string]string) domain.PostStats {
 		LikeCnt:    parseInt64(m["like_cnt"]),
 		CollectCnt: parseInt64(m["collect_cnt"]),
 		ReadCnt:    parseInt64(m["read_cnt"]),
+		CommentCnt: parseInt64(m["comment_cnt"]),
 	}
 }
 
@@ -0,0 +1,136 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+	"webook/pkg/logger"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	streamChannel   = "stream:events"
+	streamSeqKey    = "stream:seq"
+	streamReplayCap = 100
+	streamReplayTTL = 10 * time.Minute
+	// streamSubBuffer is how many events a slow subscriber may lag behind before
+	// events are dropped for it.
+	streamSubBuffer = 64
+)
+
+// RedisStreamBroker fans events out over one pub/sub channel. Each instance keeps
+// a single subscription and dispatches to its local subscribers, and user events
+// are also kept in a capped sorted set per user, scored by event id, for replay.
+type RedisStreamBroker struct {
+	client redis.UniversalClient
+	logger logger.Logger
+
+	once sync.Once
+	mu   sync.Mutex
+	subs map[chan domain.StreamEvent]struct{}
+}
+
+func NewStreamBroker(client redis.UniversalClient, l logger.Logger) ports.StreamBroker {
+	return &RedisStreamBroker{
+		client: client,
+		logger: l,
+		subs:   make(map[chan domain.StreamEvent]struct{}),
+	}
+}
+
+func (b *RedisStreamBroker) replayKey(userId int64) string {
+	return fmt.Sprintf("stream:replay:%d", userId)
+}
+
+func (b *RedisStreamBroker) Publish(ctx context.Context, event domain.StreamEvent) error {
+	id, err := b.client.Incr(ctx, streamSeqKey).Result()
+	if err != nil {
+		return err
+	}
+	event.Id = id
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	pipe := b.client.Pipeline()
+	if event.UserId > 0 {
+		key := b.replayKey(event.UserId)
+		pipe.ZAdd(ctx, key, redis.Z{Score: float64(id), Member: payload})
+		pipe.ZRemRangeByRank(ctx, key, 0, -streamReplayCap-1)
+		pipe.Expire(ctx, key, streamReplayTTL)
+	}
+	pipe.Publish(ctx, streamChannel, payload)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (b *RedisStreamBroker) Subscribe(ctx context.Context) (<-chan domain.StreamEvent, error) {
+	b.once.Do(func() {
+		go b.run()
+	})
+	ch := make(chan domain.StreamEvent, streamSubBuffer)
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		delete(b.subs, ch)
+		close(ch)
+		b.mu.Unlock()
+	}()
+	return ch, nil
+}
+
+// run receives from the shared subscription for the lifetime of the process. The
+// client reconnects and resubscribes by itself after a connection loss; events
+// published meanwhile are lost, except user events, which clients replay.
+func (b *RedisStreamBroker) run() {
+	pubsub := b.client.Subscribe(context.Background(), streamChannel)
+	for msg := range pubsub.Channel() {
+		var event domain.StreamEvent
+		if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+			b.logger.Warn("stream broker invalid payload", logger.Error(err))
+			continue
+		}
+		b.dispatch(event)
+	}
+}
+
+func (b *RedisStreamBroker) dispatch(event domain.StreamEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+func (b *RedisStreamBroker) Replay(ctx context.Context, userId, afterId int64) ([]domain.StreamEvent, error) {
+	members, err := b.client.ZRangeByScore(ctx, b.replayKey(userId), &redis.ZRangeBy{
+		Min: "(" + strconv.FormatInt(afterId, 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	events := make([]domain.StreamEvent, 0, len(members))
+	for _, m := range members {
+		var event domain.StreamEvent
+		if err := json.Unmarshal([]byte(m), &event); err != nil {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
@@ -0,0 +1,42 @@
+package redis
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStreamTicketStore keys tickets by their SHA-256 like RedisAccountTokenStore.
+type RedisStreamTicketStore struct {
+	client redis.Cmdable
+}
+
+func NewStreamTicketStore(client redis.Cmdable) ports.StreamTicketStore {
+	return &RedisStreamTicketStore{client: client}
+}
+
+func (s *RedisStreamTicketStore) key(ticket string) string {
+	hash := sha256.Sum256([]byte(ticket))
+	return "stream:ticket:" + hex.EncodeToString(hash[:])
+}
+
+func (s *RedisStreamTicketStore) Save(ctx context.Context, ticket string, userId int64, ttl time.Duration) error {
+	return s.client.Set(ctx, s.key(ticket), userId, ttl).Err()
+}
+
+func (s *RedisStreamTicketStore) Consume(ctx context.Context, ticket string) (int64, error) {
+	val, err := s.client.GetDel(ctx, s.key(ticket)).Result()
+	if err == redis.Nil {
+		return 0, domain.ErrInvalidStreamTicket
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(val, 10, 64)
+}
@@ -3,6 +3,7 @@ package redis
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 	ports "webook/internal/ports/output"
 
@@ -33,3 +34,42 @@ func (b *RedisTokenBlacklist) IsBlacklisted(ctx context.Context, ssid string) (b
 	}
 	return result > 0, nil
 }
+
+func (b *RedisTokenBlacklist) Consume(ctx context.Context, ssid string, expiration time.Duration) (bool, error) {
+	return b.client.SetNX(ctx, b.key(ssid), "1", expiration).Result()
+}
+
+func (b *RedisTokenBlacklist) familyKey(family string) string {
+	return fmt.Sprintf("token:blacklist:family:%s", family)
+}
+
+func (b *RedisTokenBlacklist) RevokeFamily(ctx context.Context, family string, expiration time.Duration) error {
+	return b.client.Set(ctx, b.familyKey(family), "1", expiration).Err()
+}
+
+func (b *RedisTokenBlacklist) IsFamilyRevoked(ctx context.Context, family string) (bool, error) {
+	result, err := b.client.Exists(ctx, b.familyKey(family)).Result()
+	if err != nil {
+		return false, err
+	}
+	return result > 0, nil
+}
+
+func (b *RedisTokenBlacklist) userKey(userId int64) string {
+	return fmt.Sprintf("token:revoked:user:%d", userId)
+}
+
+func (b *RedisTokenBlacklist) RevokeUser(ctx context.Context, userId int64, expiration time.Duration) error {
+	return b.client.Set(ctx, b.userKey(userId), time.Now().Unix(), expiration).Err()
+}
+
+func (b *RedisTokenBlacklist) UserRevokedAt(ctx context.Context, userId int64) (int64, error) {
+	val, err := b.client.Get(ctx, b.userKey(userId)).Result()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(val, 10, 64)
+}
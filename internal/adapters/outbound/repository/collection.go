@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	dao "webook/internal/adapters/outbound/persistence/mysql"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+
+	"gorm.io/gorm"
+)
+
+// NewCollectionFolderRepository builds a DAO-backed collection folder repository.
+func NewCollectionFolderRepository(dao *dao.CollectionFolderDAO) ports.CollectionFolderRepository {
+	return &collectionFolderRepository{dao: dao}
+}
+
+type collectionFolderRepository struct {
+	dao *dao.CollectionFolderDAO
+}
+
+func (r *collectionFolderRepository) Create(ctx context.Context, f domain.CollectionFolder) (int64, error) {
+	id, err := r.dao.Insert(ctx, toEntityFolder(f))
+	if errors.Is(err, dao.ErrDuplicateFolder) {
+		return 0, domain.ErrDuplicateFolder
+	}
+	return id, err
+}
+
+func (r *collectionFolderRepository) Update(ctx context.Context, f domain.CollectionFolder) error {
+	err := r.dao.Update(ctx, toEntityFolder(f))
+	switch {
+	case errors.Is(err, dao.ErrDuplicateFolder):
+		return domain.ErrDuplicateFolder
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return domain.ErrFolderNotFound
+	}
+	return err
+}
+
+func (r *collectionFolderRepository) Delete(ctx context.Context, id, userId int64) error {
+	err := r.dao.Delete(ctx, id, userId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrFolderNotFound
+	}
+	return err
+}
+
+func (r *collectionFolderRepository) FindById(ctx context.Context, id int64) (domain.CollectionFolder, error) {
+	f, err := r.dao.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.CollectionFolder{}, domain.ErrFolderNotFound
+		}
+		return domain.CollectionFolder{}, err
+	}
+	return toDomainFolder(f), nil
+}
+
+func (r *collectionFolderRepository) FindByUser(ctx context.Context, userId int64) ([]domain.CollectionFolder, error) {
+	folders, err := r.dao.FindByUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.CollectionFolder, len(folders))
+	for i, f := range folders {
+		result[i] = toDomainFolder(f)
+	}
+	return result, nil
+}
+
+func toEntityFolder(f domain.CollectionFolder) dao.CollectionFolder {
+	return dao.CollectionFolder{
+		Id:          f.Id,
+		UserId:      f.UserId,
+		Name:        f.Name,
+		Description: f.Description,
+		Public:      f.Public,
+	}
+}
+
+func toDomainFolder(f dao.CollectionFolder) domain.CollectionFolder {
+	return domain.CollectionFolder{
+		Id:          f.Id,
+		UserId:      f.UserId,
+		Name:        f.Name,
+		Description: f.Description,
+		Public:      f.Public,
+		Ctime:       f.Ctime,
+		Utime:       f.Utime,
+	}
+}
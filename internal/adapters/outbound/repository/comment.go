@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	dao "webook/internal/adapters/outbound/persistence/mysql"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+
+	"gorm.io/gorm"
+)
+
+// NewCommentRepository builds a DAO-backed comment repository.
+func NewCommentRepository(dao *dao.CommentDAO) ports.CommentRepository {
+	return &commentRepository{dao: dao}
+}
+
+type commentRepository struct {
+	dao *dao.CommentDAO
+}
+
+func (r *commentRepository) Create(ctx context.Context, c domain.Comment) (int64, error) {
+	return r.dao.Insert(ctx, dao.Comment{
+		PostId:        c.PostId,
+		UserId:        c.UserId,
+		RootId:        c.RootId,
+		ParentId:      c.ParentId,
+		ReplyToUserId: c.ReplyToUserId,
+		Content:       c.Content,
+	}, newOutboxEvent(domain.PostStatsEventComment, c.PostId, c.UserId))
+}
+
+func (r *commentRepository) FindById(ctx context.Context, id int64) (domain.Comment, error) {
+	c, err := r.dao.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Comment{}, domain.ErrCommentNotFound
+		}
+		return domain.Comment{}, err
+	}
+	return toDomainComment(c), nil
+}
+
+func (r *commentRepository) UpdateContent(ctx context.Context, id, userId int64, content string) error {
+	err := r.dao.UpdateContent(ctx, id, userId, content)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrCommentNotFound
+	}
+	return err
+}
+
+func (r *commentRepository) Delete(ctx context.Context, id int64) error {
+	_, err := r.dao.Delete(ctx, id, func(c dao.Comment) dao.PostStatsOutbox {
+		return newOutboxEvent(domain.PostStatsEventUncomment, c.PostId, c.UserId)
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrCommentNotFound
+	}
+	return err
+}
+
+func (r *commentRepository) FindRoots(ctx context.Context, postId, cursor int64, limit int) ([]domain.Comment, error) {
+	comments, err := r.dao.FindRoots(ctx, postId, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainComments(comments), nil
+}
+
+func (r *commentRepository) FindReplies(ctx context.Context, rootId, cursor int64, limit int) ([]domain.Comment, error) {
+	comments, err := r.dao.FindReplies(ctx, rootId, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainComments(comments), nil
+}
+
+func (r *commentRepository) FindRepliesPreview(ctx context.Context, rootIds []int64, n int) (map[int64][]domain.Comment, error) {
+	comments, err := r.dao.FindRepliesPreview(ctx, rootIds, n)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int64][]domain.Comment, len(rootIds))
+	for _, c := range comments {
+		result[c.RootId] = append(result[c.RootId], toDomainComment(c))
+	}
+	return result, nil
+}
+
+func (r *commentRepository) CountReplies(ctx context.Context, rootIds []int64) (map[int64]int64, error) {
+	counts, err := r.dao.CountReplies(ctx, rootIds)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int64]int64, len(counts))
+	for _, c := range counts {
+		result[c.RootId] = c.Cnt
+	}
+	return result, nil
+}
+
+func toDomainComments(comments []dao.Comment) []domain.Comment {
+	result := make([]domain.Comment, len(comments))
+	for i, c := range comments {
+		result[i] = toDomainComment(c)
+	}
+	return result
+}
+
+func toDomainComment(c dao.Comment) domain.Comment {
+	return domain.Comment{
+		Id:            c.Id,
+		PostId:        c.PostId,
+		UserId:        c.UserId,
+		RootId:        c.RootId,
+		ParentId:      c.ParentId,
+		ReplyToUserId: c.ReplyToUserId,
+		Content:       c.Content,
+		Ctime:         c.Ctime,
+		Utime:         c.Utime,
+	}
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	dao "webook/internal/adapters/outbound/persistence/mysql"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+
+	"gorm.io/gorm"
+)
+
+// NewFollowRepository builds a DAO-backed follow repository.
+func NewFollowRepository(dao *dao.FollowDAO) ports.FollowRepository {
+	return &followRepository{dao: dao}
+}
+
+type followRepository struct {
+	dao *dao.FollowDAO
+}
+
+func (r *followRepository) SetStatus(ctx context.Context, followerId, followeeId int64, followed bool) (bool, error) {
+	var status uint8
+	if followed {
+		status = 1
+	}
+	return r.dao.SetStatus(ctx, followerId, followeeId, status)
+}
+
+func (r *followRepository) IsFollowing(ctx context.Context, followerId, followeeId int64) (bool, error) {
+	rel, err := r.dao.FindRelation(ctx, followerId, followeeId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return rel.Status == 1, nil
+}
+
+func (r *followRepository) ListFollowees(ctx context.Context, userId, cursor int64, limit int) ([]domain.FollowRelation, error) {
+	rels, err := r.dao.FindFollowees(ctx, userId, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainFollowRelations(rels), nil
+}
+
+func (r *followRepository) ListFollowers(ctx context.Context, userId, cursor int64, limit int) ([]domain.FollowRelation, error) {
+	rels, err := r.dao.FindFollowers(ctx, userId, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainFollowRelations(rels), nil
+}
+
+func (r *followRepository) FindFolloweeIds(ctx context.Context, userId int64, limit int) ([]int64, error) {
+	return r.dao.FindFolloweeIds(ctx, userId, limit)
+}
+
+func (r *followRepository) FindFollowerIds(ctx context.Context, followeeId, afterId int64, limit int) ([]int64, error) {
+	return r.dao.FindFollowerIds(ctx, followeeId, afterId, limit)
+}
+
+func (r *followRepository) GetStats(ctx context.Context, userId int64) (domain.FollowStats, error) {
+	stats, err := r.dao.FindStatsByUserIds(ctx, []int64{userId})
+	if err != nil {
+		return domain.FollowStats{}, err
+	}
+	result := domain.FollowStats{UserId: userId}
+	if len(stats) > 0 {
+		result.FollowerCnt = stats[0].FollowerCnt
+		result.FolloweeCnt = stats[0].FolloweeCnt
+	}
+	return result, nil
+}
+
+func (r *followRepository) FollowerCnts(ctx context.Context, userIds []int64) (map[int64]int64, error) {
+	stats, err := r.dao.FindStatsByUserIds(ctx, userIds)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int64]int64, len(stats))
+	for _, s := range stats {
+		if s.FollowerCnt > 0 {
+			result[s.UserId] = s.FollowerCnt
+		}
+	}
+	return result, nil
+}
+
+func toDomainFollowRelations(rels []dao.FollowRelation) []domain.FollowRelation {
+	result := make([]domain.FollowRelation, len(rels))
+	for i, rel := range rels {
+		result[i] = domain.FollowRelation{
+			FollowerId: rel.FollowerId,
+			FolloweeId: rel.FolloweeId,
+			Ctime:      rel.Utime,
+		}
+	}
+	return result
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	dao "webook/internal/adapters/outbound/persistence/mysql"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+)
+
+// NewLoginAuditRepository builds a DAO-backed login audit log.
+func NewLoginAuditRepository(dao *dao.LoginAuditDAO) ports.LoginAuditRepository {
+	return &loginAuditRepository{dao: dao}
+}
+
+type loginAuditRepository struct {
+	dao *dao.LoginAuditDAO
+}
+
+func (r *loginAuditRepository) Create(ctx context.Context, a domain.LoginAttempt) error {
+	return r.dao.Insert(ctx, dao.LoginAudit{
+		UserId:    a.UserId,
+		Email:     a.Email,
+		IP:        a.IP,
+		UserAgent: a.UserAgent,
+		Result:    string(a.Result),
+	})
+}
+
+func (r *loginAuditRepository) FindByEmail(ctx context.Context, email string, limit int) ([]domain.LoginAttempt, error) {
+	audits, err := r.dao.FindByEmail(ctx, email, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.LoginAttempt, len(audits))
+	for i, a := range audits {
+		result[i] = domain.LoginAttempt{
+			Id:        a.Id,
+			UserId:    a.UserId,
+			Email:     a.Email,
+			IP:        a.IP,
+			UserAgent: a.UserAgent,
+			Result:    domain.LoginResult(a.Result),
+			Ctime:     a.Ctime,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	dao "webook/internal/adapters/outbound/persistence/mysql"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+
+	"gorm.io/gorm"
+)
+
+// NewNotificationRepository builds a DAO-backed notification repository.
+func NewNotificationRepository(dao *dao.NotificationDAO) ports.NotificationRepository {
+	return &notificationRepository{dao: dao}
+}
+
+type notificationRepository struct {
+	dao *dao.NotificationDAO
+}
+
+func (r *notificationRepository) Record(ctx context.Context, eventId string, n domain.Notification, actorId int64) (domain.Notification, bool, error) {
+	entity, recorded, err := r.dao.Record(ctx, eventId, dao.Notification{
+		RecipientId: n.RecipientId,
+		Kind:        string(n.Kind),
+		PostId:      n.PostId,
+	}, actorId)
+	if err != nil || !recorded {
+		return domain.Notification{}, recorded, err
+	}
+	return toDomainNotification(entity), true, nil
+}
+
+func (r *notificationRepository) FindByRecipient(ctx context.Context, recipientId, cursor int64, limit int, unreadOnly bool) ([]domain.Notification, error) {
+	ns, err := r.dao.FindByRecipient(ctx, recipientId, cursor, limit, unreadOnly)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.Notification, len(ns))
+	for i, n := range ns {
+		result[i] = toDomainNotification(n)
+	}
+	return result, nil
+}
+
+func (r *notificationRepository) CountUnread(ctx context.Context, recipientId int64) (int64, error) {
+	return r.dao.CountUnread(ctx, recipientId)
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, id, recipientId int64) error {
+	err := r.dao.MarkRead(ctx, id, recipientId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrNotificationNotFound
+	}
+	return err
+}
+
+func (r *notificationRepository) MarkAllRead(ctx context.Context, recipientId int64) error {
+	return r.dao.MarkAllRead(ctx, recipientId)
+}
+
+func (r *notificationRepository) DeleteEventsBefore(ctx context.Context, before int64, limit int) (int64, error) {
+	return r.dao.DeleteEventsBefore(ctx, before, limit)
+}
+
+func toDomainNotification(n dao.Notification) domain.Notification {
+	return domain.Notification{
+		Id:            n.Id,
+		RecipientId:   n.RecipientId,
+		Kind:          domain.NotificationKind(n.Kind),
+		PostId:        n.PostId,
+		LatestActorId: n.LatestActorId,
+		ActorCnt:      n.ActorCnt,
+		Read:          n.ReadAt > 0,
+		Ctime:         n.Ctime,
+		Utime:         n.Utime,
+	}
+}
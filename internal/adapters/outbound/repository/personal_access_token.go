@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	dao "webook/internal/adapters/outbound/persistence/mysql"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+
+	"gorm.io/gorm"
+)
+
+// NewPersonalAccessTokenRepository builds a DAO-backed personal access token repository.
+func NewPersonalAccessTokenRepository(dao *dao.PersonalAccessTokenDAO) ports.PersonalAccessTokenRepository {
+	return &personalAccessTokenRepository{dao: dao}
+}
+
+type personalAccessTokenRepository struct {
+	dao *dao.PersonalAccessTokenDAO
+}
+
+func (r *personalAccessTokenRepository) Create(ctx context.Context, t domain.PersonalAccessToken) (domain.PersonalAccessToken, error) {
+	scopes := make([]string, len(t.Scopes))
+	for i, s := range t.Scopes {
+		scopes[i] = string(s)
+	}
+	entity, err := r.dao.Insert(ctx, dao.PersonalAccessToken{
+		UserId:    t.UserId,
+		Name:      t.Name,
+		TokenHash: t.Hash,
+		Hint:      t.Hint,
+		Scopes:    strings.Join(scopes, ","),
+		ExpiresAt: t.ExpiresAt,
+	})
+	if err != nil {
+		return domain.PersonalAccessToken{}, err
+	}
+	return toDomainAccessToken(entity), nil
+}
+
+func (r *personalAccessTokenRepository) FindByHash(ctx context.Context, hash string) (domain.PersonalAccessToken, error) {
+	entity, err := r.dao.FindByHash(ctx, hash)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.PersonalAccessToken{}, domain.ErrAccessTokenNotFound
+	}
+	if err != nil {
+		return domain.PersonalAccessToken{}, err
+	}
+	return toDomainAccessToken(entity), nil
+}
+
+func (r *personalAccessTokenRepository) FindByUser(ctx context.Context, userId int64) ([]domain.PersonalAccessToken, error) {
+	entities, err := r.dao.FindByUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.PersonalAccessToken, len(entities))
+	for i, e := range entities {
+		result[i] = toDomainAccessToken(e)
+	}
+	return result, nil
+}
+
+func (r *personalAccessTokenRepository) Delete(ctx context.Context, userId, id int64) error {
+	err := r.dao.Delete(ctx, userId, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrAccessTokenNotFound
+	}
+	return err
+}
+
+func (r *personalAccessTokenRepository) DeleteByUser(ctx context.Context, userId int64) error {
+	return r.dao.DeleteByUser(ctx, userId)
+}
+
+func (r *personalAccessTokenRepository) UpdateLastUsed(ctx context.Context, id, lastUsed int64) error {
+	return r.dao.UpdateLastUsed(ctx, id, lastUsed)
+}
+
+func toDomainAccessToken(e dao.PersonalAccessToken) domain.PersonalAccessToken {
+	var scopes []domain.TokenScope
+	for _, s := range strings.Split(e.Scopes, ",") {
+		if s != "" {
+			scopes = append(scopes, domain.TokenScope(s))
+		}
+	}
+	return domain.PersonalAccessToken{
+		Id:        e.Id,
+		UserId:    e.UserId,
+		Name:      e.Name,
+		Hash:      e.TokenHash,
+		Hint:      e.Hint,
+		Scopes:    scopes,
+		ExpiresAt: e.ExpiresAt,
+		LastUsed:  e.LastUsed,
+		Ctime:     e.Ctime,
+	}
+}
@@ -11,13 +11,13 @@ import (
 )
 
 // NewPostRepository builds a DAO-backed post repository.
-func NewPostRepository(dao *dao.PostDAO) ports.PostRepository {
-	return &postRepository{dao: dao}
+func NewPostRepository(dao *dao.PostDAO, tagDAO *dao.TagDAO) ports.PostRepository {
+	return &postRepository{dao: dao, tagDAO: tagDAO}
 }
 
 // NewPublishedPostRepository builds a DAO-backed published repository.
-func NewPublishedPostRepository(dao *dao.PublishedPostDAO) ports.PublishedPostRepository {
-	return &publishedPostRepository{dao: dao}
+func NewPublishedPostRepository(dao *dao.PublishedPostDAO, tagDAO *dao.TagDAO) ports.PublishedPostRepository {
+	return &publishedPostRepository{dao: dao, tagDAO: tagDAO}
 }
 
 // NewCachedPublishedPostRepository wraps a published repository with cache behavior.
@@ -26,11 +26,13 @@ func NewCachedPublishedPostRepository(repo ports.PublishedPostRepository, cache
 }
 
 type postRepository struct {
-	dao *dao.PostDAO
+	dao    *dao.PostDAO
+	tagDAO *dao.TagDAO
 }
 
 type publishedPostRepository struct {
-	dao *dao.PublishedPostDAO
+	dao    *dao.PublishedPostDAO
+	tagDAO *dao.TagDAO
 }
 
 type cachedPublishedPostRepository struct {
@@ -39,11 +41,19 @@ type cachedPublishedPostRepository struct {
 }
 
 func (r *postRepository) Create(ctx context.Context, p domain.Post) (int64, error) {
-	return r.dao.Insert(ctx, toPostEntity(p))
+	entity, err := r.toEntityWithTags(ctx, p)
+	if err != nil {
+		return 0, err
+	}
+	return r.dao.Insert(ctx, entity)
 }
 
 func (r *postRepository) Update(ctx context.Context, p domain.Post) error {
-	err := r.dao.UpdateById(ctx, toPostEntity(p))
+	entity, err := r.toEntityWithTags(ctx, p)
+	if err != nil {
+		return err
+	}
+	err = r.dao.UpdateById(ctx, entity)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return domain.ErrPostNotFound
 	}
@@ -58,7 +68,11 @@ func (r *postRepository) FindById(ctx context.Context, id int64) (domain.Post, e
 		}
 		return domain.Post{}, err
 	}
-	return toDomainPost(p), nil
+	result := []domain.Post{toDomainPost(p)}
+	if err := r.fillTags(ctx, result); err != nil {
+		return domain.Post{}, err
+	}
+	return result[0], nil
 }
 
 func (r *postRepository) FindByAuthor(ctx context.Context, authorId int64, offset, limit int) ([]domain.Post, error) {
@@ -70,21 +84,102 @@ func (r *postRepository) FindByAuthor(ctx context.Context, authorId int64, offse
 	for i, p := range posts {
 		result[i] = toDomainPost(p)
 	}
+	if err := r.fillTags(ctx, result); err != nil {
+		return nil, err
+	}
 	return result, nil
 }
 
 func (r *postRepository) Sync(ctx context.Context, p domain.Post) (int64, error) {
-	return r.dao.Sync(ctx, toPostEntity(p))
+	entity, err := r.toEntityWithTags(ctx, p)
+	if err != nil {
+		return 0, err
+	}
+	id, err := r.dao.Sync(ctx, entity, func(p dao.PublishedPost) dao.PostStatsOutbox {
+		return newOutboxEvent(domain.PostStatsEventPublish, p.Id, p.AuthorId)
+	})
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return 0, domain.ErrPostNotFound
+	case errors.Is(err, dao.ErrPostHidden):
+		return 0, domain.ErrPostHidden
+	}
+	return id, err
 }
 
 func (r *postRepository) SyncStatus(ctx context.Context, id int64, authorId int64, status uint8) error {
 	err := r.dao.SyncStatus(ctx, id, authorId, status)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return domain.ErrPostNotFound
+	case errors.Is(err, dao.ErrPostHidden):
+		return domain.ErrPostHidden
+	}
+	return err
+}
+
+func (r *postRepository) Unhide(ctx context.Context, id int64) error {
+	err := r.dao.Unhide(ctx, id)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrPostNotHidden
+	}
+	return err
+}
+
+func (r *postRepository) Schedule(ctx context.Context, id int64, authorId int64, scheduledAt int64) error {
+	err := r.dao.Schedule(ctx, id, authorId, scheduledAt)
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
 		return domain.ErrPostNotFound
+	case errors.Is(err, dao.ErrPostNotDraft):
+		return domain.ErrPostNotDraft
+	case errors.Is(err, dao.ErrPostHidden):
+		return domain.ErrPostHidden
 	}
 	return err
 }
 
+func (r *postRepository) Reschedule(ctx context.Context, id int64, authorId int64, scheduledAt int64) error {
+	err := r.dao.Reschedule(ctx, id, authorId, scheduledAt)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrPostNotScheduled
+	}
+	return err
+}
+
+func (r *postRepository) CancelSchedule(ctx context.Context, id int64, authorId int64) error {
+	err := r.dao.CancelSchedule(ctx, id, authorId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrPostNotScheduled
+	}
+	return err
+}
+
+func (r *postRepository) FindDueScheduled(ctx context.Context, now int64, limit int) ([]domain.Post, error) {
+	posts, err := r.dao.FindDueScheduled(ctx, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.Post, len(posts))
+	for i, p := range posts {
+		result[i] = toDomainPost(p)
+	}
+	return result, nil
+}
+
+func (r *postRepository) PublishScheduled(ctx context.Context, id int64, now int64) (domain.Post, error) {
+	p, err := r.dao.PublishScheduled(ctx, id, now, func(p dao.PublishedPost) dao.PostStatsOutbox {
+		return newOutboxEvent(domain.PostStatsEventPublish, p.Id, p.AuthorId)
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.Post{}, domain.ErrPostNotScheduled
+	}
+	if err != nil {
+		return domain.Post{}, err
+	}
+	return toDomainPost(p), nil
+}
+
 func (r *postRepository) CountByAuthor(ctx context.Context, authorId int64) (int64, error) {
 	return r.dao.CountByAuthor(ctx, authorId)
 }
@@ -97,7 +192,19 @@ func (r *publishedPostRepository) FindById(ctx context.Context, id int64) (domai
 		}
 		return domain.Post{}, err
 	}
-	return toDomainPublishedPost(p), nil
+	result, err := r.toDomainWithTags(ctx, []dao.PublishedPost{p})
+	if err != nil {
+		return domain.Post{}, err
+	}
+	return result[0], nil
+}
+
+func (r *publishedPostRepository) FindByIds(ctx context.Context, ids []int64) ([]domain.Post, error) {
+	posts, err := r.dao.FindByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomainWithTags(ctx, posts)
 }
 
 func (r *publishedPostRepository) List(ctx context.Context, offset, limit int) ([]domain.Post, error) {
@@ -105,17 +212,103 @@ func (r *publishedPostRepository) List(ctx context.Context, offset, limit int) (
 	if err != nil {
 		return nil, err
 	}
-	result := make([]domain.Post, len(posts))
-	for i, p := range posts {
-		result[i] = toDomainPublishedPost(p)
+	return r.toDomainWithTags(ctx, posts)
+}
+
+func (r *publishedPostRepository) ListAfterId(ctx context.Context, afterId int64, limit int) ([]domain.Post, error) {
+	posts, err := r.dao.ListAfterId(ctx, afterId, limit)
+	if err != nil {
+		return nil, err
 	}
-	return result, nil
+	return r.toDomainWithTags(ctx, posts)
 }
 
 func (r *publishedPostRepository) Count(ctx context.Context) (int64, error) {
 	return r.dao.Count(ctx)
 }
 
+func (r *publishedPostRepository) ListByTag(ctx context.Context, tag string, offset, limit int) ([]domain.Post, error) {
+	t, err := r.tagDAO.FindByName(ctx, domain.NormalizeTagName(tag))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return []domain.Post{}, nil
+		}
+		return nil, err
+	}
+	posts, err := r.dao.ListByTag(ctx, t.Id, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomainWithTags(ctx, posts)
+}
+
+func (r *publishedPostRepository) CountByTag(ctx context.Context, tag string) (int64, error) {
+	t, err := r.tagDAO.FindByName(ctx, domain.NormalizeTagName(tag))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return r.dao.CountByTag(ctx, t.Id)
+}
+
+func (r *publishedPostRepository) ListByAuthors(ctx context.Context, authorIds []int64, cursor domain.FeedCursor, limit int) ([]domain.Post, error) {
+	posts, err := r.dao.ListByAuthors(ctx, authorIds, cursor.Ts, cursor.PostId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomainWithTags(ctx, posts)
+}
+
+func (r *publishedPostRepository) CountByAuthor(ctx context.Context, authorId int64) (int64, error) {
+	return r.dao.CountByAuthor(ctx, authorId)
+}
+
+func (r *publishedPostRepository) SumLikesByAuthor(ctx context.Context, authorId int64) (int64, error) {
+	return r.dao.SumLikesByAuthor(ctx, authorId)
+}
+
+func (r *publishedPostRepository) ListLikedBy(ctx context.Context, userId int64, offset, limit int) ([]domain.Post, error) {
+	posts, err := r.dao.ListLikedBy(ctx, userId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomainWithTags(ctx, posts)
+}
+
+func (r *publishedPostRepository) CountLikedBy(ctx context.Context, userId int64) (int64, error) {
+	return r.dao.CountLikedBy(ctx, userId)
+}
+
+func (r *publishedPostRepository) ListCollectedBy(ctx context.Context, userId int64, offset, limit int) ([]domain.Post, error) {
+	posts, err := r.dao.ListCollectedBy(ctx, userId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomainWithTags(ctx, posts)
+}
+
+func (r *publishedPostRepository) CountCollectedBy(ctx context.Context, userId int64) (int64, error) {
+	return r.dao.CountCollectedBy(ctx, userId)
+}
+
+// toDomainWithTags converts published entities and attaches their tags.
+func (r *publishedPostRepository) toDomainWithTags(ctx context.Context, posts []dao.PublishedPost) ([]domain.Post, error) {
+	result := make([]domain.Post, len(posts))
+	ids := make([]int64, len(posts))
+	for i, p := range posts {
+		result[i] = toDomainPublishedPost(p)
+		ids[i] = p.Id
+	}
+	names, err := r.tagDAO.FindPublishedNamesByPostIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	attachTags(result, names)
+	return result, nil
+}
+
 func (r *cachedPublishedPostRepository) FindById(ctx context.Context, id int64) (domain.Post, error) {
 	p, err := r.cache.Get(ctx, id)
 	if err == nil {
@@ -132,33 +325,136 @@ func (r *cachedPublishedPostRepository) FindById(ctx context.Context, id int64)
 	return p, nil
 }
 
+func (r *cachedPublishedPostRepository) FindByIds(ctx context.Context, ids []int64) ([]domain.Post, error) {
+	return r.repo.FindByIds(ctx, ids)
+}
+
 func (r *cachedPublishedPostRepository) List(ctx context.Context, offset, limit int) ([]domain.Post, error) {
 	return r.repo.List(ctx, offset, limit)
 }
 
+func (r *cachedPublishedPostRepository) ListAfterId(ctx context.Context, afterId int64, limit int) ([]domain.Post, error) {
+	return r.repo.ListAfterId(ctx, afterId, limit)
+}
+
 func (r *cachedPublishedPostRepository) Count(ctx context.Context) (int64, error) {
 	return r.repo.Count(ctx)
 }
 
+func (r *cachedPublishedPostRepository) ListByTag(ctx context.Context, tag string, offset, limit int) ([]domain.Post, error) {
+	return r.repo.ListByTag(ctx, tag, offset, limit)
+}
+
+func (r *cachedPublishedPostRepository) CountByTag(ctx context.Context, tag string) (int64, error) {
+	return r.repo.CountByTag(ctx, tag)
+}
+
+func (r *cachedPublishedPostRepository) ListByAuthors(ctx context.Context, authorIds []int64, cursor domain.FeedCursor, limit int) ([]domain.Post, error) {
+	return r.repo.ListByAuthors(ctx, authorIds, cursor, limit)
+}
+
+func (r *cachedPublishedPostRepository) CountByAuthor(ctx context.Context, authorId int64) (int64, error) {
+	return r.repo.CountByAuthor(ctx, authorId)
+}
+
+func (r *cachedPublishedPostRepository) SumLikesByAuthor(ctx context.Context, authorId int64) (int64, error) {
+	return r.repo.SumLikesByAuthor(ctx, authorId)
+}
+
+func (r *cachedPublishedPostRepository) ListLikedBy(ctx context.Context, userId int64, offset, limit int) ([]domain.Post, error) {
+	return r.repo.ListLikedBy(ctx, userId, offset, limit)
+}
+
+func (r *cachedPublishedPostRepository) CountLikedBy(ctx context.Context, userId int64) (int64, error) {
+	return r.repo.CountLikedBy(ctx, userId)
+}
+
+func (r *cachedPublishedPostRepository) ListCollectedBy(ctx context.Context, userId int64, offset, limit int) ([]domain.Post, error) {
+	return r.repo.ListCollectedBy(ctx, userId, offset, limit)
+}
+
+func (r *cachedPublishedPostRepository) CountCollectedBy(ctx context.Context, userId int64) (int64, error) {
+	return r.repo.CountCollectedBy(ctx, userId)
+}
+
+// toEntityWithTags converts a post and resolves its tag names to ids.
+// Nil tags stay nil so the DAO leaves the existing tags untouched.
+func (r *postRepository) toEntityWithTags(ctx context.Context, p domain.Post) (dao.Post, error) {
+	entity := toPostEntity(p)
+	if p.Tags == nil {
+		return entity, nil
+	}
+	names := make([]string, 0, len(p.Tags))
+	seen := make(map[string]struct{}, len(p.Tags))
+	for _, name := range p.Tags {
+		name = domain.NormalizeTagName(name)
+		if _, ok := seen[name]; ok || name == "" {
+			continue
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+	tags, err := r.tagDAO.FindByNames(ctx, names)
+	if err != nil {
+		return dao.Post{}, err
+	}
+	if len(tags) != len(names) {
+		return dao.Post{}, domain.ErrTagNotFound
+	}
+	entity.TagIds = make([]int64, len(tags))
+	for i, t := range tags {
+		entity.TagIds[i] = t.Id
+	}
+	return entity, nil
+}
+
+func (r *postRepository) fillTags(ctx context.Context, posts []domain.Post) error {
+	ids := make([]int64, len(posts))
+	for i, p := range posts {
+		ids[i] = p.Id
+	}
+	names, err := r.tagDAO.FindNamesByPostIds(ctx, ids)
+	if err != nil {
+		return err
+	}
+	attachTags(posts, names)
+	return nil
+}
+
+func attachTags(posts []domain.Post, names []dao.PostTagName) {
+	byPost := make(map[int64][]string, len(posts))
+	for _, n := range names {
+		byPost[n.PostId] = append(byPost[n.PostId], n.Name)
+	}
+	for i := range posts {
+		posts[i].Tags = byPost[posts[i].Id]
+		if posts[i].Tags == nil {
+			posts[i].Tags = []string{}
+		}
+	}
+}
+
 func toPostEntity(p domain.Post) dao.Post {
 	return dao.Post{
-		Id:       p.Id,
-		Title:    p.Title,
-		Content:  p.Content,
-		AuthorId: p.AuthorId,
-		Status:   p.Status,
+		Id:          p.Id,
+		Title:       p.Title,
+		Content:     p.Content,
+		AuthorId:    p.AuthorId,
+		Status:      p.Status,
+		ScheduledAt: p.ScheduledAt,
 	}
 }
 
 func toDomainPost(p dao.Post) domain.Post {
 	return domain.Post{
-		Id:       p.Id,
-		Title:    p.Title,
-		Content:  p.Content,
-		AuthorId: p.AuthorId,
-		Status:   p.Status,
-		Ctime:    p.Ctime,
-		Utime:    p.Utime,
+		Id:          p.Id,
+		Title:       p.Title,
+		Content:     p.Content,
+		AuthorId:    p.AuthorId,
+		Status:      p.Status,
+		Ctime:       p.Ctime,
+		Utime:       p.Utime,
+		ScheduledAt: p.ScheduledAt,
 	}
 }
 
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+)
+
+// NewCacheEvictingPostRepository wraps a post repository so that publishing,
+// unpublishing and hiding drop the cached published copy right away instead
+// of serving the old one until it expires.
+func NewCacheEvictingPostRepository(repo ports.PostRepository, cache ports.PostCache) ports.PostRepository {
+	return &cacheEvictingPostRepository{PostRepository: repo, cache: cache}
+}
+
+type cacheEvictingPostRepository struct {
+	ports.PostRepository
+	cache ports.PostCache
+}
+
+func (r *cacheEvictingPostRepository) Sync(ctx context.Context, p domain.Post) (int64, error) {
+	id, err := r.PostRepository.Sync(ctx, p)
+	if err != nil {
+		return 0, err
+	}
+	// The post is online either way, a failure only serves the previous version until the key expires.
+	_ = r.cache.Delete(ctx, id)
+	return id, nil
+}
+
+func (r *cacheEvictingPostRepository) PublishScheduled(ctx context.Context, id int64, now int64) (domain.Post, error) {
+	p, err := r.PostRepository.PublishScheduled(ctx, id, now)
+	if err != nil {
+		return domain.Post{}, err
+	}
+	_ = r.cache.Delete(ctx, id)
+	return p, nil
+}
+
+func (r *cacheEvictingPostRepository) SyncStatus(ctx context.Context, id int64, authorId int64, status uint8) error {
+	err := r.PostRepository.SyncStatus(ctx, id, authorId, status)
+	if err != nil {
+		return err
+	}
+	if !domain.PostStatusOffline(status) {
+		return nil
+	}
+	// Unlike a stale version, a post taken offline must stop being served, so the
+	// caller has to know and retry; setting the same status again is harmless.
+	return r.cache.Delete(ctx, id)
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+	repomocks "webook/internal/adapters/outbound/mocks"
+	"webook/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// memoryPostCache 内存版的帖子缓存，用来观察缓存中实际剩下的内容
+type memoryPostCache struct {
+	mu    sync.Mutex
+	posts map[int64]domain.Post
+}
+
+func newMemoryPostCache(posts ...domain.Post) *memoryPostCache {
+	c := &memoryPostCache{posts: make(map[int64]domain.Post)}
+	for _, p := range posts {
+		c.posts[p.Id] = p
+	}
+	return c
+}
+
+func (c *memoryPostCache) Get(ctx context.Context, id int64) (domain.Post, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p, ok := c.posts[id]
+	if !ok {
+		return domain.Post{}, errors.New("cache miss")
+	}
+	return p, nil
+}
+
+func (c *memoryPostCache) Set(ctx context.Context, p domain.Post) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.posts[p.Id] = p
+	return nil
+}
+
+func (c *memoryPostCache) Delete(ctx context.Context, id int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.posts, id)
+	return nil
+}
+
+func (c *memoryPostCache) TryLock(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return true, nil
+}
+
+func TestCacheEvictingPostRepository_SyncStatus(t *testing.T) {
+	cached := domain.Post{Id: 10, AuthorId: 7, Title: "标题", Status: domain.PostStatusPublished}
+	tests := []struct {
+		name      string
+		status    uint8
+		syncErr   error
+		wantErr   error
+		wantFound bool
+	}{
+		{
+			name:    "管理员隐藏-立即读不到",
+			status:  domain.PostStatusHidden,
+			wantErr: domain.ErrPostNotFound,
+		},
+		{
+			name:    "作者删除-立即读不到",
+			status:  domain.PostStatusPrivate,
+			wantErr: domain.ErrPostNotFound,
+		},
+		{
+			name:      "修改失败-保留缓存",
+			status:    domain.PostStatusHidden,
+			syncErr:   domain.ErrPostNotFound,
+			wantFound: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			posts := repomocks.NewMockPostRepository(ctrl)
+			published := repomocks.NewMockPublishedPostRepository(ctrl)
+			cache := newMemoryPostCache(cached)
+			repo := NewCacheEvictingPostRepository(posts, cache)
+			reader := NewCachedPublishedPostRepository(published, cache)
+
+			posts.EXPECT().SyncStatus(gomock.Any(), int64(10), int64(7), tc.status).Return(tc.syncErr)
+			err := repo.SyncStatus(context.Background(), 10, 7, tc.status)
+			assert.ErrorIs(t, err, tc.syncErr)
+
+			if tc.wantFound {
+				p, err := reader.FindById(context.Background(), 10)
+				require.NoError(t, err)
+				assert.Equal(t, cached, p)
+				return
+			}
+			// 线上库已经删除，缓存不能再返回这篇帖子
+			published.EXPECT().FindById(gomock.Any(), int64(10)).Return(domain.Post{}, domain.ErrPostNotFound)
+			_, err = reader.FindById(context.Background(), 10)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestCacheEvictingPostRepository_Sync(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	posts := repomocks.NewMockPostRepository(ctrl)
+	cache := newMemoryPostCache(domain.Post{Id: 10, Title: "旧标题"}, domain.Post{Id: 11, Title: "旧标题"})
+	repo := NewCacheEvictingPostRepository(posts, cache)
+
+	posts.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(int64(10), nil)
+	_, err := repo.Sync(context.Background(), domain.Post{Id: 10, Title: "新标题"})
+	require.NoError(t, err)
+	posts.EXPECT().PublishScheduled(gomock.Any(), int64(11), int64(1000)).Return(domain.Post{Id: 11}, nil)
+	_, err = repo.PublishScheduled(context.Background(), 11, 1000)
+	require.NoError(t, err)
+
+	_, err = cache.Get(context.Background(), 10)
+	assert.Error(t, err, "重新发布后删除旧内容的缓存")
+	_, err = cache.Get(context.Background(), 11)
+	assert.Error(t, err, "定时发布后删除旧内容的缓存")
+}
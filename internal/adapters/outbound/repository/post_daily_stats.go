@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	dao "webook/internal/adapters/outbound/persistence/mysql"
+	"webook/internal/domain"
+	output "webook/internal/ports/output"
+)
+
+// NewPostDailyStatsRepository builds a DAO-backed daily stats repository.
+func NewPostDailyStatsRepository(dao *dao.PostDailyStatsDAO) output.PostDailyStatsRepository {
+	return &postDailyStatsRepository{dao: dao}
+}
+
+type postDailyStatsRepository struct {
+	dao *dao.PostDailyStatsDAO
+}
+
+func (r *postDailyStatsRepository) IncrBatch(ctx context.Context, deltas []domain.PostDailyStats) error {
+	entities := make([]dao.PostDailyStats, 0, len(deltas))
+	for _, d := range deltas {
+		entities = append(entities, dao.PostDailyStats{
+			PostId:       d.PostId,
+			Date:         d.Date,
+			ReadCnt:      d.ReadCnt,
+			LikeCnt:      d.LikeCnt,
+			UnlikeCnt:    d.UnlikeCnt,
+			CollectCnt:   d.CollectCnt,
+			UncollectCnt: d.UncollectCnt,
+		})
+	}
+	return r.dao.IncrBatch(ctx, entities)
+}
+
+func (r *postDailyStatsRepository) FindByPost(ctx context.Context, postId int64, from, to string) ([]domain.PostDailyStats, error) {
+	rows, err := r.dao.FindByPost(ctx, postId, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainDailyStats(rows), nil
+}
+
+func (r *postDailyStatsRepository) SumByAuthorPerDay(ctx context.Context, authorId int64, from, to string) ([]domain.PostDailyStats, error) {
+	rows, err := r.dao.SumByAuthorPerDay(ctx, authorId, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return toDomainDailyStats(rows), nil
+}
+
+func (r *postDailyStatsRepository) SumByAuthorPerPost(ctx context.Context, authorId int64, from, to string) ([]domain.AuthorPostStats, error) {
+	rows, err := r.dao.SumByAuthorPerPost(ctx, authorId, from, to)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.AuthorPostStats, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, domain.AuthorPostStats{
+			PostId: row.PostId,
+			Title:  row.Title,
+			Stats: domain.PostDailyStats{
+				PostId:       row.PostId,
+				ReadCnt:      row.ReadCnt,
+				LikeCnt:      row.LikeCnt,
+				UnlikeCnt:    row.UnlikeCnt,
+				CollectCnt:   row.CollectCnt,
+				UncollectCnt: row.UncollectCnt,
+			},
+		})
+	}
+	return result, nil
+}
+
+func toDomainDailyStats(rows []dao.PostDailyStats) []domain.PostDailyStats {
+	result := make([]domain.PostDailyStats, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, domain.PostDailyStats{
+			PostId:        row.PostId,
+			Date:          row.Date,
+			ReadCnt:       row.ReadCnt,
+			LikeCnt:       row.LikeCnt,
+			UnlikeCnt:     row.UnlikeCnt,
+			CollectCnt:    row.CollectCnt,
+			UncollectCnt:  row.UncollectCnt,
+			UniqueReadCnt: row.UniqueReadCnt,
+		})
+	}
+	return result
+}
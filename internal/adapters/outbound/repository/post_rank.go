@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+)
+
+// NewRankedPostRepository wraps a post repository so that posts taken offline
+// also leave the hot ranking right away instead of lingering until the next refresh.
+func NewRankedPostRepository(repo ports.PostRepository, rank ports.PostRankCache) ports.PostRepository {
+	return &rankedPostRepository{PostRepository: repo, rank: rank}
+}
+
+type rankedPostRepository struct {
+	ports.PostRepository
+	rank ports.PostRankCache
+}
+
+func (r *rankedPostRepository) SyncStatus(ctx context.Context, id int64, authorId int64, status uint8) error {
+	err := r.PostRepository.SyncStatus(ctx, id, authorId, status)
+	if err != nil {
+		return err
+	}
+	if domain.PostStatusOffline(status) {
+		// The ranking read path drops posts it cannot find, so a failure only costs a short page.
+		_ = r.rank.Remove(ctx, id)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	dao "webook/internal/adapters/outbound/persistence/mysql"
+	"webook/internal/domain"
+	output "webook/internal/ports/output"
+)
+
+// NewPostReaderRepository builds a DAO-backed unique reader snapshot repository.
+func NewPostReaderRepository(dao *dao.PostReaderDAO) output.PostReaderRepository {
+	return &postReaderRepository{dao: dao}
+}
+
+type postReaderRepository struct {
+	dao *dao.PostReaderDAO
+}
+
+func (r *postReaderRepository) FindSketch(ctx context.Context, postId int64) ([]byte, error) {
+	return r.dao.FindSketch(ctx, postId)
+}
+
+func (r *postReaderRepository) SaveSnapshots(ctx context.Context, snaps []domain.PostReaderSnapshot) error {
+	entities := make([]dao.PostReaderSnapshot, 0, len(snaps))
+	for _, s := range snaps {
+		entities = append(entities, dao.PostReaderSnapshot{
+			PostId:   s.PostId,
+			Sketch:   s.Sketch,
+			Count:    s.Count,
+			Date:     s.Date,
+			DayCount: s.DayCount,
+		})
+	}
+	return r.dao.SaveSnapshots(ctx, entities)
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	dao "webook/internal/adapters/outbound/persistence/mysql"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+
+	"gorm.io/gorm"
+)
+
+// NewPostRevisionRepository builds a DAO-backed revision repository.
+func NewPostRevisionRepository(dao *dao.PostRevisionDAO) ports.PostRevisionRepository {
+	return &postRevisionRepository{dao: dao}
+}
+
+type postRevisionRepository struct {
+	dao *dao.PostRevisionDAO
+}
+
+func (r *postRevisionRepository) FindByPostId(ctx context.Context, postId int64, offset, limit int) ([]domain.PostRevision, error) {
+	revisions, err := r.dao.FindByPostId(ctx, postId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.PostRevision, len(revisions))
+	for i, rev := range revisions {
+		result[i] = toDomainPostRevision(rev)
+	}
+	return result, nil
+}
+
+func (r *postRevisionRepository) CountByPostId(ctx context.Context, postId int64) (int64, error) {
+	return r.dao.CountByPostId(ctx, postId)
+}
+
+func (r *postRevisionRepository) FindByVersion(ctx context.Context, postId, version int64) (domain.PostRevision, error) {
+	rev, err := r.dao.FindByVersion(ctx, postId, version)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.PostRevision{}, domain.ErrPostRevisionNotFound
+		}
+		return domain.PostRevision{}, err
+	}
+	return toDomainPostRevision(rev), nil
+}
+
+func toDomainPostRevision(r dao.PostRevision) domain.PostRevision {
+	return domain.PostRevision{
+		Id:       r.Id,
+		PostId:   r.PostId,
+		Version:  r.Version,
+		Title:    r.Title,
+		Content:  r.Content,
+		AuthorId: r.AuthorId,
+		Status:   r.Status,
+		Ctime:    r.Ctime,
+	}
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+)
+
+// NewIndexedPostRepository wraps a post repository so that publishing and
+// hiding posts keep the full-text index in step with the published table.
+func NewIndexedPostRepository(repo ports.PostRepository, index ports.SearchService) ports.PostRepository {
+	return &indexedPostRepository{PostRepository: repo, index: index}
+}
+
+type indexedPostRepository struct {
+	ports.PostRepository
+	index ports.SearchService
+}
+
+func (r *indexedPostRepository) Sync(ctx context.Context, p domain.Post) (int64, error) {
+	id, err := r.PostRepository.Sync(ctx, p)
+	if err != nil {
+		return 0, err
+	}
+	p.Id = id
+	p.Status = domain.PostStatusPublished
+	p.Utime = time.Now().UnixMilli()
+	// The index is rebuilt periodically, so a failed update only delays visibility.
+	_ = r.index.Index(ctx, p)
+	return id, nil
+}
+
+func (r *indexedPostRepository) PublishScheduled(ctx context.Context, id int64, now int64) (domain.Post, error) {
+	p, err := r.PostRepository.PublishScheduled(ctx, id, now)
+	if err != nil {
+		return domain.Post{}, err
+	}
+	_ = r.index.Index(ctx, p)
+	return p, nil
+}
+
+func (r *indexedPostRepository) SyncStatus(ctx context.Context, id int64, authorId int64, status uint8) error {
+	err := r.PostRepository.SyncStatus(ctx, id, authorId, status)
+	if err != nil {
+		return err
+	}
+	if domain.PostStatusOffline(status) {
+		_ = r.index.Remove(ctx, id)
+	}
+	return nil
+}
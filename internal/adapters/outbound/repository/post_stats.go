@@ -45,10 +45,12 @@ func (r *postStatsRepository) FindByPostIds(ctx context.Context, postIds []int64
 	result := make([]domain.PostStats, 0, len(stats))
 	for _, st := range stats {
 		result = append(result, domain.PostStats{
-			PostId:     st.PostId,
-			LikeCnt:    st.LikeCnt,
-			CollectCnt: st.CollectCnt,
-			ReadCnt:    st.ReadCnt,
+			PostId:        st.PostId,
+			LikeCnt:       st.LikeCnt,
+			CollectCnt:    st.CollectCnt,
+			ReadCnt:       st.ReadCnt,
+			CommentCnt:    st.CommentCnt,
+			UniqueReadCnt: st.UniqueReadCnt,
 		})
 	}
 	return result, nil
@@ -62,31 +64,53 @@ func (r *postStatsRepository) Upsert(ctx context.Context, stats []domain.PostSta
 			LikeCnt:    st.LikeCnt,
 			CollectCnt: st.CollectCnt,
 			ReadCnt:    st.ReadCnt,
+			CommentCnt: st.CommentCnt,
 		})
 	}
 	return r.dao.Upsert(ctx, entities)
 }
 
-func (r *postLikeRepository) SetStatus(ctx context.Context, postId, userId int64, status uint8) (bool, error) {
-	rel, err := r.dao.FindByPostIdUserId(ctx, postId, userId)
+func (r *postStatsRepository) FindByPostRange(ctx context.Context, from, to int64) ([]domain.PostStats, error) {
+	stats, err := r.dao.FindByPostRange(ctx, from, to)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			if status == 0 {
-				return false, nil
-			}
-			newRel := &dao.PostLikeRelation{
-				PostId: postId,
-				UserId: userId,
-				Status: status,
-			}
-			return true, r.dao.Insert(ctx, newRel)
-		}
-		return false, err
+		return nil, err
+	}
+	result := make([]domain.PostStats, 0, len(stats))
+	for _, st := range stats {
+		result = append(result, domain.PostStats{
+			PostId:        st.PostId,
+			LikeCnt:       st.LikeCnt,
+			CollectCnt:    st.CollectCnt,
+			ReadCnt:       st.ReadCnt,
+			CommentCnt:    st.CommentCnt,
+			UniqueReadCnt: st.UniqueReadCnt,
+		})
+	}
+	return result, nil
+}
+
+func (r *postStatsRepository) MaxPostId(ctx context.Context) (int64, error) {
+	return r.dao.MaxPostId(ctx)
+}
+
+func (r *postStatsRepository) UpdateCounts(ctx context.Context, stats domain.PostStats) error {
+	return r.dao.UpdateCounts(ctx, stats.PostId, stats.LikeCnt, stats.CollectCnt)
+}
+
+func (r *postLikeRepository) CountActiveByPostRange(ctx context.Context, from, to int64) (map[int64]int64, error) {
+	counts, err := r.dao.CountActiveByPostRange(ctx, from, to)
+	if err != nil {
+		return nil, err
 	}
-	if rel.Status == status {
-		return false, nil
+	return toCountMap(counts), nil
+}
+
+func (r *postLikeRepository) SetStatus(ctx context.Context, postId, userId int64, status uint8) (bool, error) {
+	eventType := domain.PostStatsEventUnlike
+	if status == 1 {
+		eventType = domain.PostStatsEventLike
 	}
-	return true, r.dao.UpdateStatus(ctx, rel.Id, status)
+	return r.dao.SetStatus(ctx, postId, userId, status, newOutboxEvent(eventType, postId, userId))
 }
 
 func (r *postLikeRepository) HasLiked(ctx context.Context, postId, userId int64) (bool, error) {
@@ -114,26 +138,20 @@ func (r *postLikeRepository) FindLikedPostIds(ctx context.Context, postIds []int
 	return result, nil
 }
 
-func (r *postCollectRepository) SetStatus(ctx context.Context, postId, userId int64, status uint8) (bool, error) {
-	rel, err := r.dao.FindByPostIdUserId(ctx, postId, userId)
+func (r *postCollectRepository) CountActiveByPostRange(ctx context.Context, from, to int64) (map[int64]int64, error) {
+	counts, err := r.dao.CountActiveByPostRange(ctx, from, to)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			if status == 0 {
-				return false, nil
-			}
-			newRel := &dao.PostCollectRelation{
-				PostId: postId,
-				UserId: userId,
-				Status: status,
-			}
-			return true, r.dao.Insert(ctx, newRel)
-		}
-		return false, err
+		return nil, err
 	}
-	if rel.Status == status {
-		return false, nil
+	return toCountMap(counts), nil
+}
+
+func (r *postCollectRepository) SetStatus(ctx context.Context, postId, userId int64, status uint8) (bool, error) {
+	eventType := domain.PostStatsEventUncollect
+	if status == 1 {
+		eventType = domain.PostStatsEventCollect
 	}
-	return true, r.dao.UpdateStatus(ctx, rel.Id, status)
+	return r.dao.SetStatus(ctx, postId, userId, status, newOutboxEvent(eventType, postId, userId))
 }
 
 func (r *postCollectRepository) HasCollected(ctx context.Context, postId, userId int64) (bool, error) {
@@ -160,3 +178,43 @@ func (r *postCollectRepository) FindCollectedPostIds(ctx context.Context, postId
 	}
 	return result, nil
 }
+
+func (r *postCollectRepository) MoveToFolder(ctx context.Context, postId, userId, folderId int64) error {
+	err := r.dao.MoveToFolder(ctx, postId, userId, folderId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrPostNotCollected
+	}
+	return err
+}
+
+func (r *postCollectRepository) CountByFolder(ctx context.Context, userId int64) (map[int64]int64, error) {
+	counts, err := r.dao.CountByFolder(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int64]int64, len(counts))
+	for _, c := range counts {
+		result[c.FolderId] = c.Cnt
+	}
+	return result, nil
+}
+
+func (r *postCollectRepository) FindPostIdsByFolder(ctx context.Context, userId, folderId int64, offset, limit int) ([]int64, error) {
+	rels, err := r.dao.FindByFolder(ctx, userId, folderId, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, len(rels))
+	for i, rel := range rels {
+		ids[i] = rel.PostId
+	}
+	return ids, nil
+}
+
+func toCountMap(counts []dao.PostCount) map[int64]int64 {
+	result := make(map[int64]int64, len(counts))
+	for _, c := range counts {
+		result[c.PostId] = c.Cnt
+	}
+	return result
+}
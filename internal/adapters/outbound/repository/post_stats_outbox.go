@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	dao "webook/internal/adapters/outbound/persistence/mysql"
+	"webook/internal/domain"
+	output "webook/internal/ports/output"
+
+	"github.com/google/uuid"
+)
+
+// NewPostStatsOutboxRepository builds a DAO-backed outbox repository.
+func NewPostStatsOutboxRepository(dao *dao.PostStatsOutboxDAO) output.PostStatsOutboxRepository {
+	return &postStatsOutboxRepository{dao: dao}
+}
+
+type postStatsOutboxRepository struct {
+	dao *dao.PostStatsOutboxDAO
+}
+
+func (r *postStatsOutboxRepository) FindPending(ctx context.Context, now int64, limit int) ([]domain.PostStatsOutboxMessage, error) {
+	rows, err := r.dao.FindPending(ctx, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.PostStatsOutboxMessage, len(rows))
+	for i, row := range rows {
+		result[i] = domain.PostStatsOutboxMessage{
+			Id: row.Id,
+			Event: domain.PostStatsEvent{
+				EventId: row.EventId,
+				Type:    domain.PostStatsEventType(row.Type),
+				PostId:  row.PostId,
+				UserId:  row.UserId,
+				Ts:      row.Ts,
+			},
+			Retries: row.Retries,
+		}
+	}
+	return result, nil
+}
+
+func (r *postStatsOutboxRepository) MarkSent(ctx context.Context, ids []int64) error {
+	return r.dao.MarkSent(ctx, ids)
+}
+
+func (r *postStatsOutboxRepository) MarkRetry(ctx context.Context, id int64, nextRetryAt int64, reason string) error {
+	return r.dao.MarkRetry(ctx, id, nextRetryAt, reason)
+}
+
+func (r *postStatsOutboxRepository) MarkFailed(ctx context.Context, id int64, reason string) error {
+	return r.dao.MarkFailed(ctx, id, reason)
+}
+
+func (r *postStatsOutboxRepository) DeleteSentBefore(ctx context.Context, before int64, limit int) (int64, error) {
+	return r.dao.DeleteSentBefore(ctx, before, limit)
+}
+
+// newOutboxEvent creates an outbox row for a fresh stats event.
+func newOutboxEvent(eventType domain.PostStatsEventType, postId, userId int64) dao.PostStatsOutbox {
+	event := domain.NewPostStatsEvent(uuid.NewString(), eventType, postId, userId)
+	return dao.PostStatsOutbox{
+		EventId: event.EventId,
+		Type:    string(event.Type),
+		PostId:  event.PostId,
+		UserId:  event.UserId,
+		Ts:      event.Ts,
+	}
+}
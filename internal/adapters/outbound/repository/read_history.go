@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	dao "webook/internal/adapters/outbound/persistence/mysql"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+
+	"gorm.io/gorm"
+)
+
+// NewReadHistoryRepository builds a DAO-backed reading history repository.
+func NewReadHistoryRepository(dao *dao.ReadHistoryDAO) ports.ReadHistoryRepository {
+	return &readHistoryRepository{dao: dao}
+}
+
+type readHistoryRepository struct {
+	dao *dao.ReadHistoryDAO
+}
+
+func (r *readHistoryRepository) Record(ctx context.Context, h domain.ReadHistory) error {
+	updateProgress := h.Progress != domain.ReadProgressUnknown
+	progress := max(h.Progress, 0)
+	return r.dao.Upsert(ctx, dao.ReadHistory{
+		UserId:   h.UserId,
+		PostId:   h.PostId,
+		Progress: uint8(progress),
+	}, updateProgress)
+}
+
+func (r *readHistoryRepository) FindByUser(ctx context.Context, userId int64, cursor domain.ReadHistoryCursor, limit int, unfinished bool) ([]domain.ReadHistory, error) {
+	histories, err := r.dao.FindByUser(ctx, userId, cursor.ReadAt, cursor.Id, limit, unfinished)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.ReadHistory, len(histories))
+	for i, h := range histories {
+		result[i] = domain.ReadHistory{
+			Id:       h.Id,
+			UserId:   h.UserId,
+			PostId:   h.PostId,
+			Progress: int(h.Progress),
+			ReadAt:   h.Utime,
+		}
+	}
+	return result, nil
+}
+
+func (r *readHistoryRepository) Delete(ctx context.Context, userId, postId int64) error {
+	err := r.dao.Delete(ctx, userId, postId)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrReadHistoryNotFound
+	}
+	return err
+}
+
+func (r *readHistoryRepository) DeleteByUser(ctx context.Context, userId int64) error {
+	return r.dao.DeleteByUser(ctx, userId)
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	dao "webook/internal/adapters/outbound/persistence/mysql"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+
+	"gorm.io/gorm"
+)
+
+// NewSessionRepository builds a DAO-backed session repository.
+func NewSessionRepository(dao *dao.SessionDAO) ports.SessionRepository {
+	return &sessionRepository{dao: dao}
+}
+
+type sessionRepository struct {
+	dao *dao.SessionDAO
+}
+
+func (r *sessionRepository) Upsert(ctx context.Context, s domain.Session) error {
+	return r.dao.Upsert(ctx, dao.UserSession{
+		Ssid:      s.Id,
+		UserId:    s.UserId,
+		UserAgent: s.UserAgent,
+		IP:        s.IP,
+	})
+}
+
+func (r *sessionRepository) FindByUser(ctx context.Context, userId, since int64) ([]domain.Session, error) {
+	sessions, err := r.dao.FindByUser(ctx, userId, since)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.Session, len(sessions))
+	for i, s := range sessions {
+		result[i] = domain.Session{
+			Id:        s.Ssid,
+			UserId:    s.UserId,
+			UserAgent: s.UserAgent,
+			IP:        s.IP,
+			Ctime:     s.Ctime,
+			LastUsed:  s.Utime,
+		}
+	}
+	return result, nil
+}
+
+func (r *sessionRepository) Delete(ctx context.Context, userId int64, id string) error {
+	err := r.dao.Delete(ctx, userId, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrSessionNotFound
+	}
+	return err
+}
+
+func (r *sessionRepository) DeleteByUser(ctx context.Context, userId int64) error {
+	return r.dao.DeleteByUser(ctx, userId)
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	dao "webook/internal/adapters/outbound/persistence/mysql"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+
+	"gorm.io/gorm"
+)
+
+// NewTagRepository builds a DAO-backed tag repository.
+func NewTagRepository(dao *dao.TagDAO) ports.TagRepository {
+	return &tagRepository{dao: dao}
+}
+
+type tagRepository struct {
+	dao *dao.TagDAO
+}
+
+func (r *tagRepository) Create(ctx context.Context, t domain.Tag) (int64, error) {
+	id, err := r.dao.Insert(ctx, dao.Tag{Name: t.Name})
+	if errors.Is(err, dao.ErrDuplicateTag) {
+		return 0, domain.ErrDuplicateTag
+	}
+	return id, err
+}
+
+func (r *tagRepository) Update(ctx context.Context, t domain.Tag) error {
+	err := r.dao.UpdateName(ctx, t.Id, t.Name)
+	switch {
+	case errors.Is(err, dao.ErrDuplicateTag):
+		return domain.ErrDuplicateTag
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return domain.ErrTagNotFound
+	}
+	return err
+}
+
+func (r *tagRepository) Delete(ctx context.Context, id int64) error {
+	err := r.dao.Delete(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrTagNotFound
+	}
+	return err
+}
+
+func (r *tagRepository) FindById(ctx context.Context, id int64) (domain.Tag, error) {
+	t, err := r.dao.FindById(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return domain.Tag{}, domain.ErrTagNotFound
+		}
+		return domain.Tag{}, err
+	}
+	return toDomainTag(t), nil
+}
+
+func (r *tagRepository) ListWithPostCount(ctx context.Context) ([]domain.Tag, error) {
+	tags, err := r.dao.ListWithPostCount(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.Tag, len(tags))
+	for i, t := range tags {
+		result[i] = toDomainTag(t.Tag)
+		result[i].PostCnt = t.PostCnt
+	}
+	return result, nil
+}
+
+func toDomainTag(t dao.Tag) domain.Tag {
+	return domain.Tag{
+		Id:    t.Id,
+		Name:  t.Name,
+		Ctime: t.Ctime,
+		Utime: t.Utime,
+	}
+}
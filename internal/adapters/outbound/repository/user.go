@@ -3,13 +3,18 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
+	dao "webook/internal/adapters/outbound/persistence/mysql"
 	"webook/internal/domain"
 	ports "webook/internal/ports/output"
-	dao "webook/internal/adapters/outbound/persistence/mysql"
 
 	"gorm.io/gorm"
 )
 
+// userCacheRedeleteDelay is how long after an update the cache entry is deleted
+// once more, to drop a stale value written back by a read that raced the update.
+const userCacheRedeleteDelay = time.Second
+
 // NewUserRepository builds a DAO-backed user repository.
 func NewUserRepository(dao *dao.UserDAO) ports.UserRepository {
 	return &userRepository{dao: dao}
@@ -69,6 +74,52 @@ func (r *userRepository) Update(ctx context.Context, u domain.User) error {
 	})
 }
 
+func (r *userRepository) MarkEmailVerified(ctx context.Context, id int64) error {
+	return r.dao.MarkEmailVerified(ctx, id)
+}
+
+func (r *userRepository) UpdateProfile(ctx context.Context, u domain.User) error {
+	return r.dao.UpdateProfile(ctx, dao.User{
+		Id:       u.Id,
+		Nickname: u.Nickname,
+		Avatar:   u.Avatar,
+		Bio:      u.Bio,
+		Birthday: u.Birthday,
+	})
+}
+
+func (r *userRepository) List(ctx context.Context, offset, limit int) ([]domain.User, error) {
+	users, err := r.dao.List(ctx, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]domain.User, len(users))
+	for i, u := range users {
+		result[i] = toDomainUser(u)
+	}
+	return result, nil
+}
+
+func (r *userRepository) Count(ctx context.Context) (int64, error) {
+	return r.dao.Count(ctx)
+}
+
+func (r *userRepository) UpdateStatus(ctx context.Context, id int64, status domain.UserStatus) error {
+	err := r.dao.UpdateStatus(ctx, id, uint8(status))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrUserNotFound
+	}
+	return err
+}
+
+func (r *userRepository) UpdateRole(ctx context.Context, id int64, role domain.Role) error {
+	err := r.dao.UpdateRole(ctx, id, string(role))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.ErrUserNotFound
+	}
+	return err
+}
+
 func (r *cachedUserRepository) Create(ctx context.Context, u domain.User) error {
 	return r.repo.Create(ctx, u)
 }
@@ -88,7 +139,8 @@ func (r *cachedUserRepository) FindById(ctx context.Context, id int64) (domain.U
 		return domain.User{}, err
 	}
 	go func() {
-		_ = r.cache.Set(ctx, u)
+		// the request may be done before the write-back
+		_ = r.cache.Set(context.WithoutCancel(ctx), u)
 	}()
 	return u, nil
 }
@@ -98,13 +150,71 @@ func (r *cachedUserRepository) Update(ctx context.Context, u domain.User) error
 	if err != nil {
 		return err
 	}
-	return r.cache.Delete(ctx, u.Id)
+	return r.invalidate(ctx, u.Id)
+}
+
+func (r *cachedUserRepository) UpdateProfile(ctx context.Context, u domain.User) error {
+	err := r.repo.UpdateProfile(ctx, u)
+	if err != nil {
+		return err
+	}
+	return r.invalidate(ctx, u.Id)
+}
+
+func (r *cachedUserRepository) MarkEmailVerified(ctx context.Context, id int64) error {
+	err := r.repo.MarkEmailVerified(ctx, id)
+	if err != nil {
+		return err
+	}
+	return r.invalidate(ctx, id)
+}
+
+func (r *cachedUserRepository) List(ctx context.Context, offset, limit int) ([]domain.User, error) {
+	return r.repo.List(ctx, offset, limit)
+}
+
+func (r *cachedUserRepository) Count(ctx context.Context) (int64, error) {
+	return r.repo.Count(ctx)
+}
+
+func (r *cachedUserRepository) UpdateStatus(ctx context.Context, id int64, status domain.UserStatus) error {
+	err := r.repo.UpdateStatus(ctx, id, status)
+	if err != nil {
+		return err
+	}
+	return r.invalidate(ctx, id)
+}
+
+func (r *cachedUserRepository) UpdateRole(ctx context.Context, id int64, role domain.Role) error {
+	err := r.repo.UpdateRole(ctx, id, role)
+	if err != nil {
+		return err
+	}
+	return r.invalidate(ctx, id)
+}
+
+// invalidate deletes the cache entry now and again after userCacheRedeleteDelay.
+// A FindById that read the old row before the update may write it back after the
+// first delete; the second one removes it.
+func (r *cachedUserRepository) invalidate(ctx context.Context, id int64) error {
+	time.AfterFunc(userCacheRedeleteDelay, func() {
+		_ = r.cache.Delete(context.Background(), id)
+	})
+	return r.cache.Delete(ctx, id)
 }
 
 func toDomainUser(u dao.User) domain.User {
 	return domain.User{
-		Id:       u.Id,
-		Email:    u.Email,
-		Password: u.Password,
+		Id:            u.Id,
+		Email:         u.Email,
+		Password:      u.Password,
+		EmailVerified: u.EmailVerified,
+		Nickname:      u.Nickname,
+		Avatar:        u.Avatar,
+		Bio:           u.Bio,
+		Birthday:      u.Birthday,
+		Role:          domain.Role(u.Role),
+		Status:        domain.UserStatus(u.Status),
+		Ctime:         u.Ctime,
 	}
 }
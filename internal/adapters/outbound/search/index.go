@@ -0,0 +1,324 @@
+package search
+
+import (
+	"context"
+	"html"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+	"webook/internal/domain"
+	ports "webook/internal/ports/output"
+)
+
+const (
+	// BM25 parameters.
+	bm25K1 = 1.2
+	bm25B  = 0.75
+	// Title terms count this many times towards term frequency.
+	titleWeight = 3
+	// Number of runes shown around the first hit in a snippet.
+	snippetRunes = 120
+)
+
+// InvertedIndex is an embedded, in-process full-text index.
+// It is safe for concurrent use; every instance of the app keeps its own copy.
+type InvertedIndex struct {
+	mu       sync.RWMutex
+	docs     map[int64]*document
+	postings map[string]map[int64]int // term -> post id -> weighted term frequency
+	totalLen int
+
+	// rebuildMu serializes rebuilds. While one is loading, journal records the
+	// Index and Remove calls so they can be replayed on the fresh index.
+	rebuildMu sync.Mutex
+	journal   []change
+}
+
+// change is an Index (doc != nil) or Remove (doc == nil) made during a rebuild.
+type change struct {
+	id  int64
+	doc *document
+}
+
+type document struct {
+	post   domain.Post
+	terms  map[string]int
+	length int
+}
+
+func NewInvertedIndex() ports.SearchService {
+	return &InvertedIndex{
+		docs:     make(map[int64]*document),
+		postings: make(map[string]map[int64]int),
+	}
+}
+
+func (idx *InvertedIndex) Index(ctx context.Context, p domain.Post) error {
+	doc := newDocument(p)
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.removeLocked(p.Id)
+	idx.addLocked(doc)
+	if idx.journal != nil {
+		idx.journal = append(idx.journal, change{id: p.Id, doc: doc})
+	}
+	return nil
+}
+
+func (idx *InvertedIndex) Remove(ctx context.Context, postId int64) error {
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	idx.removeLocked(postId)
+	if idx.journal != nil {
+		idx.journal = append(idx.journal, change{id: postId})
+	}
+	return nil
+}
+
+func (idx *InvertedIndex) Rebuild(ctx context.Context, load func(ctx context.Context) ([]domain.Post, error)) error {
+	idx.rebuildMu.Lock()
+	defer idx.rebuildMu.Unlock()
+
+	idx.mu.Lock()
+	idx.journal = []change{}
+	idx.mu.Unlock()
+
+	// Load and build outside the lock so searches keep being served meanwhile.
+	posts, err := load(ctx)
+	if err != nil {
+		idx.mu.Lock()
+		idx.journal = nil
+		idx.mu.Unlock()
+		return err
+	}
+	fresh := &InvertedIndex{
+		docs:     make(map[int64]*document, len(posts)),
+		postings: make(map[string]map[int64]int),
+	}
+	for _, p := range posts {
+		fresh.addLocked(newDocument(p))
+	}
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	// The load may have read a post before a concurrent change to it; the
+	// journaled change is at least as new, so it wins.
+	for _, c := range idx.journal {
+		fresh.removeLocked(c.id)
+		if c.doc != nil {
+			fresh.addLocked(c.doc)
+		}
+	}
+	idx.journal = nil
+	idx.docs = fresh.docs
+	idx.postings = fresh.postings
+	idx.totalLen = fresh.totalLen
+	return nil
+}
+
+func (idx *InvertedIndex) Search(ctx context.Context, query string, limit int) ([]domain.PostSearchHit, int64, error) {
+	terms := queryTerms(query)
+	if len(terms) == 0 || limit <= 0 {
+		return []domain.PostSearchHit{}, 0, nil
+	}
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	// Start from the rarest term so the candidate set is as small as possible.
+	lists := make([]map[int64]int, len(terms))
+	for i, t := range terms {
+		lists[i] = idx.postings[t]
+		if len(lists[i]) == 0 {
+			return []domain.PostSearchHit{}, 0, nil
+		}
+	}
+	sort.Slice(lists, func(i, j int) bool { return len(lists[i]) < len(lists[j]) })
+
+	n := float64(len(idx.docs))
+	avgLen := float64(idx.totalLen) / n
+	type scored struct {
+		doc   *document
+		score float64
+	}
+	var matches []scored
+	for id := range lists[0] {
+		score := 0.0
+		matched := true
+		for _, l := range lists {
+			tf, ok := l[id]
+			if !ok {
+				matched = false
+				break
+			}
+			doc := idx.docs[id]
+			df := float64(len(l))
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			norm := bm25K1 * (1 - bm25B + bm25B*float64(doc.length)/avgLen)
+			score += idf * float64(tf) * (bm25K1 + 1) / (float64(tf) + norm)
+		}
+		if matched {
+			matches = append(matches, scored{doc: idx.docs[id], score: score})
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		}
+		return matches[i].doc.post.Utime > matches[j].doc.post.Utime
+	})
+
+	total := int64(len(matches))
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	hits := make([]domain.PostSearchHit, len(matches))
+	for i, m := range matches {
+		p := m.doc.post
+		hits[i] = domain.PostSearchHit{
+			PostId:   p.Id,
+			AuthorId: p.AuthorId,
+			Title:    highlight([]rune(p.Title), terms, 0, utf8.RuneCountInString(p.Title)),
+			Snippet:  snippet(p.Content, terms),
+			Utime:    p.Utime,
+			Score:    m.score,
+		}
+	}
+	return hits, total, nil
+}
+
+func newDocument(p domain.Post) *document {
+	terms := make(map[string]int)
+	length := 0
+	for _, t := range tokenize(p.Title) {
+		terms[t] += titleWeight
+		length += titleWeight
+	}
+	for _, t := range tokenize(p.Content) {
+		terms[t]++
+		length++
+	}
+	return &document{post: p, terms: terms, length: length}
+}
+
+func (idx *InvertedIndex) addLocked(doc *document) {
+	idx.docs[doc.post.Id] = doc
+	idx.totalLen += doc.length
+	for t, tf := range doc.terms {
+		l, ok := idx.postings[t]
+		if !ok {
+			l = make(map[int64]int)
+			idx.postings[t] = l
+		}
+		l[doc.post.Id] = tf
+	}
+}
+
+func (idx *InvertedIndex) removeLocked(id int64) {
+	doc, ok := idx.docs[id]
+	if !ok {
+		return
+	}
+	for t := range doc.terms {
+		l := idx.postings[t]
+		delete(l, id)
+		if len(l) == 0 {
+			delete(idx.postings, t)
+		}
+	}
+	idx.totalLen -= doc.length
+	delete(idx.docs, id)
+}
+
+// snippet cuts a window of content around the first hit and highlights it.
+func snippet(content string, terms []string) string {
+	runes := []rune(content)
+	lower := []rune(strings.ToLower(content))
+	first := -1
+	if len(lower) == len(runes) {
+		if ranges := findRanges(lower, terms); len(ranges) > 0 {
+			first = ranges[0][0]
+		}
+	}
+	start := 0
+	if first > snippetRunes/4 {
+		start = first - snippetRunes/4
+	}
+	end := min(start+snippetRunes, len(runes))
+
+	out := highlight(runes, terms, start, end)
+	if start > 0 {
+		out = "..." + out
+	}
+	if end < len(runes) {
+		out += "..."
+	}
+	return out
+}
+
+// highlight escapes runes[start:end] and wraps every query term in <em>.
+func highlight(runes []rune, terms []string, start, end int) string {
+	lower := []rune(strings.ToLower(string(runes)))
+	if len(lower) != len(runes) {
+		// Lower-casing changed the rune count; fall back to no highlighting.
+		return html.EscapeString(string(runes[start:end]))
+	}
+	var b strings.Builder
+	pos := start
+	for _, r := range findRanges(lower, terms) {
+		s, e := max(r[0], pos), min(r[1], end)
+		if s >= e {
+			continue
+		}
+		b.WriteString(html.EscapeString(string(runes[pos:s])))
+		b.WriteString("<em>")
+		b.WriteString(html.EscapeString(string(runes[s:e])))
+		b.WriteString("</em>")
+		pos = e
+	}
+	if pos < end {
+		b.WriteString(html.EscapeString(string(runes[pos:end])))
+	}
+	return b.String()
+}
+
+// findRanges returns the sorted, merged [start, end) rune ranges where any term occurs.
+// Latin terms only match whole words so that "go" does not light up "google".
+func findRanges(text []rune, terms []string) [][2]int {
+	var ranges [][2]int
+	for _, t := range terms {
+		tr := []rune(t)
+		word := isWordRune(tr[0])
+		for i := 0; i+len(tr) <= len(text); i++ {
+			if !hasPrefix(text[i:], tr) {
+				continue
+			}
+			if word && ((i > 0 && isWordRune(text[i-1])) || (i+len(tr) < len(text) && isWordRune(text[i+len(tr)]))) {
+				continue
+			}
+			ranges = append(ranges, [2]int{i, i + len(tr)})
+		}
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+	merged := ranges[:0]
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r[0] <= merged[n-1][1] {
+			merged[n-1][1] = max(merged[n-1][1], r[1])
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+func hasPrefix(text, prefix []rune) bool {
+	for i, r := range prefix {
+		if text[i] != r {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,131 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"webook/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func searchIds(t *testing.T, idx *InvertedIndex, query string) []int64 {
+	hits, total, err := idx.Search(context.Background(), query, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(hits)), total)
+	ids := make([]int64, len(hits))
+	for i, h := range hits {
+		ids[i] = h.PostId
+	}
+	return ids
+}
+
+func newTestIndex(posts ...domain.Post) *InvertedIndex {
+	idx := NewInvertedIndex().(*InvertedIndex)
+	for _, p := range posts {
+		_ = idx.Index(context.Background(), p)
+	}
+	return idx
+}
+
+func TestInvertedIndex_Search(t *testing.T) {
+	idx := newTestIndex(
+		domain.Post{Id: 1, Title: "Redis 分布式锁", Content: "用 SET NX 实现", Utime: 100},
+		domain.Post{Id: 2, Title: "MySQL 索引", Content: "分布式事务和 redis 缓存", Utime: 200},
+		domain.Post{Id: 3, Title: "Go 并发", Content: "goroutine 与 channel", Utime: 300},
+	)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []int64
+	}{
+		{name: "标题命中的得分更高", query: "redis", want: []int64{1, 2}},
+		{name: "所有词都要命中", query: "分布式 缓存", want: []int64{2}},
+		{name: "英文按整词匹配", query: "go", want: []int64{3}},
+		{name: "没有命中", query: "kafka", want: []int64{}},
+		{name: "空查询", query: "", want: []int64{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, searchIds(t, idx, tt.query))
+		})
+	}
+}
+
+func TestInvertedIndex_Highlight(t *testing.T) {
+	idx := newTestIndex(domain.Post{Id: 1, Title: "Go <泛型>", Content: "google 不是 Go"})
+
+	hits, _, err := idx.Search(context.Background(), "go", 10)
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	assert.Equal(t, "<em>Go</em> &lt;泛型&gt;", hits[0].Title)
+	assert.Equal(t, "google 不是 <em>Go</em>", hits[0].Snippet)
+}
+
+func TestInvertedIndex_IndexAndRemove(t *testing.T) {
+	ctx := context.Background()
+	idx := newTestIndex(domain.Post{Id: 1, Title: "旧标题"})
+
+	// 重新索引同一篇帖子替换旧内容
+	require.NoError(t, idx.Index(ctx, domain.Post{Id: 1, Title: "新标题"}))
+	assert.Empty(t, searchIds(t, idx, "旧"))
+	assert.Equal(t, []int64{1}, searchIds(t, idx, "新"))
+
+	require.NoError(t, idx.Remove(ctx, 1))
+	assert.Empty(t, searchIds(t, idx, "标题"))
+	assert.Empty(t, idx.postings)
+	assert.Zero(t, idx.totalLen)
+}
+
+func TestInvertedIndex_Rebuild(t *testing.T) {
+	tests := []struct {
+		name string
+		// during 在加载期间对索引做的修改
+		during  func(idx *InvertedIndex)
+		loadErr error
+		want    map[string][]int64
+	}{
+		{
+			name: "替换为加载的帖子",
+			want: map[string][]int64{"旧": {}, "加载": {2, 3}},
+		},
+		{
+			name: "加载期间的发布和隐藏在新索引上重放",
+			during: func(idx *InvertedIndex) {
+				_ = idx.Index(context.Background(), domain.Post{Id: 4, Title: "新发布"})
+				_ = idx.Index(context.Background(), domain.Post{Id: 2, Title: "修改后"})
+				_ = idx.Remove(context.Background(), 3)
+			},
+			want: map[string][]int64{"新发布": {4}, "修改后": {2}, "加载": {}},
+		},
+		{
+			name: "加载失败-保留原索引",
+			during: func(idx *InvertedIndex) {
+				_ = idx.Index(context.Background(), domain.Post{Id: 4, Title: "新发布"})
+			},
+			loadErr: errors.New("db error"),
+			want:    map[string][]int64{"旧": {1}, "新发布": {4}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idx := newTestIndex(domain.Post{Id: 1, Title: "旧帖子"})
+
+			err := idx.Rebuild(context.Background(), func(ctx context.Context) ([]domain.Post, error) {
+				if tt.during != nil {
+					tt.during(idx)
+				}
+				return []domain.Post{{Id: 2, Title: "加载的帖子"}, {Id: 3, Title: "加载的另一篇"}}, tt.loadErr
+			})
+			assert.ErrorIs(t, err, tt.loadErr)
+			assert.Nil(t, idx.journal)
+			for query, want := range tt.want {
+				assert.ElementsMatch(t, want, searchIds(t, idx, query), query)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// tokenize splits text into index terms.
+// Latin letters and digits form lower-cased words; runs of Han characters are
+// indexed as both unigrams and bigrams so that single-character and phrase
+// queries both match without a dictionary.
+func tokenize(text string) []string {
+	var terms []string
+	forEachSegment(text, func(seg []rune, han bool) {
+		if !han {
+			terms = append(terms, string(seg))
+			return
+		}
+		for i := range seg {
+			terms = append(terms, string(seg[i]))
+			if i+1 < len(seg) {
+				terms = append(terms, string(seg[i:i+2]))
+			}
+		}
+	})
+	return terms
+}
+
+// queryTerms splits a query into the terms that must all match.
+// A Han run longer than one character is matched by its bigrams only.
+func queryTerms(query string) []string {
+	seen := make(map[string]struct{})
+	var terms []string
+	add := func(t string) {
+		if _, ok := seen[t]; ok {
+			return
+		}
+		seen[t] = struct{}{}
+		terms = append(terms, t)
+	}
+	forEachSegment(query, func(seg []rune, han bool) {
+		if !han || len(seg) == 1 {
+			add(string(seg))
+			return
+		}
+		for i := 0; i+1 < len(seg); i++ {
+			add(string(seg[i : i+2]))
+		}
+	})
+	return terms
+}
+
+// forEachSegment walks text and yields maximal runs of Han characters or of
+// lower-cased letters/digits. Everything else is treated as a separator.
+func forEachSegment(text string, fn func(seg []rune, han bool)) {
+	var (
+		cur    []rune
+		curHan bool
+	)
+	flush := func() {
+		if len(cur) > 0 {
+			fn(cur, curHan)
+			cur = nil
+		}
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.Is(unicode.Han, r):
+			if !curHan {
+				flush()
+			}
+			curHan = true
+			cur = append(cur, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if curHan {
+				flush()
+			}
+			curHan = false
+			cur = append(cur, r)
+		default:
+			flush()
+		}
+	}
+	flush()
+}
+
+func isWordRune(r rune) bool {
+	return !unicode.Is(unicode.Han, r) && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
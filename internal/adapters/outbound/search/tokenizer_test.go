@@ -0,0 +1,77 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "英文转小写并按非字母数字切分",
+			text: "Go-Redis, MySQL8!",
+			want: []string{"go", "redis", "mysql8"},
+		},
+		{
+			name: "中文同时索引单字和双字",
+			text: "分布式",
+			want: []string{"分", "分布", "布", "布式", "式"},
+		},
+		{
+			name: "中英文混排",
+			text: "学习Go语言",
+			want: []string{"学", "学习", "习", "go", "语", "语言", "言"},
+		},
+		{
+			name: "只有标点",
+			text: "，。!?",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tokenize(tt.text))
+		})
+	}
+}
+
+func TestQueryTerms(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{
+			name:  "中文词组只按双字匹配",
+			query: "分布式",
+			want:  []string{"分布", "布式"},
+		},
+		{
+			name:  "单个汉字",
+			query: "锁",
+			want:  []string{"锁"},
+		},
+		{
+			name:  "重复的词只保留一次",
+			query: "go Go GO",
+			want:  []string{"go"},
+		},
+		{
+			name:  "空查询",
+			query: "  ",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, queryTerms(tt.query))
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+	"webook/internal/domain"
+	input "webook/internal/ports/input"
+	output "webook/internal/ports/output"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// AccountOptions 账号邮件的配置
+type AccountOptions struct {
+	LinkBaseURL    string        // 邮件中链接指向的前端地址，如 https://webook.example.com
+	VerifyTokenTTL time.Duration // 邮箱验证令牌有效期
+	ResetTokenTTL  time.Duration // 重置密码令牌有效期
+	MailInterval   time.Duration // 同一用户同一类邮件的最小发送间隔
+	SessionTTL     time.Duration // refresh token 有效期，重置密码时吊销的时长需覆盖它
+}
+
+type accountService struct {
+	repo      output.UserRepository
+	tokens    output.AccountTokenStore
+	mailer    output.Mailer
+	blacklist output.TokenBlacklist
+	opts      AccountOptions
+}
+
+func NewAccountService(repo output.UserRepository, tokens output.AccountTokenStore, mailer output.Mailer,
+	blacklist output.TokenBlacklist, opts AccountOptions) input.AccountService {
+	return &accountService{
+		repo:      repo,
+		tokens:    tokens,
+		mailer:    mailer,
+		blacklist: blacklist,
+		opts:      opts,
+	}
+}
+
+func (s *accountService) SendVerification(ctx context.Context, email string) error {
+	u, err := s.repo.FindByEmail(ctx, email)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if u.EmailVerified {
+		return nil
+	}
+	link, err := s.issue(ctx, domain.AccountTokenVerifyEmail, u.Id, s.opts.VerifyTokenTTL, "/verify-email")
+	if err != nil || link == "" {
+		return err
+	}
+	return s.mailer.Send(ctx, domain.Mail{
+		To:      u.Email,
+		Subject: "验证你的 Webook 邮箱",
+		Body: fmt.Sprintf("你好，\n\n请在 %s 内打开下面的链接完成邮箱验证：\n%s\n\n如果这不是你的操作，请忽略本邮件。\n",
+			formatTTL(s.opts.VerifyTokenTTL), link),
+	})
+}
+
+func (s *accountService) VerifyEmail(ctx context.Context, token string) error {
+	userId, err := s.tokens.Consume(ctx, domain.AccountTokenVerifyEmail, token)
+	if err != nil {
+		return err
+	}
+	return s.repo.MarkEmailVerified(ctx, userId)
+}
+
+func (s *accountService) ForgotPassword(ctx context.Context, email string) error {
+	u, err := s.repo.FindByEmail(ctx, email)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	link, err := s.issue(ctx, domain.AccountTokenResetPassword, u.Id, s.opts.ResetTokenTTL, "/reset-password")
+	if err != nil || link == "" {
+		return err
+	}
+	return s.mailer.Send(ctx, domain.Mail{
+		To:      u.Email,
+		Subject: "重置你的 Webook 密码",
+		Body: fmt.Sprintf("你好，\n\n请在 %s 内打开下面的链接设置新密码，链接只能使用一次：\n%s\n\n如果这不是你的操作，请忽略本邮件，你的密码不会改变。\n",
+			formatTTL(s.opts.ResetTokenTTL), link),
+	})
+}
+
+func (s *accountService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	userId, err := s.tokens.Consume(ctx, domain.AccountTokenResetPassword, token)
+	if err != nil {
+		return err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	if err = s.repo.Update(ctx, domain.User{Id: userId, Password: string(hash)}); err != nil {
+		return err
+	}
+	// 密码可能已经泄露，让所有已登录的设备重新登录
+	return s.blacklist.RevokeUser(ctx, userId, s.opts.SessionTTL)
+}
+
+// issue 生成并保存令牌，返回邮件中的链接；发送过于频繁时返回空链接
+func (s *accountService) issue(ctx context.Context, purpose domain.AccountTokenPurpose, userId int64, ttl time.Duration, path string) (string, error) {
+	ok, err := s.tokens.Throttle(ctx, purpose, userId, s.opts.MailInterval)
+	if err != nil || !ok {
+		return "", err
+	}
+	token, err := newAccountToken()
+	if err != nil {
+		return "", err
+	}
+	if err = s.tokens.Save(ctx, purpose, token, userId, ttl); err != nil {
+		return "", err
+	}
+	return s.opts.LinkBaseURL + path + "?token=" + url.QueryEscape(token), nil
+}
+
+// newAccountToken 生成 256 位随机令牌
+func newAccountToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func formatTTL(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		return fmt.Sprintf("%d 小时", int(d/time.Hour))
+	}
+	return fmt.Sprintf("%d 分钟", int(d/time.Minute))
+}
@@ -0,0 +1,222 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+	repomocks "webook/internal/adapters/outbound/mocks"
+	"webook/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type accountMocks struct {
+	repo      *repomocks.MockUserRepository
+	tokens    *repomocks.MockAccountTokenStore
+	mailer    *repomocks.MockMailer
+	blacklist *repomocks.MockTokenBlacklist
+}
+
+func newAccountMocks(ctrl *gomock.Controller) accountMocks {
+	return accountMocks{
+		repo:      repomocks.NewMockUserRepository(ctrl),
+		tokens:    repomocks.NewMockAccountTokenStore(ctrl),
+		mailer:    repomocks.NewMockMailer(ctrl),
+		blacklist: repomocks.NewMockTokenBlacklist(ctrl),
+	}
+}
+
+func (m accountMocks) service() *accountService {
+	return NewAccountService(m.repo, m.tokens, m.mailer, m.blacklist, AccountOptions{
+		LinkBaseURL:    "https://webook.test",
+		VerifyTokenTTL: 24 * time.Hour,
+		ResetTokenTTL:  30 * time.Minute,
+		MailInterval:   time.Minute,
+		SessionTTL:     7 * 24 * time.Hour,
+	}).(*accountService)
+}
+
+func TestAccountService_SendVerification(t *testing.T) {
+	tests := []struct {
+		name string
+		mock func(m accountMocks)
+	}{
+		{
+			name: "发送验证邮件",
+			mock: func(m accountMocks) {
+				m.repo.EXPECT().FindByEmail(gomock.Any(), "a@test.com").Return(domain.User{Id: 1, Email: "a@test.com"}, nil)
+				m.tokens.EXPECT().Throttle(gomock.Any(), domain.AccountTokenVerifyEmail, int64(1), time.Minute).Return(true, nil)
+				var saved string
+				m.tokens.EXPECT().Save(gomock.Any(), domain.AccountTokenVerifyEmail, gomock.Any(), int64(1), 24*time.Hour).
+					DoAndReturn(func(_ context.Context, _ domain.AccountTokenPurpose, token string, _ int64, _ time.Duration) error {
+						saved = token
+						return nil
+					})
+				m.mailer.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, mail domain.Mail) error {
+					assert.Equal(t, "a@test.com", mail.To)
+					assert.Contains(t, mail.Body, "https://webook.test/verify-email?token="+saved)
+					return nil
+				})
+			},
+		},
+		{
+			name: "已验证-不发送",
+			mock: func(m accountMocks) {
+				m.repo.EXPECT().FindByEmail(gomock.Any(), "a@test.com").Return(domain.User{Id: 1, EmailVerified: true}, nil)
+			},
+		},
+		{
+			name: "发送过于频繁-不发送",
+			mock: func(m accountMocks) {
+				m.repo.EXPECT().FindByEmail(gomock.Any(), "a@test.com").Return(domain.User{Id: 1}, nil)
+				m.tokens.EXPECT().Throttle(gomock.Any(), domain.AccountTokenVerifyEmail, int64(1), time.Minute).Return(false, nil)
+			},
+		},
+		{
+			name: "邮箱未注册-不发送",
+			mock: func(m accountMocks) {
+				m.repo.EXPECT().FindByEmail(gomock.Any(), "a@test.com").Return(domain.User{}, domain.ErrUserNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newAccountMocks(ctrl)
+			tt.mock(m)
+
+			err := m.service().SendVerification(context.Background(), "a@test.com")
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAccountService_VerifyEmail(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(m accountMocks)
+		wantErr error
+	}{
+		{
+			name: "验证成功",
+			mock: func(m accountMocks) {
+				m.tokens.EXPECT().Consume(gomock.Any(), domain.AccountTokenVerifyEmail, "tok").Return(int64(1), nil)
+				m.repo.EXPECT().MarkEmailVerified(gomock.Any(), int64(1)).Return(nil)
+			},
+		},
+		{
+			name: "令牌无效或已使用",
+			mock: func(m accountMocks) {
+				m.tokens.EXPECT().Consume(gomock.Any(), domain.AccountTokenVerifyEmail, "tok").Return(int64(0), domain.ErrInvalidAccountToken)
+			},
+			wantErr: domain.ErrInvalidAccountToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newAccountMocks(ctrl)
+			tt.mock(m)
+
+			err := m.service().VerifyEmail(context.Background(), "tok")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAccountService_ForgotPassword(t *testing.T) {
+	tests := []struct {
+		name string
+		mock func(m accountMocks)
+	}{
+		{
+			name: "发送重置链接",
+			mock: func(m accountMocks) {
+				m.repo.EXPECT().FindByEmail(gomock.Any(), "a@test.com").Return(domain.User{Id: 1, Email: "a@test.com"}, nil)
+				m.tokens.EXPECT().Throttle(gomock.Any(), domain.AccountTokenResetPassword, int64(1), time.Minute).Return(true, nil)
+				m.tokens.EXPECT().Save(gomock.Any(), domain.AccountTokenResetPassword, gomock.Any(), int64(1), 30*time.Minute).Return(nil)
+				m.mailer.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, mail domain.Mail) error {
+					assert.True(t, strings.Contains(mail.Body, "https://webook.test/reset-password?token="))
+					return nil
+				})
+			},
+		},
+		{
+			name: "邮箱未注册-同样返回成功",
+			mock: func(m accountMocks) {
+				m.repo.EXPECT().FindByEmail(gomock.Any(), "a@test.com").Return(domain.User{}, domain.ErrUserNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newAccountMocks(ctrl)
+			tt.mock(m)
+
+			err := m.service().ForgotPassword(context.Background(), "a@test.com")
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAccountService_ResetPassword(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(m accountMocks)
+		wantErr error
+	}{
+		{
+			name: "重置密码并吊销所有会话",
+			mock: func(m accountMocks) {
+				m.tokens.EXPECT().Consume(gomock.Any(), domain.AccountTokenResetPassword, "tok").Return(int64(1), nil)
+				m.repo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, u domain.User) error {
+					assert.Equal(t, int64(1), u.Id)
+					assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(u.Password), []byte("newpass123")))
+					return nil
+				})
+				m.blacklist.EXPECT().RevokeUser(gomock.Any(), int64(1), 7*24*time.Hour).Return(nil)
+			},
+		},
+		{
+			name: "令牌无效或已使用",
+			mock: func(m accountMocks) {
+				m.tokens.EXPECT().Consume(gomock.Any(), domain.AccountTokenResetPassword, "tok").Return(int64(0), domain.ErrInvalidAccountToken)
+			},
+			wantErr: domain.ErrInvalidAccountToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newAccountMocks(ctrl)
+			tt.mock(m)
+
+			err := m.service().ResetPassword(context.Background(), "tok", "newpass123")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
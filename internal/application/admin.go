@@ -0,0 +1,74 @@
+package application
+
+import (
+	"context"
+	"webook/internal/domain"
+	input "webook/internal/ports/input"
+	output "webook/internal/ports/output"
+)
+
+type adminService struct {
+	users  output.UserRepository
+	posts  output.PostRepository
+	auth   input.AuthService
+	tokens output.PersonalAccessTokenRepository
+}
+
+func NewAdminService(users output.UserRepository, posts output.PostRepository, auth input.AuthService,
+	tokens output.PersonalAccessTokenRepository) input.AdminService {
+	return &adminService{
+		users:  users,
+		posts:  posts,
+		auth:   auth,
+		tokens: tokens,
+	}
+}
+
+func (s *adminService) ListUsers(ctx context.Context, page, pageSize int) ([]domain.User, int64, error) {
+	offset := (page - 1) * pageSize
+	users, err := s.users.List(ctx, offset, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.users.Count(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	return users, total, nil
+}
+
+func (s *adminService) SetUserStatus(ctx context.Context, uid int64, status domain.UserStatus) error {
+	if !domain.ValidUserStatus(status) {
+		return domain.ErrInvalidUserStatus
+	}
+	// 先改状态挡住新的登录，再注销已有的会话
+	if err := s.users.UpdateStatus(ctx, uid, status); err != nil {
+		return err
+	}
+	if status.Active() {
+		return nil
+	}
+	if err := s.auth.LogoutAll(ctx, uid); err != nil {
+		return err
+	}
+	return s.tokens.DeleteByUser(ctx, uid)
+}
+
+func (s *adminService) SetUserRole(ctx context.Context, uid int64, role domain.Role) error {
+	if !domain.ValidRole(role) {
+		return domain.ErrInvalidRole
+	}
+	return s.users.UpdateRole(ctx, uid, role)
+}
+
+func (s *adminService) HidePost(ctx context.Context, postId int64) error {
+	p, err := s.posts.FindById(ctx, postId)
+	if err != nil {
+		return err
+	}
+	return s.posts.SyncStatus(ctx, postId, p.AuthorId, domain.PostStatusHidden)
+}
+
+func (s *adminService) UnhidePost(ctx context.Context, postId int64) error {
+	return s.posts.Unhide(ctx, postId)
+}
@@ -0,0 +1,167 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+	repomocks "webook/internal/adapters/outbound/mocks"
+	"webook/internal/domain"
+	input "webook/internal/ports/input"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type adminMocks struct {
+	users     *repomocks.MockUserRepository
+	posts     *repomocks.MockPostRepository
+	jwt       *repomocks.MockTokenService
+	blacklist *repomocks.MockTokenBlacklist
+	sessions  *repomocks.MockSessionRepository
+	tokens    *repomocks.MockPersonalAccessTokenRepository
+}
+
+func newAdminMocks(ctrl *gomock.Controller) adminMocks {
+	return adminMocks{
+		users:     repomocks.NewMockUserRepository(ctrl),
+		posts:     repomocks.NewMockPostRepository(ctrl),
+		jwt:       repomocks.NewMockTokenService(ctrl),
+		blacklist: repomocks.NewMockTokenBlacklist(ctrl),
+		sessions:  repomocks.NewMockSessionRepository(ctrl),
+		tokens:    repomocks.NewMockPersonalAccessTokenRepository(ctrl),
+	}
+}
+
+// adminService 使用真实的 AuthService，检查封禁时注销会话的完整调用
+func (m adminMocks) adminService() input.AdminService {
+	auth := NewAuthService(m.jwt, m.blacklist, m.sessions, 30*time.Minute, refreshExpire)
+	return NewAdminService(m.users, m.posts, auth, m.tokens)
+}
+
+func TestAdminService_SetUserStatus(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  domain.UserStatus
+		mock    func(m adminMocks)
+		wantErr error
+	}{
+		{
+			name:   "封禁-注销全部会话并删除个人访问令牌",
+			status: domain.UserStatusBanned,
+			mock: func(m adminMocks) {
+				m.users.EXPECT().UpdateStatus(gomock.Any(), int64(2), domain.UserStatusBanned).Return(nil)
+				m.blacklist.EXPECT().RevokeUser(gomock.Any(), int64(2), refreshExpire).Return(nil)
+				m.sessions.EXPECT().DeleteByUser(gomock.Any(), int64(2)).Return(nil)
+				m.tokens.EXPECT().DeleteByUser(gomock.Any(), int64(2)).Return(nil)
+			},
+		},
+		{
+			name:   "恢复-不注销会话",
+			status: domain.UserStatusActive,
+			mock: func(m adminMocks) {
+				m.users.EXPECT().UpdateStatus(gomock.Any(), int64(2), domain.UserStatusActive).Return(nil)
+			},
+		},
+		{
+			name:   "用户不存在",
+			status: domain.UserStatusDisabled,
+			mock: func(m adminMocks) {
+				m.users.EXPECT().UpdateStatus(gomock.Any(), int64(2), domain.UserStatusDisabled).Return(domain.ErrUserNotFound)
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+		{
+			name:    "未知状态",
+			status:  domain.UserStatus(9),
+			wantErr: domain.ErrInvalidUserStatus,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := newAdminMocks(ctrl)
+			if tc.mock != nil {
+				tc.mock(m)
+			}
+
+			err := m.adminService().SetUserStatus(context.Background(), 2, tc.status)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestAdminService_HidePost(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(m adminMocks)
+		wantErr error
+	}{
+		{
+			name: "隐藏他人的帖子",
+			mock: func(m adminMocks) {
+				m.posts.EXPECT().FindById(gomock.Any(), int64(10)).Return(domain.Post{Id: 10, AuthorId: 7}, nil)
+				m.posts.EXPECT().SyncStatus(gomock.Any(), int64(10), int64(7), domain.PostStatusHidden).Return(nil)
+			},
+		},
+		{
+			name: "帖子不存在",
+			mock: func(m adminMocks) {
+				m.posts.EXPECT().FindById(gomock.Any(), int64(10)).Return(domain.Post{}, domain.ErrPostNotFound)
+			},
+			wantErr: domain.ErrPostNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			m := newAdminMocks(ctrl)
+			tc.mock(m)
+
+			err := m.adminService().HidePost(context.Background(), 10)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+// 隐藏之后作者不能重新发布、定时发布或改回仅自己可见，只有管理员能解除
+func TestAdminService_HiddenPostCannotBeRepublished(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := newAdminMocks(ctrl)
+	posts := NewPostService(m.posts, repomocks.NewMockPublishedPostRepository(ctrl), m.users)
+	ctx := context.Background()
+
+	m.posts.EXPECT().FindById(gomock.Any(), int64(10)).Return(domain.Post{Id: 10, AuthorId: 7}, nil)
+	m.posts.EXPECT().SyncStatus(gomock.Any(), int64(10), int64(7), domain.PostStatusHidden).Return(nil)
+	assert.NoError(t, m.adminService().HidePost(ctx, 10))
+
+	m.users.EXPECT().FindById(gomock.Any(), int64(7)).Return(domain.User{Id: 7, EmailVerified: true}, nil).Times(2)
+	m.posts.EXPECT().Sync(gomock.Any(), gomock.Any()).Return(int64(0), domain.ErrPostHidden)
+	m.posts.EXPECT().Schedule(gomock.Any(), int64(10), int64(7), gomock.Any()).Return(domain.ErrPostHidden)
+	m.posts.EXPECT().SyncStatus(gomock.Any(), int64(10), int64(7), domain.PostStatusPrivate).Return(domain.ErrPostHidden)
+
+	_, err := posts.Publish(ctx, domain.Post{Id: 10, AuthorId: 7, Title: "标题"})
+	assert.ErrorIs(t, err, domain.ErrPostHidden)
+	err = posts.Schedule(ctx, 10, 7, time.Now().Add(time.Hour).UnixMilli())
+	assert.ErrorIs(t, err, domain.ErrPostHidden)
+	assert.ErrorIs(t, posts.Delete(ctx, 10, 7), domain.ErrPostHidden)
+
+	m.posts.EXPECT().Unhide(gomock.Any(), int64(10)).Return(nil)
+	assert.NoError(t, m.adminService().UnhidePost(ctx, 10))
+	m.posts.EXPECT().Unhide(gomock.Any(), int64(10)).Return(domain.ErrPostNotHidden)
+	assert.ErrorIs(t, m.adminService().UnhidePost(ctx, 10), domain.ErrPostNotHidden)
+}
+
+func TestAdminService_SetUserRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	m := newAdminMocks(ctrl)
+
+	m.users.EXPECT().UpdateRole(gomock.Any(), int64(2), domain.RoleModerator).Return(nil)
+	assert.NoError(t, m.adminService().SetUserRole(context.Background(), 2, domain.RoleModerator))
+	assert.ErrorIs(t, m.adminService().SetUserRole(context.Background(), 2, "root"), domain.ErrInvalidRole)
+}
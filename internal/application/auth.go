@@ -13,36 +13,70 @@ import (
 type authService struct {
 	tokens        output.TokenService
 	blacklist     output.TokenBlacklist
+	sessions      output.SessionRepository
 	accessExpire  time.Duration
 	refreshExpire time.Duration
 }
 
-func NewAuthService(tokens output.TokenService, blacklist output.TokenBlacklist, accessExpire, refreshExpire time.Duration) input.AuthService {
+func NewAuthService(tokens output.TokenService, blacklist output.TokenBlacklist, sessions output.SessionRepository,
+	accessExpire, refreshExpire time.Duration) input.AuthService {
 	return &authService{
 		tokens:        tokens,
 		blacklist:     blacklist,
+		sessions:      sessions,
 		accessExpire:  accessExpire,
 		refreshExpire: refreshExpire,
 	}
 }
 
-func (a *authService) GenerateTokenPair(ctx context.Context, userId int64, userAgent string) (string, string, error) {
+func (a *authService) GenerateTokenPair(ctx context.Context, userId int64, client domain.LoginClient) (string, string, error) {
+	// 登录时开启一个新的会话族，族ID即首个 refresh token 的 ssid
 	ssid := uuid.NewString()
-	return a.tokens.GenerateTokenPair(userId, userAgent, ssid, a.accessExpire, a.refreshExpire)
+	accessToken, refreshToken, err := a.tokens.GenerateTokenPair(userId, client.UserAgent, ssid, ssid, a.accessExpire, a.refreshExpire)
+	if err != nil {
+		return "", "", err
+	}
+	a.touchSession(ctx, userId, ssid, client)
+	return accessToken, refreshToken, nil
 }
 
-func (a *authService) RefreshAccessToken(ctx context.Context, refreshToken, userAgent string) (string, error) {
+func (a *authService) RefreshAccessToken(ctx context.Context, refreshToken string, client domain.LoginClient) (string, string, error) {
 	claims, err := a.tokens.ParseRefreshToken(refreshToken)
 	if err != nil {
-		return "", domain.ErrUnauthorized
+		return "", "", domain.ErrUnauthorized
+	}
+
+	// 退出登录、注销该会话或检测到重放时整族吊销
+	familyRevoked, err := a.blacklist.IsFamilyRevoked(ctx, claims.Family)
+	if err != nil || familyRevoked {
+		return "", "", domain.ErrUnauthorized
+	}
+	// 重置密码、退出全部设备会吊销用户在此之前签发的全部 refresh token
+	revokedAt, err := a.blacklist.UserRevokedAt(ctx, claims.UserId)
+	if err != nil || claims.IssuedAt < revokedAt {
+		return "", "", domain.ErrUnauthorized
 	}
 
-	isBlacklisted, err := a.blacklist.IsBlacklisted(ctx, claims.SSid)
-	if err != nil || isBlacklisted {
-		return "", domain.ErrUnauthorized
+	// 原子地作废旧 token，并发刷新时只有一个请求能拿到新 token
+	fresh, err := a.blacklist.Consume(ctx, claims.SSid, a.refreshExpire)
+	if err != nil {
+		return "", "", err
+	}
+	if !fresh {
+		// 已轮换（或已退出）的 token 又被使用，说明它可能已泄露，
+		// 持有者和攻击者谁先刷新无从判断，只能让整个会话族失效
+		if err = a.blacklist.RevokeFamily(ctx, claims.Family, a.refreshExpire); err != nil {
+			return "", "", err
+		}
+		return "", "", domain.ErrUnauthorized
 	}
 
-	return a.tokens.GenerateAccessToken(claims.UserId, userAgent, a.accessExpire)
+	accessToken, newRefreshToken, err := a.tokens.GenerateTokenPair(claims.UserId, client.UserAgent, uuid.NewString(), claims.Family, a.accessExpire, a.refreshExpire)
+	if err != nil {
+		return "", "", err
+	}
+	a.touchSession(ctx, claims.UserId, claims.Family, client)
+	return accessToken, newRefreshToken, nil
 }
 
 func (a *authService) Logout(ctx context.Context, refreshToken string) error {
@@ -50,5 +84,102 @@ func (a *authService) Logout(ctx context.Context, refreshToken string) error {
 	if err != nil {
 		return nil
 	}
-	return a.blacklist.Add(ctx, claims.SSid, a.refreshExpire)
+	// 轮换后的 refresh token 都属于同一族，退出时整族失效
+	if err = a.blacklist.RevokeFamily(ctx, claims.Family, a.refreshExpire); err != nil {
+		return err
+	}
+	// 会话记录只用于展示，删除失败不影响退出
+	_ = a.sessions.Delete(ctx, claims.UserId, claims.Family)
+	return nil
+}
+
+func (a *authService) Sessions(ctx context.Context, userId int64) ([]domain.Session, error) {
+	// 超过 refresh token 有效期未使用的会话已自然失效
+	since := time.Now().Add(-a.refreshExpire).UnixMilli()
+	sessions, err := a.sessions.FindByUser(ctx, userId, since)
+	if err != nil {
+		return nil, err
+	}
+	// 重置密码吊销的会话没有删除记录，按吊销时间过滤：最近一次签发早于吊销时间的会话已失效
+	revokedAt, err := a.blacklist.UserRevokedAt(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	active := sessions[:0]
+	for _, s := range sessions {
+		if s.LastUsed/1000 >= revokedAt {
+			active = append(active, s)
+		}
+	}
+	return active, nil
+}
+
+func (a *authService) RevokeSession(ctx context.Context, userId int64, currentSessionId, sessionId string) error {
+	owned, err := a.ownsSession(ctx, userId, currentSessionId, sessionId)
+	if err != nil {
+		return err
+	}
+	if !owned {
+		return domain.ErrSessionNotFound
+	}
+	// 先吊销再删记录：吊销失败时记录还在，用户可以重试
+	if err = a.blacklist.RevokeFamily(ctx, sessionId, a.refreshExpire); err != nil {
+		return err
+	}
+	// 会话记录只用于展示，删除失败或记录已不存在都不影响注销
+	_ = a.sessions.Delete(ctx, userId, sessionId)
+	return nil
+}
+
+// ownsSession 会话是否属于该用户。当前会话的归属由 access token 证明；
+// 会话记录是尽力写入的，可能缺失，只在注销其他会话时作为依据
+func (a *authService) ownsSession(ctx context.Context, userId int64, currentSessionId, sessionId string) (bool, error) {
+	if sessionId == "" {
+		return false, nil
+	}
+	if sessionId == currentSessionId {
+		return true, nil
+	}
+	sessions, err := a.sessions.FindByUser(ctx, userId, 0)
+	if err != nil {
+		return false, err
+	}
+	for _, s := range sessions {
+		if s.Id == sessionId {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (a *authService) LogoutAll(ctx context.Context, userId int64) error {
+	if err := a.blacklist.RevokeUser(ctx, userId, a.refreshExpire); err != nil {
+		return err
+	}
+	return a.sessions.DeleteByUser(ctx, userId)
+}
+
+func (a *authService) SessionActive(ctx context.Context, userId int64, sessionId string, issuedAt int64) (bool, error) {
+	// 升级前签发的 access token 不带会话ID，只检查用户级吊销
+	if sessionId != "" {
+		revoked, err := a.blacklist.IsFamilyRevoked(ctx, sessionId)
+		if err != nil || revoked {
+			return false, err
+		}
+	}
+	revokedAt, err := a.blacklist.UserRevokedAt(ctx, userId)
+	if err != nil {
+		return false, err
+	}
+	return issuedAt >= revokedAt, nil
+}
+
+// touchSession 记录会话的最近使用，会话记录只用于展示，写入失败不影响签发
+func (a *authService) touchSession(ctx context.Context, userId int64, sessionId string, client domain.LoginClient) {
+	_ = a.sessions.Upsert(ctx, domain.Session{
+		Id:        sessionId,
+		UserId:    userId,
+		UserAgent: client.UserAgent,
+		IP:        client.IP,
+	})
 }
@@ -0,0 +1,237 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	repomocks "webook/internal/adapters/outbound/mocks"
+	"webook/internal/domain"
+	input "webook/internal/ports/input"
+	output "webook/internal/ports/output"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const refreshExpire = 7 * 24 * time.Hour
+
+type authMocks struct {
+	tokens    *repomocks.MockTokenService
+	blacklist *repomocks.MockTokenBlacklist
+	sessions  *repomocks.MockSessionRepository
+}
+
+func newAuthMocks(ctrl *gomock.Controller) authMocks {
+	return authMocks{
+		tokens:    repomocks.NewMockTokenService(ctrl),
+		blacklist: repomocks.NewMockTokenBlacklist(ctrl),
+		sessions:  repomocks.NewMockSessionRepository(ctrl),
+	}
+}
+
+func (m authMocks) authService() input.AuthService {
+	return NewAuthService(m.tokens, m.blacklist, m.sessions, 30*time.Minute, refreshExpire)
+}
+
+func TestAuthService_RefreshAccessToken(t *testing.T) {
+	claims := output.RefreshClaims{UserId: 1, SSid: "ssid-2", Family: "ssid-1", IssuedAt: 1000}
+
+	tests := []struct {
+		name        string
+		mock        func(m authMocks)
+		wantRefresh string
+		wantErr     error
+	}{
+		{
+			name: "轮换-同一会话族签发新 token",
+			mock: func(m authMocks) {
+				m.tokens.EXPECT().ParseRefreshToken("old").Return(claims, nil)
+				m.blacklist.EXPECT().IsFamilyRevoked(gomock.Any(), "ssid-1").Return(false, nil)
+				m.blacklist.EXPECT().UserRevokedAt(gomock.Any(), int64(1)).Return(int64(0), nil)
+				m.blacklist.EXPECT().Consume(gomock.Any(), "ssid-2", refreshExpire).Return(true, nil)
+				m.tokens.EXPECT().GenerateTokenPair(int64(1), "ua", gomock.Not("ssid-2"), "ssid-1", 30*time.Minute, refreshExpire).
+					Return("access", "new", nil)
+				m.sessions.EXPECT().Upsert(gomock.Any(), domain.Session{Id: "ssid-1", UserId: 1, UserAgent: "ua", IP: "1.2.3.4"}).Return(nil)
+			},
+			wantRefresh: "new",
+		},
+		{
+			name: "重复使用已轮换的 token-吊销整个会话族",
+			mock: func(m authMocks) {
+				m.tokens.EXPECT().ParseRefreshToken("old").Return(claims, nil)
+				m.blacklist.EXPECT().IsFamilyRevoked(gomock.Any(), "ssid-1").Return(false, nil)
+				m.blacklist.EXPECT().UserRevokedAt(gomock.Any(), int64(1)).Return(int64(0), nil)
+				m.blacklist.EXPECT().Consume(gomock.Any(), "ssid-2", refreshExpire).Return(false, nil)
+				m.blacklist.EXPECT().RevokeFamily(gomock.Any(), "ssid-1", refreshExpire).Return(nil)
+			},
+			wantErr: domain.ErrUnauthorized,
+		},
+		{
+			name: "会话族已吊销",
+			mock: func(m authMocks) {
+				m.tokens.EXPECT().ParseRefreshToken("old").Return(claims, nil)
+				m.blacklist.EXPECT().IsFamilyRevoked(gomock.Any(), "ssid-1").Return(true, nil)
+			},
+			wantErr: domain.ErrUnauthorized,
+		},
+		{
+			name: "重置密码前签发的 token",
+			mock: func(m authMocks) {
+				m.tokens.EXPECT().ParseRefreshToken("old").Return(claims, nil)
+				m.blacklist.EXPECT().IsFamilyRevoked(gomock.Any(), "ssid-1").Return(false, nil)
+				m.blacklist.EXPECT().UserRevokedAt(gomock.Any(), int64(1)).Return(int64(2000), nil)
+			},
+			wantErr: domain.ErrUnauthorized,
+		},
+		{
+			name: "token 无效",
+			mock: func(m authMocks) {
+				m.tokens.EXPECT().ParseRefreshToken("old").Return(output.RefreshClaims{}, errors.New("bad signature"))
+			},
+			wantErr: domain.ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newAuthMocks(ctrl)
+			tt.mock(m)
+
+			_, refresh, err := m.authService().RefreshAccessToken(context.Background(), "old", domain.LoginClient{IP: "1.2.3.4", UserAgent: "ua"})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantRefresh, refresh)
+			}
+		})
+	}
+}
+
+func TestAuthService_Sessions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newAuthMocks(ctrl)
+	m.sessions.EXPECT().FindByUser(gomock.Any(), int64(1), gomock.Any()).Return([]domain.Session{
+		{Id: "b", UserId: 1, LastUsed: 3000_000},
+		{Id: "a", UserId: 1, LastUsed: 1000_000}, // 重置密码前最后使用，已失效
+	}, nil)
+	m.blacklist.EXPECT().UserRevokedAt(gomock.Any(), int64(1)).Return(int64(2000), nil)
+
+	sessions, err := m.authService().Sessions(context.Background(), 1)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "b", sessions[0].Id)
+}
+
+func TestAuthService_RevokeSession(t *testing.T) {
+	tests := []struct {
+		name      string
+		currentId string
+		mock      func(m authMocks)
+		wantErr   error
+	}{
+		{
+			name:      "注销其他会话-吊销会话族",
+			currentId: "s0",
+			mock: func(m authMocks) {
+				m.sessions.EXPECT().FindByUser(gomock.Any(), int64(1), int64(0)).Return([]domain.Session{{Id: "s0"}, {Id: "s1"}}, nil)
+				m.blacklist.EXPECT().RevokeFamily(gomock.Any(), "s1", refreshExpire).Return(nil)
+				m.sessions.EXPECT().Delete(gomock.Any(), int64(1), "s1").Return(nil)
+			},
+		},
+		{
+			name:      "注销当前会话-会话记录缺失也能吊销",
+			currentId: "s1",
+			mock: func(m authMocks) {
+				m.blacklist.EXPECT().RevokeFamily(gomock.Any(), "s1", refreshExpire).Return(nil)
+				m.sessions.EXPECT().Delete(gomock.Any(), int64(1), "s1").Return(domain.ErrSessionNotFound)
+			},
+		},
+		{
+			name:      "吊销失败-保留会话记录以便重试",
+			currentId: "s1",
+			mock: func(m authMocks) {
+				m.blacklist.EXPECT().RevokeFamily(gomock.Any(), "s1", refreshExpire).Return(errors.New("redis down"))
+			},
+			wantErr: errors.New("redis down"),
+		},
+		{
+			name:      "会话不存在或不属于该用户",
+			currentId: "s0",
+			mock: func(m authMocks) {
+				m.sessions.EXPECT().FindByUser(gomock.Any(), int64(1), int64(0)).Return([]domain.Session{{Id: "s0"}}, nil)
+			},
+			wantErr: domain.ErrSessionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newAuthMocks(ctrl)
+			tt.mock(m)
+
+			err := m.authService().RevokeSession(context.Background(), 1, tt.currentId, "s1")
+			if tt.wantErr != nil {
+				assert.EqualError(t, err, tt.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_SessionActive(t *testing.T) {
+	tests := []struct {
+		name       string
+		sessionId  string
+		mock       func(m authMocks)
+		wantActive bool
+	}{
+		{
+			name:      "会话有效",
+			sessionId: "s1",
+			mock: func(m authMocks) {
+				m.blacklist.EXPECT().IsFamilyRevoked(gomock.Any(), "s1").Return(false, nil)
+				m.blacklist.EXPECT().UserRevokedAt(gomock.Any(), int64(1)).Return(int64(0), nil)
+			},
+			wantActive: true,
+		},
+		{
+			name:      "会话已注销",
+			sessionId: "s1",
+			mock: func(m authMocks) {
+				m.blacklist.EXPECT().IsFamilyRevoked(gomock.Any(), "s1").Return(true, nil)
+			},
+		},
+		{
+			name: "已退出全部设备-旧 token 不带会话ID",
+			mock: func(m authMocks) {
+				m.blacklist.EXPECT().UserRevokedAt(gomock.Any(), int64(1)).Return(int64(2000), nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newAuthMocks(ctrl)
+			tt.mock(m)
+
+			active, err := m.authService().SessionActive(context.Background(), 1, tt.sessionId, 1000)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantActive, active)
+		})
+	}
+}
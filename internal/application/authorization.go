@@ -0,0 +1,67 @@
+package application
+
+import (
+	"context"
+	"webook/internal/domain"
+	input "webook/internal/ports/input"
+	output "webook/internal/ports/output"
+)
+
+// AdminUserIds 配置中指定的管理员，不论数据库中的角色如何都视为管理员，用于指定第一个管理员
+type AdminUserIds []int64
+
+type authorizationService struct {
+	users  output.UserRepository
+	admins map[int64]struct{}
+}
+
+func NewAuthorizationService(users output.UserRepository, adminIds AdminUserIds) input.AuthorizationService {
+	admins := make(map[int64]struct{}, len(adminIds))
+	for _, id := range adminIds {
+		admins[id] = struct{}{}
+	}
+	return &authorizationService{users: users, admins: admins}
+}
+
+func (s *authorizationService) Role(ctx context.Context, uid int64) (domain.Role, error) {
+	u, err := s.users.FindById(ctx, uid)
+	if err != nil {
+		return "", err
+	}
+	return s.roleOf(u), nil
+}
+
+func (s *authorizationService) Authorize(ctx context.Context, uid int64, perm domain.Permission) error {
+	if uid == 0 {
+		return domain.ErrUnauthorized
+	}
+	// 用户信息有缓存，每次检查都读取，角色和账号状态的修改立即生效
+	u, err := s.users.FindById(ctx, uid)
+	if err == domain.ErrUserNotFound {
+		return domain.ErrForbidden
+	}
+	if err != nil {
+		return err
+	}
+	if !u.Status.Active() || !s.roleOf(u).Can(perm) {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+func (s *authorizationService) AuthorizeOwner(ctx context.Context, uid, ownerId int64, perm domain.Permission) error {
+	if uid != 0 && uid == ownerId {
+		return nil
+	}
+	return s.Authorize(ctx, uid, perm)
+}
+
+func (s *authorizationService) roleOf(u domain.User) domain.Role {
+	if _, ok := s.admins[u.Id]; ok {
+		return domain.RoleAdmin
+	}
+	if u.Role == "" {
+		return domain.RoleUser
+	}
+	return u.Role
+}
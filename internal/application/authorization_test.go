@@ -0,0 +1,100 @@
+package application
+
+import (
+	"context"
+	"testing"
+	repomocks "webook/internal/adapters/outbound/mocks"
+	"webook/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuthorizationService_Authorize(t *testing.T) {
+	tests := []struct {
+		name    string
+		uid     int64
+		perm    domain.Permission
+		mock    func(repo *repomocks.MockUserRepository)
+		wantErr error
+	}{
+		{
+			name: "版主可以隐藏帖子",
+			uid:  2,
+			perm: domain.PermPostModerate,
+			mock: func(repo *repomocks.MockUserRepository) {
+				repo.EXPECT().FindById(gomock.Any(), int64(2)).Return(domain.User{Id: 2, Role: domain.RoleModerator}, nil)
+			},
+		},
+		{
+			name: "版主不能管理用户",
+			uid:  2,
+			perm: domain.PermUserManage,
+			mock: func(repo *repomocks.MockUserRepository) {
+				repo.EXPECT().FindById(gomock.Any(), int64(2)).Return(domain.User{Id: 2, Role: domain.RoleModerator}, nil)
+			},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name: "普通用户没有管理权限",
+			uid:  3,
+			perm: domain.PermUserRead,
+			mock: func(repo *repomocks.MockUserRepository) {
+				repo.EXPECT().FindById(gomock.Any(), int64(3)).Return(domain.User{Id: 3, Role: domain.RoleUser}, nil)
+			},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name: "配置中的管理员-不论数据库中的角色",
+			uid:  1,
+			perm: domain.PermUserManage,
+			mock: func(repo *repomocks.MockUserRepository) {
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(domain.User{Id: 1}, nil)
+			},
+		},
+		{
+			name: "被停用的管理员没有权限",
+			uid:  4,
+			perm: domain.PermUserRead,
+			mock: func(repo *repomocks.MockUserRepository) {
+				repo.EXPECT().FindById(gomock.Any(), int64(4)).
+					Return(domain.User{Id: 4, Role: domain.RoleAdmin, Status: domain.UserStatusDisabled}, nil)
+			},
+			wantErr: domain.ErrForbidden,
+		},
+		{
+			name:    "未登录",
+			perm:    domain.PermUserRead,
+			wantErr: domain.ErrUnauthorized,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo := repomocks.NewMockUserRepository(ctrl)
+			if tc.mock != nil {
+				tc.mock(repo)
+			}
+
+			err := NewAuthorizationService(repo, AdminUserIds{1}).Authorize(context.Background(), tc.uid, tc.perm)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestAuthorizationService_AuthorizeOwner(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockUserRepository(ctrl)
+	svc := NewAuthorizationService(repo, nil)
+
+	// 资源属于自己时不查询角色
+	assert.NoError(t, svc.AuthorizeOwner(context.Background(), 3, 3, domain.PermPostRead))
+
+	repo.EXPECT().FindById(gomock.Any(), int64(3)).Return(domain.User{Id: 3, Role: domain.RoleUser}, nil)
+	assert.ErrorIs(t, svc.AuthorizeOwner(context.Background(), 3, 5, domain.PermPostRead), domain.ErrForbidden)
+
+	assert.ErrorIs(t, svc.AuthorizeOwner(context.Background(), 0, 0, domain.PermPostRead), domain.ErrUnauthorized)
+}
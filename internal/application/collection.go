@@ -0,0 +1,174 @@
+package application
+
+import (
+	"context"
+	"unicode/utf8"
+	"webook/internal/domain"
+	input "webook/internal/ports/input"
+	output "webook/internal/ports/output"
+)
+
+const (
+	// 收藏夹名称最大长度（字符数）
+	maxFolderNameLen = 32
+	// 收藏夹描述最大长度（字符数）
+	maxFolderDescLen = 256
+)
+
+type collectionService struct {
+	folderRepo  output.CollectionFolderRepository
+	collectRepo output.PostCollectRepository
+	pubRepo     output.PublishedPostRepository
+}
+
+func NewCollectionService(
+	folderRepo output.CollectionFolderRepository,
+	collectRepo output.PostCollectRepository,
+	pubRepo output.PublishedPostRepository,
+) input.CollectionService {
+	return &collectionService{
+		folderRepo:  folderRepo,
+		collectRepo: collectRepo,
+		pubRepo:     pubRepo,
+	}
+}
+
+func (s *collectionService) CreateFolder(ctx context.Context, f domain.CollectionFolder) (int64, error) {
+	f, err := normalizeFolder(f)
+	if err != nil {
+		return 0, err
+	}
+	return s.folderRepo.Create(ctx, f)
+}
+
+func (s *collectionService) UpdateFolder(ctx context.Context, f domain.CollectionFolder) error {
+	// 默认收藏夹不能修改或删除
+	if f.Id == domain.DefaultCollectionFolderId {
+		return domain.ErrForbidden
+	}
+	f, err := normalizeFolder(f)
+	if err != nil {
+		return err
+	}
+	return s.folderRepo.Update(ctx, f)
+}
+
+func (s *collectionService) DeleteFolder(ctx context.Context, id, userId int64) error {
+	if id == domain.DefaultCollectionFolderId {
+		return domain.ErrForbidden
+	}
+	return s.folderRepo.Delete(ctx, id, userId)
+}
+
+func (s *collectionService) ListFolders(ctx context.Context, userId int64) ([]domain.CollectionFolder, error) {
+	folders, err := s.folderRepo.FindByUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := s.collectRepo.CountByFolder(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.CollectionFolder, 0, len(folders)+1)
+	result = append(result, domain.CollectionFolder{
+		Id:      domain.DefaultCollectionFolderId,
+		UserId:  userId,
+		Name:    domain.DefaultCollectionFolderName,
+		PostCnt: counts[domain.DefaultCollectionFolderId],
+	})
+	for _, f := range folders {
+		f.PostCnt = counts[f.Id]
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+func (s *collectionService) CollectTo(ctx context.Context, postId, userId, folderId int64) error {
+	if folderId != domain.DefaultCollectionFolderId {
+		if _, err := s.ownFolder(ctx, folderId, userId); err != nil {
+			return err
+		}
+	}
+	// 新的收藏先进入默认收藏夹，再移动到目标收藏夹；已收藏时 SetStatus 不会产生统计事件
+	changed, err := s.collectRepo.SetStatus(ctx, postId, userId, 1)
+	if err != nil {
+		return err
+	}
+	if changed && folderId == domain.DefaultCollectionFolderId {
+		return nil
+	}
+	return s.collectRepo.MoveToFolder(ctx, postId, userId, folderId)
+}
+
+func (s *collectionService) ListPosts(ctx context.Context, ownerId, folderId, viewerId int64, page, pageSize int) ([]domain.Post, int64, error) {
+	if folderId == domain.DefaultCollectionFolderId {
+		// 默认收藏夹不能公开
+		if viewerId != ownerId {
+			return nil, 0, domain.ErrFolderNotFound
+		}
+	} else {
+		f, err := s.ownFolder(ctx, folderId, ownerId)
+		if err != nil {
+			return nil, 0, err
+		}
+		// 非公开收藏夹对其他用户表现为不存在
+		if !f.Public && viewerId != ownerId {
+			return nil, 0, domain.ErrFolderNotFound
+		}
+	}
+
+	counts, err := s.collectRepo.CountByFolder(ctx, ownerId)
+	if err != nil {
+		return nil, 0, err
+	}
+	ids, err := s.collectRepo.FindPostIdsByFolder(ctx, ownerId, folderId, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(ids) == 0 {
+		return []domain.Post{}, counts[folderId], nil
+	}
+
+	posts, err := s.pubRepo.FindByIds(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	byId := make(map[int64]domain.Post, len(posts))
+	for _, p := range posts {
+		byId[p.Id] = p
+	}
+	result := make([]domain.Post, 0, len(ids))
+	for _, id := range ids {
+		// 收藏后被作者删除或下线的帖子不再展示
+		if p, ok := byId[id]; ok {
+			result = append(result, p)
+		}
+	}
+	return result, counts[folderId], nil
+}
+
+// ownFolder 获取属于 userId 的收藏夹，不属于时视为不存在
+func (s *collectionService) ownFolder(ctx context.Context, id, userId int64) (domain.CollectionFolder, error) {
+	f, err := s.folderRepo.FindById(ctx, id)
+	if err != nil {
+		return domain.CollectionFolder{}, err
+	}
+	if f.UserId != userId {
+		return domain.CollectionFolder{}, domain.ErrFolderNotFound
+	}
+	return f, nil
+}
+
+func normalizeFolder(f domain.CollectionFolder) (domain.CollectionFolder, error) {
+	f.Name = domain.NormalizeCollectionFolderName(f.Name)
+	if f.Name == "" || utf8.RuneCountInString(f.Name) > maxFolderNameLen ||
+		utf8.RuneCountInString(f.Description) > maxFolderDescLen {
+		return f, domain.ErrInvalidFolder
+	}
+	// 与默认收藏夹同名会让用户无法区分
+	if f.Name == domain.DefaultCollectionFolderName {
+		return f, domain.ErrDuplicateFolder
+	}
+	return f, nil
+}
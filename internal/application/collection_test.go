@@ -0,0 +1,160 @@
+package application
+
+import (
+	"context"
+	"testing"
+	repomocks "webook/internal/adapters/outbound/mocks"
+	"webook/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type collectionMocks struct {
+	folderRepo  *repomocks.MockCollectionFolderRepository
+	collectRepo *repomocks.MockPostCollectRepository
+	pubRepo     *repomocks.MockPublishedPostRepository
+}
+
+func newCollectionMocks(ctrl *gomock.Controller) collectionMocks {
+	return collectionMocks{
+		folderRepo:  repomocks.NewMockCollectionFolderRepository(ctrl),
+		collectRepo: repomocks.NewMockPostCollectRepository(ctrl),
+		pubRepo:     repomocks.NewMockPublishedPostRepository(ctrl),
+	}
+}
+
+func TestCollectionService_CollectTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		folderId int64
+		mock     func(m collectionMocks)
+		wantErr  error
+	}{
+		{
+			name:     "首次收藏到默认收藏夹",
+			folderId: 0,
+			mock: func(m collectionMocks) {
+				m.collectRepo.EXPECT().SetStatus(gomock.Any(), int64(1), int64(7), uint8(1)).Return(true, nil)
+			},
+		},
+		{
+			name:     "首次收藏到指定收藏夹",
+			folderId: 3,
+			mock: func(m collectionMocks) {
+				m.folderRepo.EXPECT().FindById(gomock.Any(), int64(3)).Return(domain.CollectionFolder{Id: 3, UserId: 7}, nil)
+				m.collectRepo.EXPECT().SetStatus(gomock.Any(), int64(1), int64(7), uint8(1)).Return(true, nil)
+				m.collectRepo.EXPECT().MoveToFolder(gomock.Any(), int64(1), int64(7), int64(3)).Return(nil)
+			},
+		},
+		{
+			name:     "已收藏-移回默认收藏夹",
+			folderId: 0,
+			mock: func(m collectionMocks) {
+				m.collectRepo.EXPECT().SetStatus(gomock.Any(), int64(1), int64(7), uint8(1)).Return(false, nil)
+				m.collectRepo.EXPECT().MoveToFolder(gomock.Any(), int64(1), int64(7), int64(0)).Return(nil)
+			},
+		},
+		{
+			name:     "他人的收藏夹",
+			folderId: 4,
+			mock: func(m collectionMocks) {
+				m.folderRepo.EXPECT().FindById(gomock.Any(), int64(4)).Return(domain.CollectionFolder{Id: 4, UserId: 8}, nil)
+			},
+			wantErr: domain.ErrFolderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newCollectionMocks(ctrl)
+			tt.mock(m)
+			svc := NewCollectionService(m.folderRepo, m.collectRepo, m.pubRepo)
+
+			err := svc.CollectTo(context.Background(), 1, 7, tt.folderId)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCollectionService_ListPosts(t *testing.T) {
+	tests := []struct {
+		name      string
+		folderId  int64
+		viewerId  int64
+		mock      func(m collectionMocks)
+		wantIds   []int64
+		wantTotal int64
+		wantErr   error
+	}{
+		{
+			name:     "公开收藏夹-跳过已下线的帖子",
+			folderId: 3,
+			viewerId: 9,
+			mock: func(m collectionMocks) {
+				m.folderRepo.EXPECT().FindById(gomock.Any(), int64(3)).Return(domain.CollectionFolder{Id: 3, UserId: 7, Public: true}, nil)
+				m.collectRepo.EXPECT().CountByFolder(gomock.Any(), int64(7)).Return(map[int64]int64{0: 5, 3: 3}, nil)
+				m.collectRepo.EXPECT().FindPostIdsByFolder(gomock.Any(), int64(7), int64(3), 0, 10).Return([]int64{12, 11, 10}, nil)
+				m.pubRepo.EXPECT().FindByIds(gomock.Any(), []int64{12, 11, 10}).Return([]domain.Post{{Id: 10}, {Id: 12}}, nil)
+			},
+			wantIds:   []int64{12, 10},
+			wantTotal: 3,
+		},
+		{
+			name:     "非公开收藏夹-他人不可见",
+			folderId: 3,
+			viewerId: 9,
+			mock: func(m collectionMocks) {
+				m.folderRepo.EXPECT().FindById(gomock.Any(), int64(3)).Return(domain.CollectionFolder{Id: 3, UserId: 7}, nil)
+			},
+			wantErr: domain.ErrFolderNotFound,
+		},
+		{
+			name:     "默认收藏夹-他人不可见",
+			folderId: 0,
+			viewerId: 9,
+			mock:     func(m collectionMocks) {},
+			wantErr:  domain.ErrFolderNotFound,
+		},
+		{
+			name:     "收藏夹不属于该用户",
+			folderId: 4,
+			viewerId: 7,
+			mock: func(m collectionMocks) {
+				m.folderRepo.EXPECT().FindById(gomock.Any(), int64(4)).Return(domain.CollectionFolder{Id: 4, UserId: 8, Public: true}, nil)
+			},
+			wantErr: domain.ErrFolderNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newCollectionMocks(ctrl)
+			tt.mock(m)
+			svc := NewCollectionService(m.folderRepo, m.collectRepo, m.pubRepo)
+
+			posts, total, err := svc.ListPosts(context.Background(), 7, tt.folderId, tt.viewerId, 1, 10)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			ids := make([]int64, len(posts))
+			for i, p := range posts {
+				ids[i] = p.Id
+			}
+			assert.Equal(t, tt.wantIds, ids)
+			assert.Equal(t, tt.wantTotal, total)
+		})
+	}
+}
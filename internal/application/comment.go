@@ -0,0 +1,148 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+	"webook/internal/domain"
+	input "webook/internal/ports/input"
+	output "webook/internal/ports/output"
+)
+
+const (
+	// 评论内容最大长度（字符数）
+	maxCommentLen = 1000
+	// 根评论列表中每条评论附带的回复预览条数
+	commentReplyPreviewSize = 3
+)
+
+type commentService struct {
+	repo     output.CommentRepository
+	postRepo output.PostRepository
+	pubRepo  output.PublishedPostRepository
+}
+
+// 评论数通过 CommentRepository 写入 outbox 的 comment/uncomment 事件异步更新
+func NewCommentService(repo output.CommentRepository, postRepo output.PostRepository, pubRepo output.PublishedPostRepository) input.CommentService {
+	return &commentService{
+		repo:     repo,
+		postRepo: postRepo,
+		pubRepo:  pubRepo,
+	}
+}
+
+func (s *commentService) Create(ctx context.Context, c domain.Comment) (int64, error) {
+	content, err := normalizeCommentContent(c.Content)
+	if err != nil {
+		return 0, err
+	}
+	c.Content = content
+
+	// 只能评论已发布的帖子
+	if _, err := s.pubRepo.FindById(ctx, c.PostId); err != nil {
+		return 0, err
+	}
+
+	c.RootId, c.ReplyToUserId = 0, 0
+	if c.ParentId > 0 {
+		parent, err := s.repo.FindById(ctx, c.ParentId)
+		if err != nil {
+			return 0, err
+		}
+		if parent.PostId != c.PostId {
+			return 0, domain.ErrCommentNotFound
+		}
+		// 回复统一挂在根评论下，保持两级结构
+		c.RootId = parent.RootId
+		if parent.IsRoot() {
+			c.RootId = parent.Id
+		}
+		c.ReplyToUserId = parent.UserId
+	}
+
+	return s.repo.Create(ctx, c)
+}
+
+func (s *commentService) Edit(ctx context.Context, id, userId int64, content string) error {
+	content, err := normalizeCommentContent(content)
+	if err != nil {
+		return err
+	}
+	c, err := s.repo.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if c.UserId != userId {
+		return domain.ErrForbidden
+	}
+	return s.repo.UpdateContent(ctx, id, userId, content)
+}
+
+func (s *commentService) Delete(ctx context.Context, id, userId int64) error {
+	c, err := s.repo.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if c.UserId != userId {
+		// 帖子作者可以删除自己帖子下的任意评论
+		post, err := s.postRepo.FindById(ctx, c.PostId)
+		if err != nil && err != domain.ErrPostNotFound {
+			return err
+		}
+		if err != nil || post.AuthorId != userId {
+			return domain.ErrForbidden
+		}
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *commentService) ListByPost(ctx context.Context, postId, cursor int64, limit int) ([]domain.Comment, error) {
+	if _, err := s.pubRepo.FindById(ctx, postId); err != nil {
+		return nil, err
+	}
+	roots, err := s.repo.FindRoots(ctx, postId, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(roots) == 0 {
+		return roots, nil
+	}
+
+	rootIds := make([]int64, len(roots))
+	for i, r := range roots {
+		rootIds[i] = r.Id
+	}
+	counts, err := s.repo.CountReplies(ctx, rootIds)
+	if err != nil {
+		return nil, err
+	}
+	previews, err := s.repo.FindRepliesPreview(ctx, rootIds, commentReplyPreviewSize)
+	if err != nil {
+		return nil, err
+	}
+	for i := range roots {
+		roots[i].ReplyCnt = counts[roots[i].Id]
+		roots[i].Replies = previews[roots[i].Id]
+	}
+	return roots, nil
+}
+
+func (s *commentService) ListReplies(ctx context.Context, rootId, cursor int64, limit int) ([]domain.Comment, error) {
+	root, err := s.repo.FindById(ctx, rootId)
+	if err != nil {
+		return nil, err
+	}
+	if !root.IsRoot() {
+		return nil, domain.ErrCommentNotFound
+	}
+	return s.repo.FindReplies(ctx, rootId, cursor, limit)
+}
+
+func normalizeCommentContent(content string) (string, error) {
+	content = strings.TrimSpace(content)
+	if content == "" || utf8.RuneCountInString(content) > maxCommentLen {
+		return "", domain.ErrInvalidComment
+	}
+	return content, nil
+}
@@ -0,0 +1,154 @@
+package application
+
+import (
+	"context"
+	"testing"
+	repomocks "webook/internal/adapters/outbound/mocks"
+	"webook/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type commentMocks struct {
+	repo     *repomocks.MockCommentRepository
+	postRepo *repomocks.MockPostRepository
+	pubRepo  *repomocks.MockPublishedPostRepository
+}
+
+func newCommentMocks(ctrl *gomock.Controller) commentMocks {
+	return commentMocks{
+		repo:     repomocks.NewMockCommentRepository(ctrl),
+		postRepo: repomocks.NewMockPostRepository(ctrl),
+		pubRepo:  repomocks.NewMockPublishedPostRepository(ctrl),
+	}
+}
+
+func TestCommentService_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment domain.Comment
+		mock    func(m commentMocks)
+		wantErr error
+	}{
+		{
+			name:    "回复子评论-挂到根评论下",
+			comment: domain.Comment{PostId: 1, UserId: 3, ParentId: 11, Content: "  同意  "},
+			mock: func(m commentMocks) {
+				m.pubRepo.EXPECT().FindById(gomock.Any(), int64(1)).Return(domain.Post{Id: 1, AuthorId: 1}, nil)
+				m.repo.EXPECT().
+					FindById(gomock.Any(), int64(11)).
+					Return(domain.Comment{Id: 11, PostId: 1, UserId: 2, RootId: 10, ParentId: 10}, nil)
+				m.repo.EXPECT().
+					Create(gomock.Any(), domain.Comment{
+						PostId:        1,
+						UserId:        3,
+						RootId:        10,
+						ParentId:      11,
+						ReplyToUserId: 2,
+						Content:       "同意",
+					}).
+					Return(int64(12), nil)
+			},
+		},
+		{
+			name:    "内容为空",
+			comment: domain.Comment{PostId: 1, UserId: 3, Content: "   "},
+			mock:    func(m commentMocks) {},
+			wantErr: domain.ErrInvalidComment,
+		},
+		{
+			name:    "帖子未发布",
+			comment: domain.Comment{PostId: 1, UserId: 3, Content: "hi"},
+			mock: func(m commentMocks) {
+				m.pubRepo.EXPECT().FindById(gomock.Any(), int64(1)).Return(domain.Post{}, domain.ErrPostNotFound)
+			},
+			wantErr: domain.ErrPostNotFound,
+		},
+		{
+			name:    "父评论属于其他帖子",
+			comment: domain.Comment{PostId: 1, UserId: 3, ParentId: 20, Content: "hi"},
+			mock: func(m commentMocks) {
+				m.pubRepo.EXPECT().FindById(gomock.Any(), int64(1)).Return(domain.Post{Id: 1}, nil)
+				m.repo.EXPECT().FindById(gomock.Any(), int64(20)).Return(domain.Comment{Id: 20, PostId: 2}, nil)
+			},
+			wantErr: domain.ErrCommentNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newCommentMocks(ctrl)
+			tt.mock(m)
+			svc := NewCommentService(m.repo, m.postRepo, m.pubRepo)
+
+			_, err := svc.Create(context.Background(), tt.comment)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCommentService_Delete(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int64
+		uid     int64
+		mock    func(m commentMocks)
+		wantErr error
+	}{
+		{
+			name: "帖子作者删除他人评论",
+			id:   10,
+			uid:  1,
+			mock: func(m commentMocks) {
+				m.repo.EXPECT().FindById(gomock.Any(), int64(10)).Return(domain.Comment{Id: 10, PostId: 1, UserId: 2}, nil)
+				m.postRepo.EXPECT().FindById(gomock.Any(), int64(1)).Return(domain.Post{Id: 1, AuthorId: 1}, nil)
+				m.repo.EXPECT().Delete(gomock.Any(), int64(10)).Return(nil)
+			},
+		},
+		{
+			name: "评论者删除自己的评论",
+			id:   11,
+			uid:  3,
+			mock: func(m commentMocks) {
+				m.repo.EXPECT().FindById(gomock.Any(), int64(11)).Return(domain.Comment{Id: 11, PostId: 1, UserId: 3, RootId: 10}, nil)
+				m.repo.EXPECT().Delete(gomock.Any(), int64(11)).Return(nil)
+			},
+		},
+		{
+			name: "无关用户无权删除",
+			id:   10,
+			uid:  4,
+			mock: func(m commentMocks) {
+				m.repo.EXPECT().FindById(gomock.Any(), int64(10)).Return(domain.Comment{Id: 10, PostId: 1, UserId: 2}, nil)
+				m.postRepo.EXPECT().FindById(gomock.Any(), int64(1)).Return(domain.Post{Id: 1, AuthorId: 1}, nil)
+			},
+			wantErr: domain.ErrForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newCommentMocks(ctrl)
+			tt.mock(m)
+			svc := NewCommentService(m.repo, m.postRepo, m.pubRepo)
+
+			err := svc.Delete(context.Background(), tt.id, tt.uid)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
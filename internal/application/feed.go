@@ -0,0 +1,184 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"webook/internal/domain"
+	input "webook/internal/ports/input"
+	output "webook/internal/ports/output"
+)
+
+const (
+	// feedFanOutBatch 推送时每批读取的粉丝数
+	feedFanOutBatch = 500
+	// maxFeedFollowees 读关注流时最多考虑的关注数，超出部分（最早关注的）不出现在关注流中
+	maxFeedFollowees = 2000
+)
+
+// FeedOptions 关注流的推拉参数
+type FeedOptions struct {
+	PushThreshold int64 // 粉丝数达到该值的作者不再推送，改为读时拉取
+	BackfillSize  int   // 关注作者时补进收件箱的帖子数
+}
+
+type feedService struct {
+	followRepo output.FollowRepository
+	pubRepo    output.PublishedPostRepository
+	cache      output.FeedCache
+	opts       FeedOptions
+}
+
+func NewFeedService(followRepo output.FollowRepository, pubRepo output.PublishedPostRepository, cache output.FeedCache, opts FeedOptions) input.FeedService {
+	return &feedService{
+		followRepo: followRepo,
+		pubRepo:    pubRepo,
+		cache:      cache,
+		opts:       opts,
+	}
+}
+
+func (s *feedService) FanOut(ctx context.Context, postId int64) error {
+	post, err := s.pubRepo.FindById(ctx, postId)
+	if errors.Is(err, domain.ErrPostNotFound) {
+		// 事件送达前帖子已下线
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	pushed, err := s.isPushed(ctx, post.AuthorId)
+	if err != nil || !pushed {
+		return err
+	}
+
+	item := domain.FeedItem{PostId: post.Id, Ts: post.Ctime}
+	var after int64
+	for {
+		ids, err := s.followRepo.FindFollowerIds(ctx, post.AuthorId, after, feedFanOutBatch)
+		if err != nil {
+			return err
+		}
+		if err = s.cache.Push(ctx, ids, item); err != nil {
+			return err
+		}
+		if len(ids) < feedFanOutBatch {
+			return nil
+		}
+		after = ids[len(ids)-1]
+	}
+}
+
+func (s *feedService) Backfill(ctx context.Context, followerId, followeeId int64) error {
+	pushed, err := s.isPushed(ctx, followeeId)
+	if err != nil || !pushed {
+		return err
+	}
+	posts, err := s.pubRepo.ListByAuthors(ctx, []int64{followeeId}, domain.FeedCursor{}, s.opts.BackfillSize)
+	if err != nil {
+		return err
+	}
+	items := make([]domain.FeedItem, len(posts))
+	for i, p := range posts {
+		items[i] = domain.FeedItem{PostId: p.Id, Ts: p.Ctime}
+	}
+	return s.cache.Push(ctx, []int64{followerId}, items...)
+}
+
+// isPushed 作者的帖子是否走推送
+func (s *feedService) isPushed(ctx context.Context, authorId int64) (bool, error) {
+	stats, err := s.followRepo.GetStats(ctx, authorId)
+	if err != nil {
+		return false, err
+	}
+	return stats.FollowerCnt < s.opts.PushThreshold, nil
+}
+
+func (s *feedService) List(ctx context.Context, userId int64, cursor domain.FeedCursor, limit int) (domain.FeedPage, error) {
+	followees, err := s.followRepo.FindFolloweeIds(ctx, userId, maxFeedFollowees)
+	if err != nil {
+		return domain.FeedPage{}, err
+	}
+	if len(followees) == 0 {
+		return domain.FeedPage{Posts: []domain.Post{}}, nil
+	}
+	cnts, err := s.followRepo.FollowerCnts(ctx, followees)
+	if err != nil {
+		return domain.FeedPage{}, err
+	}
+	following := make(map[int64]bool, len(followees))
+	var pulledAuthors []int64
+	for _, id := range followees {
+		following[id] = true
+		if cnts[id] >= s.opts.PushThreshold {
+			pulledAuthors = append(pulledAuthors, id)
+		}
+	}
+
+	// 写扩散：收件箱
+	inbox, err := s.cache.Inbox(ctx, userId, cursor, limit)
+	if err != nil {
+		return domain.FeedPage{}, err
+	}
+	ids := make([]int64, len(inbox))
+	for i, item := range inbox {
+		ids[i] = item.PostId
+	}
+	inboxPosts, err := s.pubRepo.FindByIds(ctx, ids)
+	if err != nil {
+		return domain.FeedPage{}, err
+	}
+	// 已取消关注的作者的帖子留在收件箱里，读时过滤
+	inboxPosts = slices.DeleteFunc(inboxPosts, func(p domain.Post) bool {
+		return !following[p.AuthorId]
+	})
+
+	// 读扩散：大V的帖子
+	pulled, err := s.pubRepo.ListByAuthors(ctx, pulledAuthors, cursor, limit)
+	if err != nil {
+		return domain.FeedPage{}, err
+	}
+	return mergeFeed(inbox, inboxPosts, pulled, limit), nil
+}
+
+// mergeFeed 合并收件箱与拉取到的帖子，按 (发布时间, 帖子ID) 倒序取一页。
+// 某个来源取满 limit 条时，排在其最后一条之后的帖子还没有取到，本页只能返回不晚于
+// 该位置的帖子，下一页从这个位置继续，避免跳过帖子
+func mergeFeed(inbox []domain.FeedItem, inboxPosts, pulled []domain.Post, limit int) domain.FeedPage {
+	var cutoff domain.FeedCursor
+	if len(inbox) == limit && limit > 0 {
+		cutoff = inbox[limit-1].Cursor()
+	}
+	if len(pulled) == limit && limit > 0 {
+		if c := feedCursorOf(pulled[limit-1]); c.Compare(cutoff) > 0 {
+			cutoff = c
+		}
+	}
+
+	posts := slices.Concat(inboxPosts, pulled)
+	slices.SortFunc(posts, func(a, b domain.Post) int {
+		return feedCursorOf(b).Compare(feedCursorOf(a))
+	})
+	// 作者粉丝数越过阈值前后，同一篇帖子可能同时出现在两个来源中
+	posts = slices.CompactFunc(posts, func(a, b domain.Post) bool {
+		return a.Id == b.Id
+	})
+
+	page := domain.FeedPage{Posts: make([]domain.Post, 0, limit), NextCursor: cutoff}
+	for _, p := range posts {
+		if feedCursorOf(p).Compare(cutoff) < 0 || len(page.Posts) == limit {
+			break
+		}
+		page.Posts = append(page.Posts, p)
+	}
+	if n := len(page.Posts); n > 0 {
+		page.NextCursor = feedCursorOf(page.Posts[n-1])
+	}
+	page.HasMore = len(page.Posts) == limit || cutoff != domain.FeedCursor{}
+	return page
+}
+
+// feedCursorOf 帖子在关注流中的位置
+func feedCursorOf(p domain.Post) domain.FeedCursor {
+	return domain.FeedCursor{Ts: p.Ctime, PostId: p.Id}
+}
@@ -0,0 +1,251 @@
+package application
+
+import (
+	"context"
+	"testing"
+	repomocks "webook/internal/adapters/outbound/mocks"
+	"webook/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type feedMocks struct {
+	followRepo *repomocks.MockFollowRepository
+	pubRepo    *repomocks.MockPublishedPostRepository
+	cache      *repomocks.MockFeedCache
+	userRepo   *repomocks.MockUserRepository
+}
+
+func newFeedMocks(ctrl *gomock.Controller) feedMocks {
+	return feedMocks{
+		followRepo: repomocks.NewMockFollowRepository(ctrl),
+		pubRepo:    repomocks.NewMockPublishedPostRepository(ctrl),
+		cache:      repomocks.NewMockFeedCache(ctrl),
+		userRepo:   repomocks.NewMockUserRepository(ctrl),
+	}
+}
+
+func (m feedMocks) feedService() *feedService {
+	return NewFeedService(m.followRepo, m.pubRepo, m.cache, FeedOptions{PushThreshold: 100, BackfillSize: 20}).(*feedService)
+}
+
+func TestFeedService_FanOut(t *testing.T) {
+	post := domain.Post{Id: 7, AuthorId: 1, Ctime: 1000}
+	item := domain.FeedItem{PostId: 7, Ts: 1000}
+	batch := make([]int64, feedFanOutBatch)
+	for i := range batch {
+		batch[i] = int64(i + 10)
+	}
+
+	tests := []struct {
+		name string
+		mock func(m feedMocks)
+	}{
+		{
+			name: "分批推送到粉丝收件箱",
+			mock: func(m feedMocks) {
+				m.pubRepo.EXPECT().FindById(gomock.Any(), int64(7)).Return(post, nil)
+				m.followRepo.EXPECT().GetStats(gomock.Any(), int64(1)).Return(domain.FollowStats{UserId: 1, FollowerCnt: 99}, nil)
+				m.followRepo.EXPECT().FindFollowerIds(gomock.Any(), int64(1), int64(0), feedFanOutBatch).Return(batch, nil)
+				m.cache.EXPECT().Push(gomock.Any(), batch, item).Return(nil)
+				m.followRepo.EXPECT().FindFollowerIds(gomock.Any(), int64(1), batch[len(batch)-1], feedFanOutBatch).Return([]int64{9999}, nil)
+				m.cache.EXPECT().Push(gomock.Any(), []int64{9999}, item).Return(nil)
+			},
+		},
+		{
+			name: "粉丝数达到阈值-不推送",
+			mock: func(m feedMocks) {
+				m.pubRepo.EXPECT().FindById(gomock.Any(), int64(7)).Return(post, nil)
+				m.followRepo.EXPECT().GetStats(gomock.Any(), int64(1)).Return(domain.FollowStats{UserId: 1, FollowerCnt: 100}, nil)
+			},
+		},
+		{
+			name: "帖子已下线-忽略",
+			mock: func(m feedMocks) {
+				m.pubRepo.EXPECT().FindById(gomock.Any(), int64(7)).Return(domain.Post{}, domain.ErrPostNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newFeedMocks(ctrl)
+			tt.mock(m)
+
+			err := m.feedService().FanOut(context.Background(), 7)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestFeedService_List(t *testing.T) {
+	tests := []struct {
+		name     string
+		cursor   domain.FeedCursor
+		limit    int
+		mock     func(m feedMocks)
+		wantIds  []int64
+		wantNext domain.FeedCursor
+		wantMore bool
+	}{
+		{
+			name:  "合并收件箱与大V的帖子-过滤已取关作者",
+			limit: 3,
+			mock: func(m feedMocks) {
+				m.followRepo.EXPECT().FindFolloweeIds(gomock.Any(), int64(5), maxFeedFollowees).Return([]int64{1, 2}, nil)
+				m.followRepo.EXPECT().FollowerCnts(gomock.Any(), []int64{1, 2}).Return(map[int64]int64{1: 3, 2: 500}, nil)
+				m.cache.EXPECT().Inbox(gomock.Any(), int64(5), domain.FeedCursor{}, 3).Return([]domain.FeedItem{
+					{PostId: 30, Ts: 300}, {PostId: 20, Ts: 200},
+				}, nil)
+				m.pubRepo.EXPECT().FindByIds(gomock.Any(), []int64{30, 20}).Return([]domain.Post{
+					{Id: 20, AuthorId: 1, Ctime: 200},
+					{Id: 30, AuthorId: 9, Ctime: 300}, // 已取消关注
+				}, nil)
+				m.pubRepo.EXPECT().ListByAuthors(gomock.Any(), []int64{2}, domain.FeedCursor{}, 3).Return([]domain.Post{
+					{Id: 25, AuthorId: 2, Ctime: 250},
+				}, nil)
+			},
+			wantIds:  []int64{25, 20},
+			wantNext: domain.FeedCursor{Ts: 200, PostId: 20},
+		},
+		{
+			name:  "收件箱取满-只返回不早于其最后一条的帖子",
+			limit: 2,
+			mock: func(m feedMocks) {
+				m.followRepo.EXPECT().FindFolloweeIds(gomock.Any(), int64(5), maxFeedFollowees).Return([]int64{1, 2}, nil)
+				m.followRepo.EXPECT().FollowerCnts(gomock.Any(), []int64{1, 2}).Return(map[int64]int64{2: 500}, nil)
+				m.cache.EXPECT().Inbox(gomock.Any(), int64(5), domain.FeedCursor{}, 2).Return([]domain.FeedItem{
+					{PostId: 40, Ts: 400}, {PostId: 30, Ts: 300},
+				}, nil)
+				m.pubRepo.EXPECT().FindByIds(gomock.Any(), []int64{40, 30}).Return([]domain.Post{
+					{Id: 30, AuthorId: 1, Ctime: 300},
+				}, nil)
+				m.pubRepo.EXPECT().ListByAuthors(gomock.Any(), []int64{2}, domain.FeedCursor{}, 2).Return([]domain.Post{
+					{Id: 25, AuthorId: 2, Ctime: 250},
+				}, nil)
+			},
+			// 40 已下线；25 比收件箱最后一条更早，留到下一页
+			wantIds:  []int64{30},
+			wantNext: domain.FeedCursor{Ts: 300, PostId: 30},
+			wantMore: true,
+		},
+		{
+			name:   "同一毫秒发布的帖子-按ID跨页不跳过",
+			cursor: domain.FeedCursor{Ts: 300, PostId: 30},
+			limit:  2,
+			mock: func(m feedMocks) {
+				cursor := domain.FeedCursor{Ts: 300, PostId: 30}
+				m.followRepo.EXPECT().FindFolloweeIds(gomock.Any(), int64(5), maxFeedFollowees).Return([]int64{1, 2}, nil)
+				m.followRepo.EXPECT().FollowerCnts(gomock.Any(), []int64{1, 2}).Return(map[int64]int64{2: 500}, nil)
+				m.cache.EXPECT().Inbox(gomock.Any(), int64(5), cursor, 2).Return([]domain.FeedItem{
+					{PostId: 29, Ts: 300}, {PostId: 27, Ts: 250},
+				}, nil)
+				m.pubRepo.EXPECT().FindByIds(gomock.Any(), []int64{29, 27}).Return([]domain.Post{
+					{Id: 29, AuthorId: 1, Ctime: 300},
+					{Id: 27, AuthorId: 1, Ctime: 250},
+				}, nil)
+				m.pubRepo.EXPECT().ListByAuthors(gomock.Any(), []int64{2}, cursor, 2).Return([]domain.Post{
+					{Id: 28, AuthorId: 2, Ctime: 250}, {Id: 26, AuthorId: 2, Ctime: 250},
+				}, nil)
+			},
+			// 与上一页最后一条同一毫秒的 29 不会被跳过；27、26 与 28 同一毫秒，下一页按ID继续
+			wantIds:  []int64{29, 28},
+			wantNext: domain.FeedCursor{Ts: 250, PostId: 28},
+			wantMore: true,
+		},
+		{
+			name:  "没有关注任何人",
+			limit: 10,
+			mock: func(m feedMocks) {
+				m.followRepo.EXPECT().FindFolloweeIds(gomock.Any(), int64(5), maxFeedFollowees).Return([]int64{}, nil)
+			},
+			wantIds: []int64{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newFeedMocks(ctrl)
+			tt.mock(m)
+
+			page, err := m.feedService().List(context.Background(), 5, tt.cursor, tt.limit)
+			require.NoError(t, err)
+			ids := make([]int64, len(page.Posts))
+			for i, p := range page.Posts {
+				ids[i] = p.Id
+			}
+			assert.Equal(t, tt.wantIds, ids)
+			assert.Equal(t, tt.wantNext, page.NextCursor)
+			assert.Equal(t, tt.wantMore, page.HasMore)
+		})
+	}
+}
+
+func TestFollowService_Follow(t *testing.T) {
+	tests := []struct {
+		name     string
+		followee int64
+		mock     func(m feedMocks)
+		wantErr  error
+	}{
+		{
+			name:     "首次关注-补齐收件箱",
+			followee: 2,
+			mock: func(m feedMocks) {
+				m.userRepo.EXPECT().FindById(gomock.Any(), int64(2)).Return(domain.User{Id: 2}, nil)
+				m.followRepo.EXPECT().SetStatus(gomock.Any(), int64(1), int64(2), true).Return(true, nil)
+				m.followRepo.EXPECT().GetStats(gomock.Any(), int64(2)).Return(domain.FollowStats{UserId: 2, FollowerCnt: 1}, nil)
+				m.pubRepo.EXPECT().ListByAuthors(gomock.Any(), []int64{2}, domain.FeedCursor{}, 20).Return([]domain.Post{{Id: 8, AuthorId: 2, Ctime: 800}}, nil)
+				m.cache.EXPECT().Push(gomock.Any(), []int64{1}, domain.FeedItem{PostId: 8, Ts: 800}).Return(nil)
+			},
+		},
+		{
+			name:     "重复关注-不再补齐",
+			followee: 2,
+			mock: func(m feedMocks) {
+				m.userRepo.EXPECT().FindById(gomock.Any(), int64(2)).Return(domain.User{Id: 2}, nil)
+				m.followRepo.EXPECT().SetStatus(gomock.Any(), int64(1), int64(2), true).Return(false, nil)
+			},
+		},
+		{
+			name:     "关注自己",
+			followee: 1,
+			mock:     func(m feedMocks) {},
+			wantErr:  domain.ErrFollowSelf,
+		},
+		{
+			name:     "用户不存在",
+			followee: 3,
+			mock: func(m feedMocks) {
+				m.userRepo.EXPECT().FindById(gomock.Any(), int64(3)).Return(domain.User{}, domain.ErrUserNotFound)
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newFeedMocks(ctrl)
+			tt.mock(m)
+			svc := NewFollowService(m.followRepo, m.userRepo, m.feedService())
+
+			err := svc.Follow(context.Background(), 1, tt.followee)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package application
+
+import (
+	"context"
+	"webook/internal/domain"
+	input "webook/internal/ports/input"
+	output "webook/internal/ports/output"
+)
+
+type followService struct {
+	repo     output.FollowRepository
+	userRepo output.UserRepository
+	feed     input.FeedService
+}
+
+func NewFollowService(repo output.FollowRepository, userRepo output.UserRepository, feed input.FeedService) input.FollowService {
+	return &followService{
+		repo:     repo,
+		userRepo: userRepo,
+		feed:     feed,
+	}
+}
+
+func (s *followService) Follow(ctx context.Context, followerId, followeeId int64) error {
+	if followerId == followeeId {
+		return domain.ErrFollowSelf
+	}
+	if _, err := s.userRepo.FindById(ctx, followeeId); err != nil {
+		return err
+	}
+	changed, err := s.repo.SetStatus(ctx, followerId, followeeId, true)
+	if err != nil || !changed {
+		return err
+	}
+	// 补齐失败只影响关注之前的帖子，作者之后发的帖子照常推送
+	_ = s.feed.Backfill(ctx, followerId, followeeId)
+	return nil
+}
+
+func (s *followService) Unfollow(ctx context.Context, followerId, followeeId int64) error {
+	// 收件箱里该作者的帖子在读关注流时过滤
+	_, err := s.repo.SetStatus(ctx, followerId, followeeId, false)
+	return err
+}
+
+func (s *followService) GetStats(ctx context.Context, userId, viewerId int64) (domain.FollowStats, error) {
+	stats, err := s.repo.GetStats(ctx, userId)
+	if err != nil {
+		return domain.FollowStats{}, err
+	}
+	if viewerId > 0 && viewerId != userId {
+		stats.Followed, err = s.repo.IsFollowing(ctx, viewerId, userId)
+		if err != nil {
+			return domain.FollowStats{}, err
+		}
+	}
+	return stats, nil
+}
+
+func (s *followService) ListFollowers(ctx context.Context, userId, cursor int64, limit int) ([]domain.FollowRelation, error) {
+	return s.repo.ListFollowers(ctx, userId, cursor, limit)
+}
+
+func (s *followService) ListFollowees(ctx context.Context, userId, cursor int64, limit int) ([]domain.FollowRelation, error) {
+	return s.repo.ListFollowees(ctx, userId, cursor, limit)
+}
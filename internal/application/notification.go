@@ -0,0 +1,74 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"webook/internal/domain"
+	input "webook/internal/ports/input"
+	output "webook/internal/ports/output"
+)
+
+type notificationService struct {
+	repo    output.NotificationRepository
+	pubRepo output.PublishedPostRepository
+	broker  output.StreamBroker
+}
+
+func NewNotificationService(repo output.NotificationRepository, pubRepo output.PublishedPostRepository, broker output.StreamBroker) input.NotificationService {
+	return &notificationService{
+		repo:    repo,
+		pubRepo: pubRepo,
+		broker:  broker,
+	}
+}
+
+func (s *notificationService) Handle(ctx context.Context, event domain.PostStatsEvent) error {
+	kind, ok := domain.NotificationKindOf(event.Type)
+	if !ok || event.UserId == 0 {
+		return nil
+	}
+	post, err := s.pubRepo.FindById(ctx, event.PostId)
+	if errors.Is(err, domain.ErrPostNotFound) {
+		// 帖子已下线，不再通知
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if post.AuthorId == event.UserId {
+		// 作者自己的操作
+		return nil
+	}
+	n, recorded, err := s.repo.Record(ctx, event.EventId, domain.Notification{
+		RecipientId: post.AuthorId,
+		Kind:        kind,
+		PostId:      post.Id,
+	}, event.UserId)
+	if err != nil || !recorded {
+		return err
+	}
+	// 实时推送是尽力而为的，通知已落库，推送失败时客户端下次拉取列表即可看到
+	_ = s.broker.Publish(ctx, domain.StreamEvent{
+		Type:         domain.StreamEventNotification,
+		UserId:       n.RecipientId,
+		PostId:       n.PostId,
+		Notification: n,
+	})
+	return nil
+}
+
+func (s *notificationService) UnreadCount(ctx context.Context, userId int64) (int64, error) {
+	return s.repo.CountUnread(ctx, userId)
+}
+
+func (s *notificationService) List(ctx context.Context, userId, cursor int64, limit int, unreadOnly bool) ([]domain.Notification, error) {
+	return s.repo.FindByRecipient(ctx, userId, cursor, limit, unreadOnly)
+}
+
+func (s *notificationService) MarkRead(ctx context.Context, userId, id int64) error {
+	return s.repo.MarkRead(ctx, id, userId)
+}
+
+func (s *notificationService) MarkAllRead(ctx context.Context, userId int64) error {
+	return s.repo.MarkAllRead(ctx, userId)
+}
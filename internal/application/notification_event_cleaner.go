@@ -0,0 +1,58 @@
+package application
+
+import (
+	"context"
+	"time"
+	output "webook/internal/ports/output"
+	"webook/pkg/logger"
+)
+
+// NotificationEventCleaner purges old notification idempotency records. They only
+// have to outlive redeliveries of the same event, which the outbox and the MQ
+// retries finish within hours, so a week of retention is plenty.
+type NotificationEventCleaner struct {
+	repo      output.NotificationRepository
+	logger    logger.Logger
+	interval  time.Duration
+	retention time.Duration
+	batchSize int
+}
+
+func NewNotificationEventCleaner(repo output.NotificationRepository, l logger.Logger) *NotificationEventCleaner {
+	return &NotificationEventCleaner{
+		repo:      repo,
+		logger:    l,
+		interval:  time.Hour,
+		retention: 7 * 24 * time.Hour,
+		batchSize: 1000,
+	}
+}
+
+func (c *NotificationEventCleaner) Start(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.CleanOnce(ctx)
+		}
+	}
+}
+
+// CleanOnce deletes expired records in batches. Deleting is idempotent, so
+// several workers may run it at the same time without a lock.
+func (c *NotificationEventCleaner) CleanOnce(ctx context.Context) {
+	before := time.Now().Add(-c.retention).UnixMilli()
+	for {
+		n, err := c.repo.DeleteEventsBefore(ctx, before, c.batchSize)
+		if err != nil {
+			c.logger.Warn("notification event cleanup failed", logger.Error(err))
+			return
+		}
+		if n < int64(c.batchSize) {
+			return
+		}
+	}
+}
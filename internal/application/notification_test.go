@@ -0,0 +1,141 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	repomocks "webook/internal/adapters/outbound/mocks"
+	"webook/internal/domain"
+	"webook/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNotificationService_Handle(t *testing.T) {
+	errDBDown := errors.New("db down")
+	tests := []struct {
+		name    string
+		event   domain.PostStatsEvent
+		mock    func(repo *repomocks.MockNotificationRepository, pubRepo *repomocks.MockPublishedPostRepository, broker *repomocks.MockStreamBroker)
+		wantErr error
+	}{
+		{
+			name:  "点赞-通知帖子作者并实时推送",
+			event: domain.PostStatsEvent{EventId: "e1", Type: domain.PostStatsEventLike, PostId: 10, UserId: 3},
+			mock: func(repo *repomocks.MockNotificationRepository, pubRepo *repomocks.MockPublishedPostRepository, broker *repomocks.MockStreamBroker) {
+				pubRepo.EXPECT().FindById(gomock.Any(), int64(10)).Return(domain.Post{Id: 10, AuthorId: 1}, nil)
+				recorded := domain.Notification{Id: 7, RecipientId: 1, Kind: domain.NotificationLike, PostId: 10, LatestActorId: 3, ActorCnt: 2}
+				repo.EXPECT().
+					Record(gomock.Any(), "e1", domain.Notification{RecipientId: 1, Kind: domain.NotificationLike, PostId: 10}, int64(3)).
+					Return(recorded, true, nil)
+				broker.EXPECT().Publish(gomock.Any(), domain.StreamEvent{
+					Type:         domain.StreamEventNotification,
+					UserId:       1,
+					PostId:       10,
+					Notification: recorded,
+				}).Return(nil)
+			},
+		},
+		{
+			name:  "推送失败-不影响通知",
+			event: domain.PostStatsEvent{EventId: "e6", Type: domain.PostStatsEventComment, PostId: 10, UserId: 3},
+			mock: func(repo *repomocks.MockNotificationRepository, pubRepo *repomocks.MockPublishedPostRepository, broker *repomocks.MockStreamBroker) {
+				pubRepo.EXPECT().FindById(gomock.Any(), int64(10)).Return(domain.Post{Id: 10, AuthorId: 1}, nil)
+				repo.EXPECT().Record(gomock.Any(), "e6", gomock.Any(), int64(3)).
+					Return(domain.Notification{Id: 8, RecipientId: 1, PostId: 10}, true, nil)
+				broker.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(errDBDown)
+			},
+		},
+		{
+			name:  "重复投递-仓储按 EventId 去重",
+			event: domain.PostStatsEvent{EventId: "e1", Type: domain.PostStatsEventComment, PostId: 10, UserId: 3},
+			mock: func(repo *repomocks.MockNotificationRepository, pubRepo *repomocks.MockPublishedPostRepository, broker *repomocks.MockStreamBroker) {
+				pubRepo.EXPECT().FindById(gomock.Any(), int64(10)).Return(domain.Post{Id: 10, AuthorId: 1}, nil)
+				repo.EXPECT().Record(gomock.Any(), "e1", gomock.Any(), int64(3)).Return(domain.Notification{}, false, nil)
+			},
+		},
+		{
+			name:  "作者自己点赞-不通知",
+			event: domain.PostStatsEvent{EventId: "e2", Type: domain.PostStatsEventLike, PostId: 10, UserId: 1},
+			mock: func(repo *repomocks.MockNotificationRepository, pubRepo *repomocks.MockPublishedPostRepository, broker *repomocks.MockStreamBroker) {
+				pubRepo.EXPECT().FindById(gomock.Any(), int64(10)).Return(domain.Post{Id: 10, AuthorId: 1}, nil)
+			},
+		},
+		{
+			name:  "取消点赞与阅读-不产生通知",
+			event: domain.PostStatsEvent{EventId: "e3", Type: domain.PostStatsEventUnlike, PostId: 10, UserId: 3},
+			mock: func(repo *repomocks.MockNotificationRepository, pubRepo *repomocks.MockPublishedPostRepository, broker *repomocks.MockStreamBroker) {
+			},
+		},
+		{
+			name:  "帖子已下线-忽略",
+			event: domain.PostStatsEvent{EventId: "e4", Type: domain.PostStatsEventCollect, PostId: 11, UserId: 3},
+			mock: func(repo *repomocks.MockNotificationRepository, pubRepo *repomocks.MockPublishedPostRepository, broker *repomocks.MockStreamBroker) {
+				pubRepo.EXPECT().FindById(gomock.Any(), int64(11)).Return(domain.Post{}, domain.ErrPostNotFound)
+			},
+		},
+		{
+			name:  "写入失败-返回错误以便重试",
+			event: domain.PostStatsEvent{EventId: "e5", Type: domain.PostStatsEventCollect, PostId: 10, UserId: 3},
+			mock: func(repo *repomocks.MockNotificationRepository, pubRepo *repomocks.MockPublishedPostRepository, broker *repomocks.MockStreamBroker) {
+				pubRepo.EXPECT().FindById(gomock.Any(), int64(10)).Return(domain.Post{Id: 10, AuthorId: 1}, nil)
+				repo.EXPECT().Record(gomock.Any(), "e5", gomock.Any(), int64(3)).Return(domain.Notification{}, false, errDBDown)
+			},
+			wantErr: errDBDown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repomocks.NewMockNotificationRepository(ctrl)
+			pubRepo := repomocks.NewMockPublishedPostRepository(ctrl)
+			broker := repomocks.NewMockStreamBroker(ctrl)
+			tt.mock(repo, pubRepo, broker)
+			svc := NewNotificationService(repo, pubRepo, broker)
+
+			err := svc.Handle(context.Background(), tt.event)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNotificationEventCleaner_CleanOnce(t *testing.T) {
+	tests := []struct {
+		name string
+		mock func(repo *repomocks.MockNotificationRepository)
+	}{
+		{
+			name: "删满一批继续删",
+			mock: func(repo *repomocks.MockNotificationRepository) {
+				gomock.InOrder(
+					repo.EXPECT().DeleteEventsBefore(gomock.Any(), gomock.Any(), 1000).Return(int64(1000), nil),
+					repo.EXPECT().DeleteEventsBefore(gomock.Any(), gomock.Any(), 1000).Return(int64(3), nil),
+				)
+			},
+		},
+		{
+			name: "删除失败-停止本轮",
+			mock: func(repo *repomocks.MockNotificationRepository) {
+				repo.EXPECT().DeleteEventsBefore(gomock.Any(), gomock.Any(), 1000).Return(int64(0), errors.New("db down"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repomocks.NewMockNotificationRepository(ctrl)
+			tt.mock(repo)
+			NewNotificationEventCleaner(repo, logger.NewZapLogger("error", false)).CleanOnce(context.Background())
+		})
+	}
+}
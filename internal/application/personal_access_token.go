@@ -0,0 +1,122 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"webook/internal/domain"
+	input "webook/internal/ports/input"
+	output "webook/internal/ports/output"
+)
+
+const (
+	maxAccessTokenNameLen = 64
+	maxAccessTokenExpiry  = 365 * 24 * time.Hour
+	// 使用时间精确到分钟即可，避免脚本密集调用时每个请求都写一次库
+	accessTokenTouchInterval = time.Minute
+)
+
+type personalAccessTokenService struct {
+	repo output.PersonalAccessTokenRepository
+}
+
+func NewPersonalAccessTokenService(repo output.PersonalAccessTokenRepository) input.PersonalAccessTokenService {
+	return &personalAccessTokenService{repo: repo}
+}
+
+func (s *personalAccessTokenService) Create(ctx context.Context, userId int64, name string, scopes []domain.TokenScope,
+	expiresIn time.Duration) (domain.PersonalAccessToken, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxAccessTokenNameLen {
+		return domain.PersonalAccessToken{}, "", domain.ErrInvalidAccessToken
+	}
+	if expiresIn < 0 || expiresIn > maxAccessTokenExpiry {
+		return domain.PersonalAccessToken{}, "", domain.ErrInvalidAccessToken
+	}
+	scopes, ok := normalizeScopes(scopes)
+	if !ok {
+		return domain.PersonalAccessToken{}, "", domain.ErrInvalidAccessToken
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return domain.PersonalAccessToken{}, "", err
+	}
+	token := domain.PersonalAccessTokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	t := domain.PersonalAccessToken{
+		UserId: userId,
+		Name:   name,
+		Hash:   hashAccessToken(token),
+		Hint:   token[len(token)-4:],
+		Scopes: scopes,
+	}
+	if expiresIn > 0 {
+		t.ExpiresAt = time.Now().Add(expiresIn).UnixMilli()
+	}
+	t, err := s.repo.Create(ctx, t)
+	if err != nil {
+		return domain.PersonalAccessToken{}, "", err
+	}
+	return t, token, nil
+}
+
+func (s *personalAccessTokenService) List(ctx context.Context, userId int64) ([]domain.PersonalAccessToken, error) {
+	return s.repo.FindByUser(ctx, userId)
+}
+
+func (s *personalAccessTokenService) Revoke(ctx context.Context, userId, id int64) error {
+	return s.repo.Delete(ctx, userId, id)
+}
+
+func (s *personalAccessTokenService) Authenticate(ctx context.Context, token string) (domain.PersonalAccessToken, error) {
+	if !strings.HasPrefix(token, domain.PersonalAccessTokenPrefix) {
+		return domain.PersonalAccessToken{}, domain.ErrUnauthorized
+	}
+	t, err := s.repo.FindByHash(ctx, hashAccessToken(token))
+	if errors.Is(err, domain.ErrAccessTokenNotFound) {
+		return domain.PersonalAccessToken{}, domain.ErrUnauthorized
+	}
+	if err != nil {
+		return domain.PersonalAccessToken{}, err
+	}
+	now := time.Now()
+	if t.Expired(now.UnixMilli()) {
+		return domain.PersonalAccessToken{}, domain.ErrUnauthorized
+	}
+	if now.Sub(time.UnixMilli(t.LastUsed)) >= accessTokenTouchInterval {
+		// 使用时间只用于展示，写入失败不影响本次请求
+		_ = s.repo.UpdateLastUsed(ctx, t.Id, now.UnixMilli())
+		t.LastUsed = now.UnixMilli()
+	}
+	return t, nil
+}
+
+// normalizeScopes 去重并校验权限范围，至少需要一个
+func normalizeScopes(scopes []domain.TokenScope) ([]domain.TokenScope, bool) {
+	result := make([]domain.TokenScope, 0, len(scopes))
+	seen := make(map[domain.TokenScope]struct{}, len(scopes))
+	for _, scope := range scopes {
+		if !domain.ValidTokenScope(scope) {
+			return nil, false
+		}
+		if _, ok := seen[scope]; ok {
+			continue
+		}
+		seen[scope] = struct{}{}
+		result = append(result, scope)
+	}
+	return result, len(result) > 0
+}
+
+// hashAccessToken 令牌本身是 256 位随机数，无需加盐或慢哈希，SHA-256 即可按哈希直接查找
+func hashAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,183 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	repomocks "webook/internal/adapters/outbound/mocks"
+	"webook/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPersonalAccessTokenService_Create(t *testing.T) {
+	tests := []struct {
+		name      string
+		tokenName string
+		scopes    []domain.TokenScope
+		expiresIn time.Duration
+		mock      func(repo *repomocks.MockPersonalAccessTokenRepository)
+		wantErr   error
+	}{
+		{
+			name:      "创建成功-去重权限范围并保存哈希",
+			tokenName: "  批量发布脚本 ",
+			scopes:    []domain.TokenScope{domain.ScopePostsWrite, domain.ScopeStatsRead, domain.ScopePostsWrite},
+			expiresIn: 30 * 24 * time.Hour,
+			mock: func(repo *repomocks.MockPersonalAccessTokenRepository) {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, pat domain.PersonalAccessToken) (domain.PersonalAccessToken, error) {
+						assert.Equal(t, "批量发布脚本", pat.Name)
+						assert.Equal(t, []domain.TokenScope{domain.ScopePostsWrite, domain.ScopeStatsRead}, pat.Scopes)
+						assert.Len(t, pat.Hash, 64)
+						assert.Len(t, pat.Hint, 4)
+						assert.InDelta(t, time.Now().Add(30*24*time.Hour).UnixMilli(), pat.ExpiresAt, 1000)
+						pat.Id = 1
+						return pat, nil
+					})
+			},
+		},
+		{
+			name:      "永不过期",
+			tokenName: "ci",
+			scopes:    []domain.TokenScope{domain.ScopeStatsRead},
+			mock: func(repo *repomocks.MockPersonalAccessTokenRepository) {
+				repo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, pat domain.PersonalAccessToken) (domain.PersonalAccessToken, error) {
+						assert.Zero(t, pat.ExpiresAt)
+						return pat, nil
+					})
+			},
+		},
+		{
+			name:      "未知的权限范围",
+			tokenName: "ci",
+			scopes:    []domain.TokenScope{"admin"},
+			wantErr:   domain.ErrInvalidAccessToken,
+		},
+		{
+			name:      "没有权限范围",
+			tokenName: "ci",
+			wantErr:   domain.ErrInvalidAccessToken,
+		},
+		{
+			name:      "名称为空",
+			tokenName: "  ",
+			scopes:    []domain.TokenScope{domain.ScopeStatsRead},
+			wantErr:   domain.ErrInvalidAccessToken,
+		},
+		{
+			name:      "有效期超过一年",
+			tokenName: "ci",
+			scopes:    []domain.TokenScope{domain.ScopeStatsRead},
+			expiresIn: 366 * 24 * time.Hour,
+			wantErr:   domain.ErrInvalidAccessToken,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo := repomocks.NewMockPersonalAccessTokenRepository(ctrl)
+			if tc.mock != nil {
+				tc.mock(repo)
+			}
+
+			pat, token, err := NewPersonalAccessTokenService(repo).Create(context.Background(), 1, tc.tokenName, tc.scopes, tc.expiresIn)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(token, domain.PersonalAccessTokenPrefix))
+			assert.Equal(t, hashAccessToken(token), pat.Hash)
+			assert.True(t, strings.HasSuffix(token, pat.Hint))
+		})
+	}
+}
+
+func TestPersonalAccessTokenService_Authenticate(t *testing.T) {
+	const token = domain.PersonalAccessTokenPrefix + "secret"
+	now := time.Now().UnixMilli()
+
+	tests := []struct {
+		name    string
+		token   string
+		mock    func(repo *repomocks.MockPersonalAccessTokenRepository)
+		wantErr error
+	}{
+		{
+			name:  "有效令牌-记录使用时间",
+			token: token,
+			mock: func(repo *repomocks.MockPersonalAccessTokenRepository) {
+				repo.EXPECT().FindByHash(gomock.Any(), hashAccessToken(token)).
+					Return(domain.PersonalAccessToken{Id: 1, UserId: 2, LastUsed: now - time.Hour.Milliseconds()}, nil)
+				repo.EXPECT().UpdateLastUsed(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:  "一分钟内使用过-不重复记录",
+			token: token,
+			mock: func(repo *repomocks.MockPersonalAccessTokenRepository) {
+				repo.EXPECT().FindByHash(gomock.Any(), hashAccessToken(token)).
+					Return(domain.PersonalAccessToken{Id: 1, UserId: 2, LastUsed: now}, nil)
+			},
+		},
+		{
+			name:  "记录使用时间失败-不影响认证",
+			token: token,
+			mock: func(repo *repomocks.MockPersonalAccessTokenRepository) {
+				repo.EXPECT().FindByHash(gomock.Any(), hashAccessToken(token)).
+					Return(domain.PersonalAccessToken{Id: 1, UserId: 2}, nil)
+				repo.EXPECT().UpdateLastUsed(gomock.Any(), int64(1), gomock.Any()).Return(errors.New("db down"))
+			},
+		},
+		{
+			name:  "已过期",
+			token: token,
+			mock: func(repo *repomocks.MockPersonalAccessTokenRepository) {
+				repo.EXPECT().FindByHash(gomock.Any(), hashAccessToken(token)).
+					Return(domain.PersonalAccessToken{Id: 1, UserId: 2, ExpiresAt: now - 1}, nil)
+			},
+			wantErr: domain.ErrUnauthorized,
+		},
+		{
+			name:  "不存在或已撤销",
+			token: token,
+			mock: func(repo *repomocks.MockPersonalAccessTokenRepository) {
+				repo.EXPECT().FindByHash(gomock.Any(), hashAccessToken(token)).
+					Return(domain.PersonalAccessToken{}, domain.ErrAccessTokenNotFound)
+			},
+			wantErr: domain.ErrUnauthorized,
+		},
+		{
+			name:    "缺少前缀",
+			token:   "secret",
+			wantErr: domain.ErrUnauthorized,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo := repomocks.NewMockPersonalAccessTokenRepository(ctrl)
+			if tc.mock != nil {
+				tc.mock(repo)
+			}
+
+			pat, err := NewPersonalAccessTokenService(repo).Authenticate(context.Background(), tc.token)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), pat.UserId)
+		})
+	}
+}
@@ -2,20 +2,23 @@ package application
 
 import (
 	"context"
+	"time"
 	"webook/internal/domain"
 	input "webook/internal/ports/input"
 	output "webook/internal/ports/output"
 )
 
 type postService struct {
-	repo    output.PostRepository
-	pubRepo output.PublishedPostRepository
+	repo     output.PostRepository
+	pubRepo  output.PublishedPostRepository
+	userRepo output.UserRepository
 }
 
-func NewPostService(repo output.PostRepository, pubRepo output.PublishedPostRepository) input.PostService {
+func NewPostService(repo output.PostRepository, pubRepo output.PublishedPostRepository, userRepo output.UserRepository) input.PostService {
 	return &postService{
-		repo:    repo,
-		pubRepo: pubRepo,
+		repo:     repo,
+		pubRepo:  pubRepo,
+		userRepo: userRepo,
 	}
 }
 
@@ -27,6 +30,9 @@ func (s *postService) Save(ctx context.Context, p domain.Post) (int64, error) {
 }
 
 func (s *postService) Publish(ctx context.Context, p domain.Post) (int64, error) {
+	if err := s.checkCanPublish(ctx, p.AuthorId); err != nil {
+		return 0, err
+	}
 	p.Status = domain.PostStatusPublished
 	return s.repo.Sync(ctx, p)
 }
@@ -35,6 +41,44 @@ func (s *postService) Delete(ctx context.Context, id int64, authorId int64) erro
 	return s.repo.SyncStatus(ctx, id, authorId, domain.PostStatusPrivate)
 }
 
+// Schedule 设置定时发布，只有草稿可以设置，到点后由 PostPublishScheduler 发布
+func (s *postService) Schedule(ctx context.Context, id int64, authorId int64, scheduledAt int64) error {
+	if scheduledAt <= time.Now().UnixMilli() {
+		return domain.ErrInvalidScheduleTime
+	}
+	if err := s.checkCanPublish(ctx, authorId); err != nil {
+		return err
+	}
+	return s.repo.Schedule(ctx, id, authorId, scheduledAt)
+}
+
+// checkCanPublish 只有账号正常且验证过邮箱的用户才能发布帖子；
+// 停用或封禁的用户在 access token 过期前也不能发布
+func (s *postService) checkCanPublish(ctx context.Context, authorId int64) error {
+	u, err := s.userRepo.FindById(ctx, authorId)
+	if err != nil {
+		return err
+	}
+	if err := u.Status.Err(); err != nil {
+		return err
+	}
+	if !u.EmailVerified {
+		return domain.ErrEmailNotVerified
+	}
+	return nil
+}
+
+func (s *postService) Reschedule(ctx context.Context, id int64, authorId int64, scheduledAt int64) error {
+	if scheduledAt <= time.Now().UnixMilli() {
+		return domain.ErrInvalidScheduleTime
+	}
+	return s.repo.Reschedule(ctx, id, authorId, scheduledAt)
+}
+
+func (s *postService) CancelSchedule(ctx context.Context, id int64, authorId int64) error {
+	return s.repo.CancelSchedule(ctx, id, authorId)
+}
+
 func (s *postService) GetById(ctx context.Context, id int64) (domain.Post, error) {
 	return s.repo.FindById(ctx, id)
 }
@@ -68,3 +112,42 @@ func (s *postService) ListPublished(ctx context.Context, page, pageSize int) ([]
 	}
 	return posts, total, nil
 }
+
+func (s *postService) ListPublishedByTag(ctx context.Context, tag string, page, pageSize int) ([]domain.Post, int64, error) {
+	offset := (page - 1) * pageSize
+	posts, err := s.pubRepo.ListByTag(ctx, tag, offset, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.pubRepo.CountByTag(ctx, tag)
+	if err != nil {
+		return nil, 0, err
+	}
+	return posts, total, nil
+}
+
+func (s *postService) ListLikedBy(ctx context.Context, uid int64, page, pageSize int) ([]domain.Post, int64, error) {
+	offset := (page - 1) * pageSize
+	posts, err := s.pubRepo.ListLikedBy(ctx, uid, offset, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.pubRepo.CountLikedBy(ctx, uid)
+	if err != nil {
+		return nil, 0, err
+	}
+	return posts, total, nil
+}
+
+func (s *postService) ListCollectedBy(ctx context.Context, uid int64, page, pageSize int) ([]domain.Post, int64, error) {
+	offset := (page - 1) * pageSize
+	posts, err := s.pubRepo.ListCollectedBy(ctx, uid, offset, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.pubRepo.CountCollectedBy(ctx, uid)
+	if err != nil {
+		return nil, 0, err
+	}
+	return posts, total, nil
+}
@@ -0,0 +1,75 @@
+package application
+
+import (
+	"context"
+	"webook/internal/domain"
+	input "webook/internal/ports/input"
+	output "webook/internal/ports/output"
+)
+
+type postAnalyticsService struct {
+	repo      output.PostRepository
+	dailyRepo output.PostDailyStatsRepository
+}
+
+func NewPostAnalyticsService(repo output.PostRepository, dailyRepo output.PostDailyStatsRepository) input.PostAnalyticsService {
+	return &postAnalyticsService{
+		repo:      repo,
+		dailyRepo: dailyRepo,
+	}
+}
+
+func (s *postAnalyticsService) GetDaily(ctx context.Context, postId, uid int64, r domain.StatsRange) ([]domain.PostDailyStats, error) {
+	p, err := s.repo.FindById(ctx, postId)
+	if err != nil {
+		return nil, err
+	}
+	if p.AuthorId != uid {
+		return nil, domain.ErrPostNotAuthor
+	}
+	rows, err := s.dailyRepo.FindByPost(ctx, postId, r.From, r.To)
+	if err != nil {
+		return nil, err
+	}
+	return fillDays(r, postId, rows), nil
+}
+
+func (s *postAnalyticsService) GetDashboard(ctx context.Context, authorId int64, r domain.StatsRange) (domain.AuthorDashboard, error) {
+	perDay, err := s.dailyRepo.SumByAuthorPerDay(ctx, authorId, r.From, r.To)
+	if err != nil {
+		return domain.AuthorDashboard{}, err
+	}
+	perPost, err := s.dailyRepo.SumByAuthorPerPost(ctx, authorId, r.From, r.To)
+	if err != nil {
+		return domain.AuthorDashboard{}, err
+	}
+
+	var total domain.PostDailyStats
+	for _, d := range perDay {
+		total = total.Add(d)
+	}
+	return domain.AuthorDashboard{
+		From:  r.From,
+		To:    r.To,
+		Total: total,
+		Days:  fillDays(r, 0, perDay),
+		Posts: perPost,
+	}, nil
+}
+
+// fillDays returns one entry per day of the range, with zero counters for days without rows.
+func fillDays(r domain.StatsRange, postId int64, rows []domain.PostDailyStats) []domain.PostDailyStats {
+	byDate := make(map[string]domain.PostDailyStats, len(rows))
+	for _, row := range rows {
+		byDate[row.Date] = row
+	}
+	days := r.Days()
+	result := make([]domain.PostDailyStats, len(days))
+	for i, day := range days {
+		row := byDate[day]
+		row.PostId = postId
+		row.Date = day
+		result[i] = row
+	}
+	return result
+}
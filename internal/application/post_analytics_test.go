@@ -0,0 +1,121 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+	repomocks "webook/internal/adapters/outbound/mocks"
+	"webook/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPostAnalyticsService_GetDaily(t *testing.T) {
+	r := domain.StatsRange{From: "2026-02-27", To: "2026-03-02"}
+	tests := []struct {
+		name    string
+		uid     int64
+		mock    func(repo *repomocks.MockPostRepository, dailyRepo *repomocks.MockPostDailyStatsRepository)
+		want    []domain.PostDailyStats
+		wantErr error
+	}{
+		{
+			name: "没有数据的日期补0",
+			uid:  1,
+			mock: func(repo *repomocks.MockPostRepository, dailyRepo *repomocks.MockPostDailyStatsRepository) {
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).Return(domain.Post{Id: 10, AuthorId: 1}, nil)
+				dailyRepo.EXPECT().FindByPost(gomock.Any(), int64(10), "2026-02-27", "2026-03-02").Return([]domain.PostDailyStats{
+					{PostId: 10, Date: "2026-02-28", ReadCnt: 5, LikeCnt: 2, UnlikeCnt: 1},
+				}, nil)
+			},
+			want: []domain.PostDailyStats{
+				{PostId: 10, Date: "2026-02-27"},
+				{PostId: 10, Date: "2026-02-28", ReadCnt: 5, LikeCnt: 2, UnlikeCnt: 1},
+				{PostId: 10, Date: "2026-03-01"},
+				{PostId: 10, Date: "2026-03-02"},
+			},
+		},
+		{
+			name: "非作者无权查看",
+			uid:  2,
+			mock: func(repo *repomocks.MockPostRepository, dailyRepo *repomocks.MockPostDailyStatsRepository) {
+				repo.EXPECT().FindById(gomock.Any(), int64(10)).Return(domain.Post{Id: 10, AuthorId: 1}, nil)
+			},
+			wantErr: domain.ErrPostNotAuthor,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repomocks.NewMockPostRepository(ctrl)
+			dailyRepo := repomocks.NewMockPostDailyStatsRepository(ctrl)
+			tt.mock(repo, dailyRepo)
+
+			svc := NewPostAnalyticsService(repo, dailyRepo)
+			got, err := svc.GetDaily(context.Background(), 10, tt.uid, r)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPostAnalyticsService_GetDashboard(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	r := domain.StatsRange{From: "2026-03-01", To: "2026-03-02"}
+	dailyRepo := repomocks.NewMockPostDailyStatsRepository(ctrl)
+	dailyRepo.EXPECT().SumByAuthorPerDay(gomock.Any(), int64(1), r.From, r.To).Return([]domain.PostDailyStats{
+		{Date: "2026-03-01", ReadCnt: 10, LikeCnt: 3, UniqueReadCnt: 6},
+		{Date: "2026-03-02", ReadCnt: 4, CollectCnt: 1},
+	}, nil)
+	perPost := []domain.AuthorPostStats{
+		{PostId: 11, Title: "a", Stats: domain.PostDailyStats{PostId: 11, ReadCnt: 12, LikeCnt: 3}},
+		{PostId: 12, Title: "b", Stats: domain.PostDailyStats{PostId: 12, ReadCnt: 2, CollectCnt: 1}},
+	}
+	dailyRepo.EXPECT().SumByAuthorPerPost(gomock.Any(), int64(1), r.From, r.To).Return(perPost, nil)
+
+	svc := NewPostAnalyticsService(repomocks.NewMockPostRepository(ctrl), dailyRepo)
+	dash, err := svc.GetDashboard(context.Background(), 1, r)
+	require.NoError(t, err)
+	// 独立读者数跨天不可相加，合计里不带
+	assert.Equal(t, domain.PostDailyStats{ReadCnt: 14, LikeCnt: 3, CollectCnt: 1}, dash.Total)
+	assert.Len(t, dash.Days, 2)
+	assert.Equal(t, perPost, dash.Posts)
+}
+
+func TestNewStatsRange(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		name     string
+		from, to string
+		want     domain.StatsRange
+		wantErr  error
+	}{
+		{name: "默认最近30天", want: domain.StatsRange{From: "2026-09-18", To: "2026-10-17"}},
+		{name: "指定区间", from: "2026-01-01", to: "2026-01-31", want: domain.StatsRange{From: "2026-01-01", To: "2026-01-31"}},
+		{name: "起始晚于结束", from: "2026-02-01", to: "2026-01-31", wantErr: domain.ErrInvalidStatsRange},
+		{name: "格式错误", from: "2026/01/01", wantErr: domain.ErrInvalidStatsRange},
+		{name: "区间过长", from: "2024-01-01", to: "2026-01-01", wantErr: domain.ErrInvalidStatsRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.NewStatsRange(tt.from, tt.to, now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package application
+
+import (
+	"context"
+	"time"
+	"webook/internal/domain"
+	output "webook/internal/ports/output"
+	"webook/pkg/logger"
+)
+
+// PostPublishScheduler periodically publishes scheduled drafts whose time has come.
+// Only one instance runs a tick at a time thanks to the Redis lock.
+type PostPublishScheduler struct {
+	repo      output.PostRepository
+	cache     output.PostCache
+	logger    logger.Logger
+	interval  time.Duration
+	batchSize int
+	lockTTL   time.Duration
+}
+
+func NewPostPublishScheduler(repo output.PostRepository, cache output.PostCache, l logger.Logger) *PostPublishScheduler {
+	return &PostPublishScheduler{
+		repo:      repo,
+		cache:     cache,
+		logger:    l,
+		interval:  10 * time.Second,
+		batchSize: 100,
+		lockTTL:   9 * time.Second,
+	}
+}
+
+func (s *PostPublishScheduler) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.RunOnce(ctx)
+		}
+	}
+}
+
+func (s *PostPublishScheduler) RunOnce(ctx context.Context) {
+	locked, err := s.cache.TryLock(ctx, "post:schedule:publish:lock", s.lockTTL)
+	if err != nil || !locked {
+		return
+	}
+
+	for {
+		now := time.Now().UnixMilli()
+		posts, err := s.repo.FindDueScheduled(ctx, now, s.batchSize)
+		if err != nil {
+			s.logger.Warn("post schedule find due failed", logger.Error(err))
+			return
+		}
+		if len(posts) == 0 {
+			return
+		}
+
+		handled := 0
+		for _, p := range posts {
+			// 按 ID 条件发布，查询之后被取消、改期或删除的帖子不会被发布
+			_, err := s.repo.PublishScheduled(ctx, p.Id, now)
+			switch err {
+			case nil, domain.ErrPostNotScheduled:
+				handled++
+			default:
+				s.logger.Error("post schedule publish failed",
+					logger.Int64("postId", p.Id),
+					logger.Error(err))
+			}
+		}
+		// 本批次全部失败时直接退出，避免在同一批帖子上空转
+		if handled == 0 || len(posts) < s.batchSize {
+			return
+		}
+	}
+}
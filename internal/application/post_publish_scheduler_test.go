@@ -0,0 +1,90 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	repomocks "webook/internal/adapters/outbound/mocks"
+	"webook/internal/domain"
+	"webook/pkg/logger"
+
+	"go.uber.org/mock/gomock"
+)
+
+func TestPostPublishScheduler_RunOnce(t *testing.T) {
+	tests := []struct {
+		name      string
+		batchSize int
+		mock      func(repo *repomocks.MockPostRepository)
+	}{
+		{
+			name:      "到点的帖子逐个按条件发布",
+			batchSize: 100,
+			mock: func(repo *repomocks.MockPostRepository) {
+				repo.EXPECT().FindDueScheduled(gomock.Any(), gomock.Any(), 100).
+					Return([]domain.Post{{Id: 1}, {Id: 2}}, nil)
+				repo.EXPECT().PublishScheduled(gomock.Any(), int64(1), gomock.Any()).Return(domain.Post{Id: 1}, nil)
+				repo.EXPECT().PublishScheduled(gomock.Any(), int64(2), gomock.Any()).Return(domain.Post{Id: 2}, nil)
+			},
+		},
+		{
+			name:      "查询后被取消的帖子跳过-继续下一批",
+			batchSize: 2,
+			mock: func(repo *repomocks.MockPostRepository) {
+				gomock.InOrder(
+					repo.EXPECT().FindDueScheduled(gomock.Any(), gomock.Any(), 2).
+						Return([]domain.Post{{Id: 1}, {Id: 2}}, nil),
+					repo.EXPECT().FindDueScheduled(gomock.Any(), gomock.Any(), 2).
+						Return([]domain.Post{}, nil),
+				)
+				repo.EXPECT().PublishScheduled(gomock.Any(), int64(1), gomock.Any()).Return(domain.Post{}, domain.ErrPostNotScheduled)
+				repo.EXPECT().PublishScheduled(gomock.Any(), int64(2), gomock.Any()).Return(domain.Post{Id: 2}, nil)
+			},
+		},
+		{
+			name:      "整批发布失败-停止本轮",
+			batchSize: 2,
+			mock: func(repo *repomocks.MockPostRepository) {
+				repo.EXPECT().FindDueScheduled(gomock.Any(), gomock.Any(), 2).
+					Return([]domain.Post{{Id: 1}, {Id: 2}}, nil)
+				repo.EXPECT().PublishScheduled(gomock.Any(), gomock.Any(), gomock.Any()).
+					Times(2).Return(domain.Post{}, errors.New("db error"))
+			},
+		},
+		{
+			name:      "查询失败",
+			batchSize: 100,
+			mock: func(repo *repomocks.MockPostRepository) {
+				repo.EXPECT().FindDueScheduled(gomock.Any(), gomock.Any(), 100).Return(nil, errors.New("db error"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repomocks.NewMockPostRepository(ctrl)
+			cache := repomocks.NewMockPostCache(ctrl)
+			cache.EXPECT().TryLock(gomock.Any(), "post:schedule:publish:lock", gomock.Any()).Return(true, nil)
+			tt.mock(repo)
+
+			s := NewPostPublishScheduler(repo, cache, logger.NewZapLogger("error", false))
+			s.batchSize = tt.batchSize
+			s.RunOnce(context.Background())
+		})
+	}
+}
+
+func TestPostPublishScheduler_RunOnce_NotLocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// 其他实例持有锁时不查询也不发布
+	repo := repomocks.NewMockPostRepository(ctrl)
+	cache := repomocks.NewMockPostCache(ctrl)
+	cache.EXPECT().TryLock(gomock.Any(), "post:schedule:publish:lock", gomock.Any()).Return(false, nil)
+
+	NewPostPublishScheduler(repo, cache, logger.NewZapLogger("error", false)).RunOnce(context.Background())
+}
@@ -0,0 +1,233 @@
+package application
+
+import (
+	"context"
+	"math"
+	"time"
+	"webook/internal/domain"
+	input "webook/internal/ports/input"
+	output "webook/internal/ports/output"
+	"webook/pkg/logger"
+)
+
+// weeklyHalfLifeDays is how many days it takes for an interaction to count half
+// as much in the weekly ranking.
+const weeklyHalfLifeDays = 2
+
+type postRankService struct {
+	rank    output.PostRankCache
+	pubRepo output.PublishedPostRepository
+}
+
+func NewPostRankService(rank output.PostRankCache, pubRepo output.PublishedPostRepository) input.PostRankService {
+	return &postRankService{
+		rank:    rank,
+		pubRepo: pubRepo,
+	}
+}
+
+func (s *postRankService) ListHot(ctx context.Context, window domain.HotWindow, page, pageSize int) ([]domain.HotPost, int64, error) {
+	if !window.Valid() {
+		window = domain.HotWindowDaily
+	}
+	scores, total, err := s.rank.Top(ctx, window, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(scores) == 0 {
+		return []domain.HotPost{}, total, nil
+	}
+
+	ids := make([]int64, len(scores))
+	for i, sc := range scores {
+		ids[i] = sc.PostId
+	}
+	posts, err := s.pubRepo.FindByIds(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	byId := make(map[int64]domain.Post, len(posts))
+	for _, p := range posts {
+		byId[p.Id] = p
+	}
+
+	result := make([]domain.HotPost, 0, len(scores))
+	var offline []int64
+	for _, sc := range scores {
+		// Hidden or deleted since the window was last refreshed.
+		p, ok := byId[sc.PostId]
+		if !ok {
+			offline = append(offline, sc.PostId)
+			continue
+		}
+		result = append(result, domain.HotPost{Post: p, Score: sc.Score})
+	}
+	if len(offline) > 0 {
+		// Drop them from the ranking so later pages and totals are right. Only
+		// this page comes back short; the total already excludes them.
+		_ = s.rank.Remove(ctx, offline...)
+		total -= int64(len(offline))
+	}
+	return result, total, nil
+}
+
+// PostHotRankSchedule configures the hot ranking job.
+type PostHotRankSchedule struct {
+	RefreshInterval time.Duration // how often the windows are re-materialised
+	RebuildInterval time.Duration // how often the all-time scores are recomputed from post_stats
+	TopN            int           // posts kept per window
+}
+
+// PostHotRanker materialises the hot ranking windows from the score buckets the
+// stats consumer maintains, and periodically recomputes the all-time scores from
+// post_stats to repair drift (lost events, Redis restarts, weight changes).
+type PostHotRanker struct {
+	statsRepo  output.PostStatsRepository
+	pubRepo    output.PublishedPostRepository
+	rank       output.PostRankCache
+	statsCache output.PostStatsCache
+	logger     logger.Logger
+	schedule   PostHotRankSchedule
+	batchSize  int64
+	rebuildTTL time.Duration
+}
+
+func NewPostHotRanker(
+	statsRepo output.PostStatsRepository,
+	pubRepo output.PublishedPostRepository,
+	rank output.PostRankCache,
+	statsCache output.PostStatsCache,
+	l logger.Logger,
+	schedule PostHotRankSchedule,
+) *PostHotRanker {
+	return &PostHotRanker{
+		statsRepo:  statsRepo,
+		pubRepo:    pubRepo,
+		rank:       rank,
+		statsCache: statsCache,
+		logger:     l,
+		schedule:   schedule,
+		batchSize:  1000,
+		rebuildTTL: 10 * time.Minute,
+	}
+}
+
+func (r *PostHotRanker) Start(ctx context.Context) {
+	r.RebuildOnce(ctx)
+	refresh := time.NewTicker(r.schedule.RefreshInterval)
+	defer refresh.Stop()
+	rebuild := time.NewTicker(r.schedule.RebuildInterval)
+	defer rebuild.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-refresh.C:
+			r.RefreshOnce(ctx)
+		case <-rebuild.C:
+			r.RebuildOnce(ctx)
+		}
+	}
+}
+
+// RefreshOnce re-materialises every window.
+func (r *PostHotRanker) RefreshOnce(ctx context.Context) {
+	locked, err := r.statsCache.TryLock(ctx, "post:hot:refresh:lock", r.schedule.RefreshInterval*9/10)
+	if err != nil || !locked {
+		return
+	}
+	r.refresh(ctx, time.Now())
+}
+
+func (r *PostHotRanker) refresh(ctx context.Context, now time.Time) {
+	for _, w := range domain.HotWindows {
+		if err := r.rank.RefreshWindow(ctx, w, now, hotDayWeights(w, now), r.schedule.TopN); err != nil {
+			r.logger.Warn("post hot rank refresh failed", logger.String("window", string(w)), logger.Error(err))
+		}
+	}
+}
+
+// RebuildOnce recomputes the all-time scores from post_stats and refreshes the windows.
+// Events consumed but not yet flushed to MySQL are dropped from the all-time set
+// until the next rebuild; the day buckets keep them.
+func (r *PostHotRanker) RebuildOnce(ctx context.Context) {
+	locked, err := r.statsCache.TryLock(ctx, "post:hot:rebuild:lock", r.rebuildTTL)
+	if err != nil || !locked {
+		return
+	}
+
+	maxId, err := r.statsRepo.MaxPostId(ctx)
+	if err != nil {
+		r.logger.Error("post hot rank rebuild failed", logger.Error(err))
+		return
+	}
+	var scores []domain.PostScore
+	for from := int64(1); from <= maxId; from += r.batchSize {
+		stats, err := r.statsRepo.FindByPostRange(ctx, from, from+r.batchSize)
+		if err != nil {
+			r.logger.Error("post hot rank rebuild failed", logger.Error(err))
+			return
+		}
+		batch, err := r.publishedScores(ctx, stats)
+		if err != nil {
+			r.logger.Error("post hot rank rebuild failed", logger.Error(err))
+			return
+		}
+		scores = append(scores, batch...)
+	}
+	if err := r.rank.ReplaceTotal(ctx, scores); err != nil {
+		r.logger.Error("post hot rank rebuild failed", logger.Error(err))
+		return
+	}
+	r.refresh(ctx, time.Now())
+	r.logger.Info("post hot rank rebuilt", logger.Int("posts", len(scores)))
+}
+
+// publishedScores scores the posts with interactions, skipping the ones that
+// are no longer published so hidden posts do not return to the all-time set.
+func (r *PostHotRanker) publishedScores(ctx context.Context, stats []domain.PostStats) ([]domain.PostScore, error) {
+	var ids []int64
+	for _, st := range stats {
+		if domain.HotScore(st) > 0 {
+			ids = append(ids, st.PostId)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	posts, err := r.pubRepo.FindByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	published := make(map[int64]struct{}, len(posts))
+	for _, p := range posts {
+		published[p.Id] = struct{}{}
+	}
+	scores := make([]domain.PostScore, 0, len(posts))
+	for _, st := range stats {
+		if _, ok := published[st.PostId]; ok {
+			scores = append(scores, domain.PostScore{PostId: st.PostId, Score: domain.HotScore(st)})
+		}
+	}
+	return scores, nil
+}
+
+// hotDayWeights returns the weight of each day bucket, today first.
+//   - daily: a sliding 24 hours, yesterday counts for the part of it not yet covered by today.
+//   - weekly: the last 7 days, halving every weeklyHalfLifeDays.
+//   - all: nil, the undecayed all-time scores.
+func hotDayWeights(window domain.HotWindow, now time.Time) []float64 {
+	switch window {
+	case domain.HotWindowDaily:
+		midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		elapsed := now.Sub(midnight).Hours() / 24
+		return []float64{1, 1 - elapsed}
+	case domain.HotWindowWeekly:
+		weights := make([]float64, 7)
+		for i := range weights {
+			weights[i] = math.Pow(0.5, float64(i)/weeklyHalfLifeDays)
+		}
+		return weights
+	}
+	return nil
+}
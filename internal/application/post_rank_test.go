@@ -0,0 +1,93 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+	repomocks "webook/internal/adapters/outbound/mocks"
+	"webook/internal/domain"
+	"webook/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPostRankService_ListHot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	rank := repomocks.NewMockPostRankCache(ctrl)
+	pubRepo := repomocks.NewMockPublishedPostRepository(ctrl)
+	rank.EXPECT().Top(gomock.Any(), domain.HotWindowWeekly, 10, 10).Return([]domain.PostScore{
+		{PostId: 3, Score: 30},
+		{PostId: 1, Score: 20},
+		{PostId: 2, Score: 10},
+	}, int64(23), nil)
+	// 帖子 1 已下线，且仓储返回顺序与榜单不同
+	pubRepo.EXPECT().FindByIds(gomock.Any(), []int64{3, 1, 2}).Return([]domain.Post{
+		{Id: 2, Title: "b"},
+		{Id: 3, Title: "c"},
+	}, nil)
+	// 下线的帖子从榜单中移除，总数随之减少
+	rank.EXPECT().Remove(gomock.Any(), int64(1)).Return(nil)
+
+	svc := NewPostRankService(rank, pubRepo)
+	hots, total, err := svc.ListHot(context.Background(), domain.HotWindowWeekly, 2, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(22), total)
+	assert.Equal(t, []domain.HotPost{
+		{Post: domain.Post{Id: 3, Title: "c"}, Score: 30},
+		{Post: domain.Post{Id: 2, Title: "b"}, Score: 10},
+	}, hots)
+}
+
+func TestPostHotRanker_RebuildOnce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	statsRepo := repomocks.NewMockPostStatsRepository(ctrl)
+	pubRepo := repomocks.NewMockPublishedPostRepository(ctrl)
+	rank := repomocks.NewMockPostRankCache(ctrl)
+	statsCache := repomocks.NewMockPostStatsCache(ctrl)
+
+	statsCache.EXPECT().TryLock(gomock.Any(), "post:hot:rebuild:lock", gomock.Any()).Return(true, nil)
+	statsRepo.EXPECT().MaxPostId(gomock.Any()).Return(int64(3), nil)
+	statsRepo.EXPECT().FindByPostRange(gomock.Any(), int64(1), int64(1001)).Return([]domain.PostStats{
+		{PostId: 1, LikeCnt: 2},
+		{PostId: 2, LikeCnt: 5},
+		{PostId: 3},
+	}, nil)
+	// 没有互动的帖子不查询；帖子 2 已被隐藏，不再回到总榜
+	pubRepo.EXPECT().FindByIds(gomock.Any(), []int64{1, 2}).Return([]domain.Post{{Id: 1}}, nil)
+	rank.EXPECT().ReplaceTotal(gomock.Any(), []domain.PostScore{
+		{PostId: 1, Score: domain.HotScore(domain.PostStats{PostId: 1, LikeCnt: 2})},
+	}).Return(nil)
+	rank.EXPECT().RefreshWindow(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), 100).
+		Times(len(domain.HotWindows)).Return(nil)
+
+	r := NewPostHotRanker(statsRepo, pubRepo, rank, statsCache, logger.NewZapLogger("error", false), PostHotRankSchedule{TopN: 100})
+	r.RebuildOnce(context.Background())
+}
+
+func TestHotDayWeights(t *testing.T) {
+	now := time.Date(2026, 10, 17, 6, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		window domain.HotWindow
+		want   []float64
+	}{
+		{name: "日榜-昨天按未被今天覆盖的部分计入", window: domain.HotWindowDaily, want: []float64{1, 0.75}},
+		{name: "周榜-每两天减半", window: domain.HotWindowWeekly, want: []float64{1, 0.7071, 0.5, 0.3536, 0.25, 0.1768, 0.125}},
+		{name: "总榜-不衰减", window: domain.HotWindowAll, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hotDayWeights(tt.window, now)
+			require.Len(t, got, len(tt.want))
+			for i := range tt.want {
+				assert.InDelta(t, tt.want[i], got[i], 0.0001)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package application
+
+import (
+	"context"
+	"time"
+	output "webook/internal/ports/output"
+	"webook/pkg/logger"
+)
+
+// PostReaderSnapshotInterval is how often reader snapshots are written.
+type PostReaderSnapshotInterval time.Duration
+
+// PostReaderSnapshotter copies the unique reader HyperLogLogs of recently read
+// posts to MySQL, so the counts survive Redis loss and the HyperLogLogs can be
+// restored from there.
+type PostReaderSnapshotter struct {
+	cache     output.PostStatsCache
+	repo      output.PostReaderRepository
+	logger    logger.Logger
+	interval  time.Duration
+	batchSize int64
+	lockTTL   time.Duration
+}
+
+func NewPostReaderSnapshotter(cache output.PostStatsCache, repo output.PostReaderRepository, l logger.Logger, interval PostReaderSnapshotInterval) *PostReaderSnapshotter {
+	return &PostReaderSnapshotter{
+		cache:     cache,
+		repo:      repo,
+		logger:    l,
+		interval:  time.Duration(interval),
+		batchSize: 100,
+		lockTTL:   time.Duration(interval) * 9 / 10,
+	}
+}
+
+func (s *PostReaderSnapshotter) Start(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.SnapshotOnce(ctx)
+		}
+	}
+}
+
+func (s *PostReaderSnapshotter) SnapshotOnce(ctx context.Context) {
+	locked, err := s.cache.TryLock(ctx, "post:uv:snapshot:lock", s.lockTTL)
+	if err != nil || !locked {
+		return
+	}
+
+	for {
+		snaps, err := s.cache.TakeReaderSnapshots(ctx, s.batchSize)
+		if err != nil {
+			s.logger.Warn("post reader snapshot take failed", logger.Error(err))
+			return
+		}
+		if len(snaps) == 0 {
+			return
+		}
+		if err := s.repo.SaveSnapshots(ctx, snaps); err != nil {
+			s.logger.Error("post reader snapshot save failed", logger.Error(err))
+			if err := s.cache.RequeueReaderSnapshots(ctx, snaps); err != nil {
+				s.logger.Error("post reader snapshot requeue failed", logger.Error(err))
+			}
+			return
+		}
+	}
+}
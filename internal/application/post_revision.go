@@ -0,0 +1,172 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"webook/internal/domain"
+	input "webook/internal/ports/input"
+	output "webook/internal/ports/output"
+)
+
+type postRevisionService struct {
+	repo    output.PostRepository
+	revRepo output.PostRevisionRepository
+}
+
+func NewPostRevisionService(repo output.PostRepository, revRepo output.PostRevisionRepository) input.PostRevisionService {
+	return &postRevisionService{
+		repo:    repo,
+		revRepo: revRepo,
+	}
+}
+
+func (s *postRevisionService) List(ctx context.Context, postId, uid int64, page, pageSize int) ([]domain.PostRevision, int64, error) {
+	if err := s.checkAuthor(ctx, postId, uid); err != nil {
+		return nil, 0, err
+	}
+	offset := (page - 1) * pageSize
+	revisions, err := s.revRepo.FindByPostId(ctx, postId, offset, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.revRepo.CountByPostId(ctx, postId)
+	if err != nil {
+		return nil, 0, err
+	}
+	return revisions, total, nil
+}
+
+func (s *postRevisionService) Get(ctx context.Context, postId, uid, version int64) (domain.PostRevision, error) {
+	if err := s.checkAuthor(ctx, postId, uid); err != nil {
+		return domain.PostRevision{}, err
+	}
+	return s.revRepo.FindByVersion(ctx, postId, version)
+}
+
+func (s *postRevisionService) Diff(ctx context.Context, postId, uid, from, to int64) ([]domain.DiffLine, error) {
+	if err := s.checkAuthor(ctx, postId, uid); err != nil {
+		return nil, err
+	}
+	oldRev, err := s.revRepo.FindByVersion(ctx, postId, from)
+	if err != nil {
+		return nil, err
+	}
+	newRev, err := s.revRepo.FindByVersion(ctx, postId, to)
+	if err != nil {
+		return nil, err
+	}
+	return diffLines(revisionText(oldRev), revisionText(newRev)), nil
+}
+
+// Restore 把指定版本的内容写回草稿。
+// 走与 Save 相同的草稿更新路径，线上库不受影响，需要作者再次发布才会对读者生效。
+func (s *postRevisionService) Restore(ctx context.Context, postId, uid, version int64) error {
+	if err := s.checkAuthor(ctx, postId, uid); err != nil {
+		return err
+	}
+	rev, err := s.revRepo.FindByVersion(ctx, postId, version)
+	if err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, domain.Post{
+		Id:       postId,
+		Title:    rev.Title,
+		Content:  rev.Content,
+		AuthorId: uid,
+		Status:   domain.PostStatusUnpublished,
+	})
+}
+
+func (s *postRevisionService) checkAuthor(ctx context.Context, postId, uid int64) error {
+	p, err := s.repo.FindById(ctx, postId)
+	if err != nil {
+		return err
+	}
+	if p.AuthorId != uid {
+		return domain.ErrPostNotAuthor
+	}
+	return nil
+}
+
+// revisionText 把标题作为第一行参与对比，这样标题修改也能体现在差异中
+func revisionText(r domain.PostRevision) string {
+	return r.Title + "\n" + r.Content
+}
+
+// maxDiffCells LCS 矩阵的最大格数（约 32MB），帖子长度没有上限，
+// 两个差别很大的长版本直接按矩阵计算会耗尽内存
+const maxDiffCells = 1 << 22
+
+// diffLines 基于最长公共子序列计算两段文本的行级差异。
+// 剥离公共前后缀后中间部分超过 maxDiffCells 时不再逐行对齐，整体作为删除加插入返回
+func diffLines(oldText, newText string) []domain.DiffLine {
+	a := strings.Split(oldText, "\n")
+	b := strings.Split(newText, "\n")
+
+	// 先剥离公共前后缀，缩小 LCS 矩阵
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	result := make([]domain.DiffLine, 0, len(a)+len(b))
+	result = appendLines(result, domain.DiffOpEqual, a[:prefix])
+
+	midA := a[prefix : len(a)-suffix]
+	midB := b[prefix : len(b)-suffix]
+	if len(midA)*len(midB) > maxDiffCells {
+		result = appendLines(result, domain.DiffOpDelete, midA)
+		result = appendLines(result, domain.DiffOpInsert, midB)
+	} else {
+		result = appendLCSDiff(result, midA, midB)
+	}
+
+	return appendLines(result, domain.DiffOpEqual, a[len(a)-suffix:])
+}
+
+// appendLCSDiff 用 LCS 矩阵对齐 midA 和 midB，把逐行差异追加到 result
+func appendLCSDiff(result []domain.DiffLine, midA, midB []string) []domain.DiffLine {
+	n, m := len(midA), len(midB)
+	// lcs[i][j] 表示 midA[i:] 与 midB[j:] 的最长公共子序列长度
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case midA[i] == midB[j]:
+			result = append(result, domain.DiffLine{Op: domain.DiffOpEqual, Text: midA[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			result = append(result, domain.DiffLine{Op: domain.DiffOpDelete, Text: midA[i]})
+			i++
+		default:
+			result = append(result, domain.DiffLine{Op: domain.DiffOpInsert, Text: midB[j]})
+			j++
+		}
+	}
+	result = appendLines(result, domain.DiffOpDelete, midA[i:])
+	return appendLines(result, domain.DiffOpInsert, midB[j:])
+}
+
+func appendLines(result []domain.DiffLine, op domain.DiffOp, lines []string) []domain.DiffLine {
+	for _, line := range lines {
+		result = append(result, domain.DiffLine{Op: op, Text: line})
+	}
+	return result
+}
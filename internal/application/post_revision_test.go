@@ -0,0 +1,180 @@
+package application
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	repomocks "webook/internal/adapters/outbound/mocks"
+	"webook/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		oldText string
+		newText string
+		want    []domain.DiffLine
+	}{
+		{
+			name:    "内容相同",
+			oldText: "a\nb",
+			newText: "a\nb",
+			want: []domain.DiffLine{
+				{Op: domain.DiffOpEqual, Text: "a"},
+				{Op: domain.DiffOpEqual, Text: "b"},
+			},
+		},
+		{
+			name:    "中间插入一行",
+			oldText: "a\nc",
+			newText: "a\nb\nc",
+			want: []domain.DiffLine{
+				{Op: domain.DiffOpEqual, Text: "a"},
+				{Op: domain.DiffOpInsert, Text: "b"},
+				{Op: domain.DiffOpEqual, Text: "c"},
+			},
+		},
+		{
+			name:    "修改一行",
+			oldText: "a\nb\nc",
+			newText: "a\nx\nc",
+			want: []domain.DiffLine{
+				{Op: domain.DiffOpEqual, Text: "a"},
+				{Op: domain.DiffOpDelete, Text: "b"},
+				{Op: domain.DiffOpInsert, Text: "x"},
+				{Op: domain.DiffOpEqual, Text: "c"},
+			},
+		},
+		{
+			name:    "删除末尾",
+			oldText: "a\nb\nc",
+			newText: "a",
+			want: []domain.DiffLine{
+				{Op: domain.DiffOpEqual, Text: "a"},
+				{Op: domain.DiffOpDelete, Text: "b"},
+				{Op: domain.DiffOpDelete, Text: "c"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, diffLines(tt.oldText, tt.newText))
+		})
+	}
+}
+
+func TestDiffLines_Large(t *testing.T) {
+	// 中间部分各 5 万行且完全不同，超过上限时整体替换，不分配 LCS 矩阵
+	const lines = 50000
+	oldLines := make([]string, lines)
+	newLines := make([]string, lines)
+	for i := range oldLines {
+		oldLines[i] = "old " + strconv.Itoa(i)
+		newLines[i] = "new " + strconv.Itoa(i)
+	}
+	oldText := "标题\n" + strings.Join(oldLines, "\n") + "\n结尾"
+	newText := "标题\n" + strings.Join(newLines, "\n") + "\n结尾"
+
+	diff := diffLines(oldText, newText)
+	require.Len(t, diff, 2*lines+2)
+	assert.Equal(t, domain.DiffLine{Op: domain.DiffOpEqual, Text: "标题"}, diff[0])
+	assert.Equal(t, domain.DiffLine{Op: domain.DiffOpDelete, Text: "old 0"}, diff[1])
+	assert.Equal(t, domain.DiffLine{Op: domain.DiffOpInsert, Text: "new 0"}, diff[lines+1])
+	assert.Equal(t, domain.DiffLine{Op: domain.DiffOpEqual, Text: "结尾"}, diff[2*lines+1])
+}
+
+func TestPostRevisionService_Restore(t *testing.T) {
+	tests := []struct {
+		name    string
+		postId  int64
+		uid     int64
+		version int64
+		mock    func(ctrl *gomock.Controller) (*repomocks.MockPostRepository, *repomocks.MockPostRevisionRepository)
+		wantErr error
+	}{
+		{
+			name:    "恢复到草稿成功",
+			postId:  1,
+			uid:     1,
+			version: 2,
+			mock: func(ctrl *gomock.Controller) (*repomocks.MockPostRepository, *repomocks.MockPostRevisionRepository) {
+				repo := repomocks.NewMockPostRepository(ctrl)
+				revRepo := repomocks.NewMockPostRevisionRepository(ctrl)
+				repo.EXPECT().
+					FindById(gomock.Any(), int64(1)).
+					Return(domain.Post{Id: 1, AuthorId: 1, Status: domain.PostStatusPublished}, nil)
+				revRepo.EXPECT().
+					FindByVersion(gomock.Any(), int64(1), int64(2)).
+					Return(domain.PostRevision{PostId: 1, Version: 2, Title: "旧标题", Content: "旧内容"}, nil)
+				// 只写回草稿，不触发 Sync
+				repo.EXPECT().
+					Update(gomock.Any(), domain.Post{
+						Id:       1,
+						Title:    "旧标题",
+						Content:  "旧内容",
+						AuthorId: 1,
+						Status:   domain.PostStatusUnpublished,
+					}).
+					Return(nil)
+				return repo, revRepo
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "恢复失败-非作者",
+			postId:  1,
+			uid:     2,
+			version: 2,
+			mock: func(ctrl *gomock.Controller) (*repomocks.MockPostRepository, *repomocks.MockPostRevisionRepository) {
+				repo := repomocks.NewMockPostRepository(ctrl)
+				revRepo := repomocks.NewMockPostRevisionRepository(ctrl)
+				repo.EXPECT().
+					FindById(gomock.Any(), int64(1)).
+					Return(domain.Post{Id: 1, AuthorId: 1}, nil)
+				return repo, revRepo
+			},
+			wantErr: domain.ErrPostNotAuthor,
+		},
+		{
+			name:    "恢复失败-版本不存在",
+			postId:  1,
+			uid:     1,
+			version: 99,
+			mock: func(ctrl *gomock.Controller) (*repomocks.MockPostRepository, *repomocks.MockPostRevisionRepository) {
+				repo := repomocks.NewMockPostRepository(ctrl)
+				revRepo := repomocks.NewMockPostRevisionRepository(ctrl)
+				repo.EXPECT().
+					FindById(gomock.Any(), int64(1)).
+					Return(domain.Post{Id: 1, AuthorId: 1}, nil)
+				revRepo.EXPECT().
+					FindByVersion(gomock.Any(), int64(1), int64(99)).
+					Return(domain.PostRevision{}, domain.ErrPostRevisionNotFound)
+				return repo, revRepo
+			},
+			wantErr: domain.ErrPostRevisionNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, revRepo := tt.mock(ctrl)
+			svc := NewPostRevisionService(repo, revRepo)
+
+			err := svc.Restore(context.Background(), tt.postId, tt.uid, tt.version)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package application
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+	"webook/internal/domain"
+	input "webook/internal/ports/input"
+	output "webook/internal/ports/output"
+	"webook/pkg/logger"
+)
+
+const (
+	// 启用热度加权时，在前 searchBoostWindow 的整数倍条结果内重新排序
+	searchBoostWindow = 100
+	// 热度加权系数：final = text * (1 + weight * log(1 + heat))
+	searchBoostWeight = 0.2
+)
+
+type postSearchService struct {
+	index    output.SearchService
+	statsSvc input.PostInteractionService
+}
+
+func NewPostSearchService(index output.SearchService, statsSvc input.PostInteractionService) input.PostSearchService {
+	return &postSearchService{
+		index:    index,
+		statsSvc: statsSvc,
+	}
+}
+
+func (s *postSearchService) Search(ctx context.Context, query string, page, pageSize int, boost bool) ([]domain.PostSearchHit, int64, error) {
+	offset := (page - 1) * pageSize
+	limit := offset + pageSize
+	if boost {
+		// 固定窗口，保证同一窗口内的分页顺序一致
+		limit = (limit + searchBoostWindow - 1) / searchBoostWindow * searchBoostWindow
+	}
+
+	hits, total, err := s.index.Search(ctx, query, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	if boost && len(hits) > 0 {
+		s.boostByStats(ctx, hits)
+	}
+
+	if offset >= len(hits) {
+		return []domain.PostSearchHit{}, total, nil
+	}
+	return hits[offset:min(offset+pageSize, len(hits))], total, nil
+}
+
+// boostByStats 按帖子热度调整得分并重新排序；统计读取失败时保留文本相关度排序
+func (s *postSearchService) boostByStats(ctx context.Context, hits []domain.PostSearchHit) {
+	postIds := make([]int64, len(hits))
+	for i, h := range hits {
+		postIds[i] = h.PostId
+	}
+	stats, _, err := s.statsSvc.GetStatsBatch(ctx, postIds, 0)
+	if err != nil {
+		return
+	}
+	for i := range hits {
+		st := stats[hits[i].PostId]
+		heat := float64(st.LikeCnt)*2 + float64(st.CollectCnt)*3 + float64(st.ReadCnt)*0.1
+		hits[i].Score *= 1 + searchBoostWeight*math.Log1p(heat)
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		return hits[i].Score > hits[j].Score
+	})
+}
+
+// PostSearchIndexer rebuilds the embedded search index from the published table.
+// Each instance keeps its own index, so the periodic rebuild is what brings in
+// posts published or hidden by other instances.
+type PostSearchIndexer struct {
+	pubRepo   output.PublishedPostRepository
+	index     output.SearchService
+	logger    logger.Logger
+	interval  time.Duration
+	batchSize int
+}
+
+func NewPostSearchIndexer(pubRepo output.PublishedPostRepository, index output.SearchService, l logger.Logger, interval time.Duration) *PostSearchIndexer {
+	return &PostSearchIndexer{
+		pubRepo:   pubRepo,
+		index:     index,
+		logger:    l,
+		interval:  interval,
+		batchSize: 500,
+	}
+}
+
+func (i *PostSearchIndexer) Start(ctx context.Context) {
+	i.RebuildOnce(ctx)
+	ticker := time.NewTicker(i.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			i.RebuildOnce(ctx)
+		}
+	}
+}
+
+func (i *PostSearchIndexer) RebuildOnce(ctx context.Context) {
+	var all []domain.Post
+	err := i.index.Rebuild(ctx, func(ctx context.Context) ([]domain.Post, error) {
+		// 按 ID 翻页，遍历期间有帖子被更新也不会漏掉或重复
+		var lastId int64
+		for {
+			posts, err := i.pubRepo.ListAfterId(ctx, lastId, i.batchSize)
+			if err != nil {
+				return nil, err
+			}
+			all = append(all, posts...)
+			if len(posts) < i.batchSize {
+				return all, nil
+			}
+			lastId = posts[len(posts)-1].Id
+		}
+	})
+	if err != nil {
+		i.logger.Error("post search rebuild failed", logger.Error(err))
+		return
+	}
+	i.logger.Info("post search index rebuilt", logger.Int("posts", len(all)))
+}
@@ -19,6 +19,8 @@ type postInteractionService struct {
 	collectRepo output.PostCollectRepository
 	statsRepo   output.PostStatsRepository
 	statsCache  output.PostStatsCache
+	readerRepo  output.PostReaderRepository
+	historyRepo output.ReadHistoryRepository
 	publisher   output.PostStatsEventPublisher
 }
 
@@ -27,6 +29,8 @@ func NewPostInteractionService(
 	collectRepo output.PostCollectRepository,
 	statsRepo output.PostStatsRepository,
 	statsCache output.PostStatsCache,
+	readerRepo output.PostReaderRepository,
+	historyRepo output.ReadHistoryRepository,
 	publisher output.PostStatsEventPublisher,
 ) input.PostInteractionService {
 	return &postInteractionService{
@@ -34,55 +38,47 @@ func NewPostInteractionService(
 		collectRepo: collectRepo,
 		statsRepo:   statsRepo,
 		statsCache:  statsCache,
+		readerRepo:  readerRepo,
+		historyRepo: historyRepo,
 		publisher:   publisher,
 	}
 }
 
 func (s *postInteractionService) Like(ctx context.Context, postId, userId int64) error {
-	changed, err := s.likeRepo.SetStatus(ctx, postId, userId, 1)
-	if err != nil {
-		return err
-	}
-	if !changed {
-		return nil
-	}
-	return s.publish(ctx, domain.PostStatsEventLike, postId, userId)
+	// The stats event is written to the outbox together with the relation change
+	// and shipped to MQ by PostStatsOutboxRelay.
+	_, err := s.likeRepo.SetStatus(ctx, postId, userId, 1)
+	return err
 }
 
 func (s *postInteractionService) Unlike(ctx context.Context, postId, userId int64) error {
-	changed, err := s.likeRepo.SetStatus(ctx, postId, userId, 0)
-	if err != nil {
-		return err
-	}
-	if !changed {
-		return nil
-	}
-	return s.publish(ctx, domain.PostStatsEventUnlike, postId, userId)
+	_, err := s.likeRepo.SetStatus(ctx, postId, userId, 0)
+	return err
 }
 
 func (s *postInteractionService) Collect(ctx context.Context, postId, userId int64) error {
-	changed, err := s.collectRepo.SetStatus(ctx, postId, userId, 1)
-	if err != nil {
-		return err
-	}
-	if !changed {
-		return nil
-	}
-	return s.publish(ctx, domain.PostStatsEventCollect, postId, userId)
+	_, err := s.collectRepo.SetStatus(ctx, postId, userId, 1)
+	return err
 }
 
 func (s *postInteractionService) Uncollect(ctx context.Context, postId, userId int64) error {
-	changed, err := s.collectRepo.SetStatus(ctx, postId, userId, 0)
-	if err != nil {
-		return err
+	_, err := s.collectRepo.SetStatus(ctx, postId, userId, 0)
+	return err
+}
+
+func (s *postInteractionService) Read(ctx context.Context, postId, userId int64, ip, userAgent string, progress int) error {
+	if progress != domain.ReadProgressUnknown && (progress < 0 || progress > domain.ReadProgressFinished) {
+		return domain.ErrInvalidReadProgress
 	}
-	if !changed {
-		return nil
+	// History is kept for every call, including the progress updates the 30 second
+	// dedupe below swallows.
+	if userId > 0 {
+		err := s.historyRepo.Record(ctx, domain.ReadHistory{UserId: userId, PostId: postId, Progress: progress})
+		if err != nil {
+			return err
+		}
 	}
-	return s.publish(ctx, domain.PostStatsEventUncollect, postId, userId)
-}
 
-func (s *postInteractionService) Read(ctx context.Context, postId, userId int64, ip, userAgent string) error {
 	key := s.readDedupeKey(postId, userId, ip, userAgent)
 	ok, err := s.statsCache.SetReadDedupe(ctx, key, 30*time.Second)
 	if err != nil {
@@ -91,7 +87,29 @@ func (s *postInteractionService) Read(ctx context.Context, postId, userId int64,
 	if !ok {
 		return nil
 	}
-	return s.publish(ctx, domain.PostStatsEventRead, postId, userId)
+	if err := s.publish(ctx, domain.PostStatsEventRead, postId, userId); err != nil {
+		return err
+	}
+	return s.addReader(ctx, postId, s.readerId(userId, ip, userAgent))
+}
+
+// addReader counts the reader in the unique reader HyperLogLogs. If Redis lost
+// the post's HyperLogLog, the last snapshot is loaded before counting.
+func (s *postInteractionService) addReader(ctx context.Context, postId int64, reader string) error {
+	date := time.Now().Format(domain.StatsDateLayout)
+	added, err := s.statsCache.AddReader(ctx, postId, reader, date)
+	if err != nil || added {
+		return err
+	}
+	sketch, err := s.readerRepo.FindSketch(ctx, postId)
+	if err != nil {
+		return err
+	}
+	if err := s.statsCache.RestoreReaders(ctx, postId, sketch); err != nil {
+		return err
+	}
+	_, err = s.statsCache.AddReader(ctx, postId, reader, date)
+	return err
 }
 
 func (s *postInteractionService) GetStats(ctx context.Context, postId, userId int64) (domain.PostStats, domain.PostUserStats, error) {
@@ -144,6 +162,17 @@ func (s *postInteractionService) GetStatsBatch(ctx context.Context, postIds []in
 		_ = s.statsCache.BatchSet(ctx, mapToSlice(stats, missing, dbStatsMap))
 	}
 
+	// Unique readers live in HyperLogLogs rather than the stats hash. Snapshots in
+	// MySQL may be ahead of Redis right after it lost the HyperLogLogs.
+	if readers, err := s.statsCache.CountReaders(ctx, postIds); err == nil {
+		for id, n := range readers {
+			if st := stats[id]; n > st.UniqueReadCnt {
+				st.UniqueReadCnt = n
+				stats[id] = st
+			}
+		}
+	}
+
 	if userId > 0 {
 		liked, err := s.likeRepo.FindLikedPostIds(ctx, postIds, userId)
 		if err != nil && !errors.Is(err, context.Canceled) {
@@ -173,6 +202,15 @@ func (s *postInteractionService) publish(ctx context.Context, eventType domain.P
 	return s.publisher.Publish(ctx, event)
 }
 
+// readerId identifies a reader for unique reader counting, the same way readDedupeKey does.
+func (s *postInteractionService) readerId(userId int64, ip, userAgent string) string {
+	if userId > 0 {
+		return fmt.Sprintf("uid:%d", userId)
+	}
+	sum := sha1.Sum([]byte(ip + "|" + userAgent))
+	return "anon:" + hex.EncodeToString(sum[:])
+}
+
 func (s *postInteractionService) readDedupeKey(postId, userId int64, ip, userAgent string) string {
 	if userId > 0 {
 		return fmt.Sprintf("post:read:dedupe:uid:%d:pid:%d", userId, postId)
@@ -11,16 +11,18 @@ import (
 type PostStatsFlusher struct {
 	cache     output.PostStatsCache
 	repo      output.PostStatsRepository
+	dailyRepo output.PostDailyStatsRepository
 	logger    logger.Logger
 	interval  time.Duration
 	batchSize int64
 	lockTTL   time.Duration
 }
 
-func NewPostStatsFlusher(cache output.PostStatsCache, repo output.PostStatsRepository, l logger.Logger) *PostStatsFlusher {
+func NewPostStatsFlusher(cache output.PostStatsCache, repo output.PostStatsRepository, dailyRepo output.PostDailyStatsRepository, l logger.Logger) *PostStatsFlusher {
 	return &PostStatsFlusher{
 		cache:     cache,
 		repo:      repo,
+		dailyRepo: dailyRepo,
 		logger:    l,
 		interval:  5 * time.Second,
 		batchSize: 100,
@@ -46,7 +48,11 @@ func (f *PostStatsFlusher) FlushOnce(ctx context.Context) {
 	if err != nil || !locked {
 		return
 	}
+	f.flushTotals(ctx)
+	f.flushDaily(ctx)
+}
 
+func (f *PostStatsFlusher) flushTotals(ctx context.Context) {
 	for {
 		postIds, err := f.cache.PopDirty(ctx, f.batchSize)
 		if err != nil {
@@ -76,3 +82,30 @@ func (f *PostStatsFlusher) FlushOnce(ctx context.Context) {
 		}
 	}
 }
+
+// flushDaily moves buffered daily deltas into MySQL. Deltas are removed from Redis
+// when taken, so a failed write puts them back for the next round.
+func (f *PostStatsFlusher) flushDaily(ctx context.Context) {
+	for {
+		deltas, err := f.cache.TakeDaily(ctx, f.batchSize)
+		if err != nil {
+			f.logger.Warn("post daily stats flush take failed", logger.Error(err))
+			return
+		}
+		if len(deltas) == 0 {
+			return
+		}
+		if err := f.dailyRepo.IncrBatch(ctx, deltas); err != nil {
+			f.logger.Error("post daily stats flush upsert failed", logger.Error(err))
+			for _, d := range deltas {
+				if err := f.cache.IncrDaily(ctx, d); err != nil {
+					f.logger.Error("post daily stats restore failed",
+						logger.Int64("post_id", d.PostId),
+						logger.String("date", d.Date),
+						logger.Error(err))
+				}
+			}
+			return
+		}
+	}
+}
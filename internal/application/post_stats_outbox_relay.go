@@ -0,0 +1,153 @@
+package application
+
+import (
+	"context"
+	"time"
+	output "webook/internal/ports/output"
+	"webook/pkg/logger"
+)
+
+// PostStatsOutboxRelay ships pending outbox rows to MQ and marks them sent.
+// Delivery is at-least-once; the consumer dedupes by EventId.
+type PostStatsOutboxRelay struct {
+	repo       output.PostStatsOutboxRepository
+	publisher  output.PostStatsEventPublisher
+	cache      output.PostStatsCache
+	logger     logger.Logger
+	interval   time.Duration
+	batchSize  int
+	lockTTL    time.Duration
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+
+	retention       time.Duration
+	cleanupInterval time.Duration
+	lastCleanup     time.Time
+}
+
+func NewPostStatsOutboxRelay(
+	repo output.PostStatsOutboxRepository,
+	publisher output.PostStatsEventPublisher,
+	cache output.PostStatsCache,
+	l logger.Logger,
+) *PostStatsOutboxRelay {
+	return &PostStatsOutboxRelay{
+		repo:            repo,
+		publisher:       publisher,
+		cache:           cache,
+		logger:          l,
+		interval:        time.Second,
+		batchSize:       100,
+		lockTTL:         900 * time.Millisecond,
+		maxRetries:      10,
+		baseDelay:       time.Second,
+		maxDelay:        5 * time.Minute,
+		retention:       72 * time.Hour,
+		cleanupInterval: time.Hour,
+	}
+}
+
+func (r *PostStatsOutboxRelay) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.RelayOnce(ctx)
+		}
+	}
+}
+
+func (r *PostStatsOutboxRelay) RelayOnce(ctx context.Context) {
+	locked, err := r.cache.TryLock(ctx, "post:stats:outbox:lock", r.lockTTL)
+	if err != nil || !locked {
+		return
+	}
+
+	for {
+		msgs, err := r.repo.FindPending(ctx, time.Now().UnixMilli(), r.batchSize)
+		if err != nil {
+			r.logger.Warn("post stats outbox find pending failed", logger.Error(err))
+			return
+		}
+		if len(msgs) == 0 {
+			break
+		}
+
+		sent := make([]int64, 0, len(msgs))
+		failed := false
+		for _, msg := range msgs {
+			if err := r.publisher.Publish(ctx, msg.Event); err != nil {
+				r.handleFailure(ctx, msg.Id, msg.Retries, err)
+				// The broker is most likely unavailable, retry the rest on the next tick.
+				failed = true
+				break
+			}
+			sent = append(sent, msg.Id)
+		}
+		if err := r.repo.MarkSent(ctx, sent); err != nil {
+			// Rows stay pending and are published again; the consumer dedupes them.
+			r.logger.Error("post stats outbox mark sent failed", logger.Error(err))
+			return
+		}
+		if failed || len(msgs) < r.batchSize {
+			break
+		}
+	}
+
+	r.cleanup(ctx)
+}
+
+func (r *PostStatsOutboxRelay) handleFailure(ctx context.Context, id int64, retries int, cause error) {
+	if retries+1 >= r.maxRetries {
+		r.logger.Error("post stats outbox giving up on event",
+			logger.Int64("id", id),
+			logger.Int("retries", retries+1),
+			logger.Error(cause))
+		if err := r.repo.MarkFailed(ctx, id, cause.Error()); err != nil {
+			r.logger.Error("post stats outbox mark failed failed", logger.Error(err))
+		}
+		return
+	}
+	next := time.Now().Add(r.backoff(retries)).UnixMilli()
+	r.logger.Warn("post stats outbox publish failed",
+		logger.Int64("id", id),
+		logger.Int("retries", retries+1),
+		logger.Error(cause))
+	if err := r.repo.MarkRetry(ctx, id, next, cause.Error()); err != nil {
+		r.logger.Error("post stats outbox mark retry failed", logger.Error(err))
+	}
+}
+
+// backoff doubles the delay on every attempt, capped at maxDelay.
+func (r *PostStatsOutboxRelay) backoff(retries int) time.Duration {
+	delay := r.baseDelay
+	for i := 0; i < retries && delay < r.maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > r.maxDelay {
+		delay = r.maxDelay
+	}
+	return delay
+}
+
+func (r *PostStatsOutboxRelay) cleanup(ctx context.Context) {
+	if time.Since(r.lastCleanup) < r.cleanupInterval {
+		return
+	}
+	r.lastCleanup = time.Now()
+	before := time.Now().Add(-r.retention).UnixMilli()
+	for {
+		n, err := r.repo.DeleteSentBefore(ctx, before, 1000)
+		if err != nil {
+			r.logger.Warn("post stats outbox cleanup failed", logger.Error(err))
+			return
+		}
+		if n < 1000 {
+			return
+		}
+	}
+}
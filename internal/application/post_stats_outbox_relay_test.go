@@ -0,0 +1,78 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	repomocks "webook/internal/adapters/outbound/mocks"
+	"webook/internal/domain"
+	"webook/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPostStatsOutboxRelay_RelayOnce(t *testing.T) {
+	msgs := []domain.PostStatsOutboxMessage{
+		{Id: 1, Event: domain.PostStatsEvent{EventId: "e1", Type: domain.PostStatsEventLike, PostId: 10}},
+		{Id: 2, Event: domain.PostStatsEvent{EventId: "e2", Type: domain.PostStatsEventCollect, PostId: 10}, Retries: 9},
+		{Id: 3, Event: domain.PostStatsEvent{EventId: "e3", Type: domain.PostStatsEventLike, PostId: 11}, Retries: 2},
+	}
+
+	tests := []struct {
+		name string
+		mock func(repo *repomocks.MockPostStatsOutboxRepository, pub *repomocks.MockPostStatsEventPublisher)
+	}{
+		{
+			name: "全部投递成功",
+			mock: func(repo *repomocks.MockPostStatsOutboxRepository, pub *repomocks.MockPostStatsEventPublisher) {
+				repo.EXPECT().FindPending(gomock.Any(), gomock.Any(), 100).Return(msgs, nil)
+				pub.EXPECT().Publish(gomock.Any(), gomock.Any()).Times(3).Return(nil)
+				repo.EXPECT().MarkSent(gomock.Any(), []int64{1, 2, 3}).Return(nil)
+			},
+		},
+		{
+			name: "投递失败-退避重试并停止本轮",
+			mock: func(repo *repomocks.MockPostStatsOutboxRepository, pub *repomocks.MockPostStatsEventPublisher) {
+				repo.EXPECT().FindPending(gomock.Any(), gomock.Any(), 100).Return([]domain.PostStatsOutboxMessage{msgs[0], msgs[2]}, nil)
+				pub.EXPECT().Publish(gomock.Any(), msgs[0].Event).Return(nil)
+				pub.EXPECT().Publish(gomock.Any(), msgs[2].Event).Return(errors.New("connection closed"))
+				repo.EXPECT().
+					MarkRetry(gomock.Any(), int64(3), gomock.Any(), "connection closed").
+					DoAndReturn(func(ctx context.Context, id, next int64, reason string) error {
+						// 第 3 次失败，退避 1s * 2^2
+						assert.InDelta(t, time.Now().Add(4*time.Second).UnixMilli(), next, 1000)
+						return nil
+					})
+				repo.EXPECT().MarkSent(gomock.Any(), []int64{1}).Return(nil)
+			},
+		},
+		{
+			name: "超过最大重试次数-标记失败",
+			mock: func(repo *repomocks.MockPostStatsOutboxRepository, pub *repomocks.MockPostStatsEventPublisher) {
+				repo.EXPECT().FindPending(gomock.Any(), gomock.Any(), 100).Return([]domain.PostStatsOutboxMessage{msgs[1]}, nil)
+				pub.EXPECT().Publish(gomock.Any(), msgs[1].Event).Return(errors.New("nacked"))
+				repo.EXPECT().MarkFailed(gomock.Any(), int64(2), "nacked").Return(nil)
+				repo.EXPECT().MarkSent(gomock.Any(), []int64{}).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repomocks.NewMockPostStatsOutboxRepository(ctrl)
+			pub := repomocks.NewMockPostStatsEventPublisher(ctrl)
+			cache := repomocks.NewMockPostStatsCache(ctrl)
+			cache.EXPECT().TryLock(gomock.Any(), "post:stats:outbox:lock", gomock.Any()).Return(true, nil)
+			repo.EXPECT().DeleteSentBefore(gomock.Any(), gomock.Any(), 1000).Return(int64(0), nil).AnyTimes()
+			tt.mock(repo, pub)
+
+			relay := NewPostStatsOutboxRelay(repo, pub, cache, logger.NewZapLogger("error", false))
+			relay.RelayOnce(context.Background())
+		})
+	}
+}
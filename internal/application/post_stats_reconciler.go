@@ -0,0 +1,212 @@
+package application
+
+import (
+	"context"
+	"slices"
+	"time"
+	"webook/internal/domain"
+	output "webook/internal/ports/output"
+	"webook/pkg/logger"
+)
+
+// maxReportedMismatches caps how many mismatches a report keeps in detail.
+const maxReportedMismatches = 1000
+
+// PostStatsReconcileOptions controls a single reconciliation run.
+type PostStatsReconcileOptions struct {
+	FromId    int64 // inclusive
+	ToId      int64 // exclusive, 0 means up to the largest known post id
+	BatchSize int64
+	Repair    bool
+}
+
+// PostStatsReconcileSchedule configures the periodic run started by the worker.
+type PostStatsReconcileSchedule struct {
+	Interval time.Duration // 0 disables the scheduled run
+	Repair   bool
+}
+
+// PostStatsReconciler recounts like/collect counters from the relation tables and
+// compares them with post_stats and the Redis stats hashes.
+//
+// Events still in flight (outbox, MQ, dirty set not yet flushed) show up as
+// mismatches too, so repairs are best run when traffic is low.
+type PostStatsReconciler struct {
+	statsRepo   output.PostStatsRepository
+	likeRepo    output.PostLikeRepository
+	collectRepo output.PostCollectRepository
+	cache       output.PostStatsCache
+	logger      logger.Logger
+	schedule    PostStatsReconcileSchedule
+	lockTTL     time.Duration
+}
+
+func NewPostStatsReconciler(
+	statsRepo output.PostStatsRepository,
+	likeRepo output.PostLikeRepository,
+	collectRepo output.PostCollectRepository,
+	cache output.PostStatsCache,
+	l logger.Logger,
+	schedule PostStatsReconcileSchedule,
+) *PostStatsReconciler {
+	return &PostStatsReconciler{
+		statsRepo:   statsRepo,
+		likeRepo:    likeRepo,
+		collectRepo: collectRepo,
+		cache:       cache,
+		logger:      l,
+		schedule:    schedule,
+		lockTTL:     30 * time.Minute,
+	}
+}
+
+func (r *PostStatsReconciler) Start(ctx context.Context) {
+	if r.schedule.Interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(r.schedule.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.runScheduled(ctx)
+		}
+	}
+}
+
+func (r *PostStatsReconciler) runScheduled(ctx context.Context) {
+	locked, err := r.cache.TryLock(ctx, "post:stats:reconcile:lock", r.lockTTL)
+	if err != nil || !locked {
+		return
+	}
+	report, err := r.Reconcile(ctx, PostStatsReconcileOptions{Repair: r.schedule.Repair})
+	if err != nil {
+		r.logger.Error("post stats reconcile failed", logger.Error(err))
+		return
+	}
+	for _, m := range report.Mismatches {
+		r.logger.Warn("post stats mismatch",
+			logger.Int64("post_id", m.PostId),
+			logger.Int64("like_cnt", m.LikeCnt),
+			logger.Int64("db_like_cnt", m.DBLikeCnt),
+			logger.Int64("cache_like_cnt", m.CacheLikeCnt),
+			logger.Int64("collect_cnt", m.CollectCnt),
+			logger.Int64("db_collect_cnt", m.DBCollectCnt),
+			logger.Int64("cache_collect_cnt", m.CacheCollectCnt),
+			logger.Bool("repaired", m.Repaired))
+	}
+	r.logger.Info("post stats reconcile done",
+		logger.Int("scanned", report.Scanned),
+		logger.Int("mismatches", report.MismatchCnt),
+		logger.Int("repaired", report.RepairedCnt))
+}
+
+// Reconcile scans post ids in [FromId, ToId) in batches of BatchSize.
+func (r *PostStatsReconciler) Reconcile(ctx context.Context, opts PostStatsReconcileOptions) (domain.PostStatsReconcileReport, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 500
+	}
+	if opts.ToId <= 0 {
+		maxId, err := r.statsRepo.MaxPostId(ctx)
+		if err != nil {
+			return domain.PostStatsReconcileReport{}, err
+		}
+		opts.ToId = maxId + 1
+	}
+
+	report := domain.PostStatsReconcileReport{FromId: opts.FromId, ToId: opts.ToId}
+	for from := opts.FromId; from < opts.ToId; from += opts.BatchSize {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		to := min(from+opts.BatchSize, opts.ToId)
+		if err := r.reconcileRange(ctx, from, to, opts.Repair, &report); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+func (r *PostStatsReconciler) reconcileRange(ctx context.Context, from, to int64, repair bool, report *domain.PostStatsReconcileReport) error {
+	stats, err := r.statsRepo.FindByPostRange(ctx, from, to)
+	if err != nil {
+		return err
+	}
+	likes, err := r.likeRepo.CountActiveByPostRange(ctx, from, to)
+	if err != nil {
+		return err
+	}
+	collects, err := r.collectRepo.CountActiveByPostRange(ctx, from, to)
+	if err != nil {
+		return err
+	}
+
+	dbStats := make(map[int64]domain.PostStats, len(stats))
+	for _, st := range stats {
+		dbStats[st.PostId] = st
+	}
+	postIds := make([]int64, 0, len(dbStats))
+	for id := range dbStats {
+		postIds = append(postIds, id)
+	}
+	for id := range likes {
+		postIds = append(postIds, id)
+	}
+	for id := range collects {
+		postIds = append(postIds, id)
+	}
+	slices.Sort(postIds)
+	postIds = slices.Compact(postIds)
+	if len(postIds) == 0 {
+		return nil
+	}
+
+	cached, err := r.cache.BatchGet(ctx, postIds)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range postIds {
+		report.Scanned++
+		db := dbStats[id]
+		c, inCache := cached[id]
+		m := domain.PostStatsMismatch{
+			PostId:          id,
+			LikeCnt:         likes[id],
+			CollectCnt:      collects[id],
+			DBLikeCnt:       db.LikeCnt,
+			DBCollectCnt:    db.CollectCnt,
+			InCache:         inCache,
+			CacheLikeCnt:    c.LikeCnt,
+			CacheCollectCnt: c.CollectCnt,
+		}
+		dbOk := m.DBLikeCnt == m.LikeCnt && m.DBCollectCnt == m.CollectCnt
+		cacheOk := !inCache || (m.CacheLikeCnt == m.LikeCnt && m.CacheCollectCnt == m.CollectCnt)
+		if dbOk && cacheOk {
+			continue
+		}
+
+		if repair {
+			fixed := domain.PostStats{PostId: id, LikeCnt: m.LikeCnt, CollectCnt: m.CollectCnt}
+			if !dbOk {
+				if err := r.statsRepo.UpdateCounts(ctx, fixed); err != nil {
+					return err
+				}
+			}
+			if !cacheOk {
+				if err := r.cache.SetCountsIfPresent(ctx, fixed); err != nil {
+					return err
+				}
+			}
+			m.Repaired = true
+			report.RepairedCnt++
+		}
+		report.MismatchCnt++
+		if len(report.Mismatches) < maxReportedMismatches {
+			report.Mismatches = append(report.Mismatches, m)
+		}
+	}
+	return nil
+}
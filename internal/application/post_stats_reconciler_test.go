@@ -0,0 +1,81 @@
+package application
+
+import (
+	"context"
+	"testing"
+	repomocks "webook/internal/adapters/outbound/mocks"
+	"webook/internal/domain"
+	"webook/pkg/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPostStatsReconciler_Reconcile(t *testing.T) {
+	tests := []struct {
+		name        string
+		repair      bool
+		mock        func(stats *repomocks.MockPostStatsRepository, cache *repomocks.MockPostStatsCache)
+		wantPostIds []int64
+		wantFixed   int
+	}{
+		{
+			name: "只报告不修复",
+			mock: func(stats *repomocks.MockPostStatsRepository, cache *repomocks.MockPostStatsCache) {},
+			// 1 一致；2 库中偏大；3 缺少 post_stats 行；4 Redis 偏小
+			wantPostIds: []int64{2, 3, 4},
+		},
+		{
+			name:   "修复-只覆盖不一致的一侧",
+			repair: true,
+			mock: func(stats *repomocks.MockPostStatsRepository, cache *repomocks.MockPostStatsCache) {
+				stats.EXPECT().UpdateCounts(gomock.Any(), domain.PostStats{PostId: 2, LikeCnt: 1}).Return(nil)
+				stats.EXPECT().UpdateCounts(gomock.Any(), domain.PostStats{PostId: 3, CollectCnt: 1}).Return(nil)
+				cache.EXPECT().SetCountsIfPresent(gomock.Any(), domain.PostStats{PostId: 4, LikeCnt: 3, CollectCnt: 1}).Return(nil)
+			},
+			wantPostIds: []int64{2, 3, 4},
+			wantFixed:   3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			stats := repomocks.NewMockPostStatsRepository(ctrl)
+			likes := repomocks.NewMockPostLikeRepository(ctrl)
+			collects := repomocks.NewMockPostCollectRepository(ctrl)
+			cache := repomocks.NewMockPostStatsCache(ctrl)
+
+			stats.EXPECT().MaxPostId(gomock.Any()).Return(int64(4), nil)
+			stats.EXPECT().FindByPostRange(gomock.Any(), int64(1), int64(5)).Return([]domain.PostStats{
+				{PostId: 1, LikeCnt: 2},
+				{PostId: 2, LikeCnt: 5},
+				{PostId: 4, LikeCnt: 3, CollectCnt: 1},
+			}, nil)
+			likes.EXPECT().CountActiveByPostRange(gomock.Any(), int64(1), int64(5)).
+				Return(map[int64]int64{1: 2, 2: 1, 4: 3}, nil)
+			collects.EXPECT().CountActiveByPostRange(gomock.Any(), int64(1), int64(5)).
+				Return(map[int64]int64{3: 1, 4: 1}, nil)
+			cache.EXPECT().BatchGet(gomock.Any(), []int64{1, 2, 3, 4}).Return(map[int64]domain.PostStats{
+				1: {PostId: 1, LikeCnt: 2},
+				4: {PostId: 4, LikeCnt: 2, CollectCnt: 1},
+			}, nil)
+			tt.mock(stats, cache)
+
+			r := NewPostStatsReconciler(stats, likes, collects, cache, logger.NewZapLogger("error", false), PostStatsReconcileSchedule{})
+			report, err := r.Reconcile(context.Background(), PostStatsReconcileOptions{FromId: 1, Repair: tt.repair})
+			require.NoError(t, err)
+
+			postIds := make([]int64, len(report.Mismatches))
+			for i, m := range report.Mismatches {
+				postIds[i] = m.PostId
+			}
+			assert.Equal(t, 4, report.Scanned)
+			assert.Equal(t, tt.wantPostIds, postIds)
+			assert.Equal(t, tt.wantFixed, report.RepairedCnt)
+		})
+	}
+}
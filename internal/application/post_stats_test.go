@@ -0,0 +1,141 @@
+package application
+
+import (
+	"context"
+	"testing"
+	repomocks "webook/internal/adapters/outbound/mocks"
+	"webook/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type interactionMocks struct {
+	likeRepo    *repomocks.MockPostLikeRepository
+	collectRepo *repomocks.MockPostCollectRepository
+	statsRepo   *repomocks.MockPostStatsRepository
+	cache       *repomocks.MockPostStatsCache
+	readerRepo  *repomocks.MockPostReaderRepository
+	historyRepo *repomocks.MockReadHistoryRepository
+	publisher   *repomocks.MockPostStatsEventPublisher
+}
+
+func newInteractionMocks(ctrl *gomock.Controller) interactionMocks {
+	return interactionMocks{
+		likeRepo:    repomocks.NewMockPostLikeRepository(ctrl),
+		collectRepo: repomocks.NewMockPostCollectRepository(ctrl),
+		statsRepo:   repomocks.NewMockPostStatsRepository(ctrl),
+		cache:       repomocks.NewMockPostStatsCache(ctrl),
+		readerRepo:  repomocks.NewMockPostReaderRepository(ctrl),
+		historyRepo: repomocks.NewMockReadHistoryRepository(ctrl),
+		publisher:   repomocks.NewMockPostStatsEventPublisher(ctrl),
+	}
+}
+
+func (m interactionMocks) service() *postInteractionService {
+	return NewPostInteractionService(m.likeRepo, m.collectRepo, m.statsRepo, m.cache, m.readerRepo, m.historyRepo, m.publisher).(*postInteractionService)
+}
+
+func TestPostInteractionService_Read(t *testing.T) {
+	tests := []struct {
+		name     string
+		userId   int64
+		progress int
+		mock     func(m interactionMocks)
+		wantErr  error
+	}{
+		{
+			name:     "登录用户-记录阅读历史并计入独立访客",
+			userId:   7,
+			progress: domain.ReadProgressUnknown,
+			mock: func(m interactionMocks) {
+				m.historyRepo.EXPECT().
+					Record(gomock.Any(), domain.ReadHistory{UserId: 7, PostId: 1, Progress: domain.ReadProgressUnknown}).
+					Return(nil)
+				m.cache.EXPECT().SetReadDedupe(gomock.Any(), "post:read:dedupe:uid:7:pid:1", gomock.Any()).Return(true, nil)
+				m.publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+				m.cache.EXPECT().AddReader(gomock.Any(), int64(1), "uid:7", gomock.Any()).Return(true, nil)
+			},
+		},
+		{
+			name:     "Redis丢失HyperLogLog-先从快照恢复",
+			userId:   7,
+			progress: domain.ReadProgressUnknown,
+			mock: func(m interactionMocks) {
+				m.historyRepo.EXPECT().Record(gomock.Any(), gomock.Any()).Return(nil)
+				m.cache.EXPECT().SetReadDedupe(gomock.Any(), gomock.Any(), gomock.Any()).Return(true, nil)
+				m.publisher.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+				gomock.InOrder(
+					m.cache.EXPECT().AddReader(gomock.Any(), int64(1), "uid:7", gomock.Any()).Return(false, nil),
+					m.readerRepo.EXPECT().FindSketch(gomock.Any(), int64(1)).Return([]byte("HYLL"), nil),
+					m.cache.EXPECT().RestoreReaders(gomock.Any(), int64(1), []byte("HYLL")).Return(nil),
+					m.cache.EXPECT().AddReader(gomock.Any(), int64(1), "uid:7", gomock.Any()).Return(true, nil),
+				)
+			},
+		},
+		{
+			name:     "匿名用户30秒内重复阅读-不重复计数",
+			userId:   0,
+			progress: domain.ReadProgressUnknown,
+			mock: func(m interactionMocks) {
+				m.cache.EXPECT().SetReadDedupe(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+		},
+		{
+			name:     "30秒内上报进度-只更新阅读历史",
+			userId:   7,
+			progress: 60,
+			mock: func(m interactionMocks) {
+				m.historyRepo.EXPECT().
+					Record(gomock.Any(), domain.ReadHistory{UserId: 7, PostId: 1, Progress: 60}).
+					Return(nil)
+				m.cache.EXPECT().SetReadDedupe(gomock.Any(), gomock.Any(), gomock.Any()).Return(false, nil)
+			},
+		},
+		{
+			name:     "进度超出范围",
+			userId:   7,
+			progress: 101,
+			mock:     func(m interactionMocks) {},
+			wantErr:  domain.ErrInvalidReadProgress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newInteractionMocks(ctrl)
+			tt.mock(m)
+			err := m.service().Read(context.Background(), 1, tt.userId, "127.0.0.1", "curl", tt.progress)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPostInteractionService_GetStatsBatch_UniqueReaders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newInteractionMocks(ctrl)
+	m.cache.EXPECT().BatchGet(gomock.Any(), []int64{1, 2}).Return(map[int64]domain.PostStats{
+		1: {PostId: 1, ReadCnt: 30},
+	}, nil)
+	// 帖子 2 的统计来自 MySQL 快照，Redis 中的 HyperLogLog 已丢失
+	m.statsRepo.EXPECT().FindByPostIds(gomock.Any(), []int64{2}).Return([]domain.PostStats{
+		{PostId: 2, ReadCnt: 50, UniqueReadCnt: 20},
+	}, nil)
+	m.cache.EXPECT().BatchSet(gomock.Any(), gomock.Any()).Return(nil)
+	m.cache.EXPECT().CountReaders(gomock.Any(), []int64{1, 2}).Return(map[int64]int64{1: 12, 2: 3}, nil)
+
+	stats, _, err := m.service().GetStatsBatch(context.Background(), []int64{1, 2}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), stats[1].UniqueReadCnt)
+	assert.Equal(t, int64(20), stats[2].UniqueReadCnt)
+}
@@ -5,8 +5,15 @@ import (
 )
 
 type PostStatsWorker struct {
-	consumer RabbitMQStatsConsumerWrapper
-	flusher  *PostStatsFlusher
+	consumer  RabbitMQStatsConsumerWrapper
+	flusher   *PostStatsFlusher
+	scheduler *PostPublishScheduler
+	indexer   *PostSearchIndexer
+	relay     *PostStatsOutboxRelay
+	reconcile *PostStatsReconciler
+	hotRanker *PostHotRanker
+	snapshots *PostReaderSnapshotter
+	notifyGC  *NotificationEventCleaner
 }
 
 // RabbitMQStatsConsumerWrapper wraps a consumer without exposing MQ package to main.
@@ -14,14 +21,28 @@ type RabbitMQStatsConsumerWrapper interface {
 	Start(ctx context.Context)
 }
 
-func NewPostStatsWorker(consumer RabbitMQStatsConsumerWrapper, flusher *PostStatsFlusher) *PostStatsWorker {
+func NewPostStatsWorker(consumer RabbitMQStatsConsumerWrapper, flusher *PostStatsFlusher, scheduler *PostPublishScheduler, indexer *PostSearchIndexer, relay *PostStatsOutboxRelay, reconcile *PostStatsReconciler, hotRanker *PostHotRanker, snapshots *PostReaderSnapshotter, notifyGC *NotificationEventCleaner) *PostStatsWorker {
 	return &PostStatsWorker{
-		consumer: consumer,
-		flusher:  flusher,
+		consumer:  consumer,
+		flusher:   flusher,
+		scheduler: scheduler,
+		indexer:   indexer,
+		relay:     relay,
+		reconcile: reconcile,
+		hotRanker: hotRanker,
+		snapshots: snapshots,
+		notifyGC:  notifyGC,
 	}
 }
 
 func (w *PostStatsWorker) Start(ctx context.Context) {
 	go w.consumer.Start(ctx)
 	go w.flusher.Start(ctx)
+	go w.scheduler.Start(ctx)
+	go w.indexer.Start(ctx)
+	go w.relay.Start(ctx)
+	go w.reconcile.Start(ctx)
+	go w.hotRanker.Start(ctx)
+	go w.snapshots.Start(ctx)
+	go w.notifyGC.Start(ctx)
 }
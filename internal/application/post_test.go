@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 	repomocks "webook/internal/adapters/outbound/mocks"
 	"webook/internal/domain"
 
@@ -82,7 +83,7 @@ func TestPostService_Save(t *testing.T) {
 			defer ctrl.Finish()
 
 			repo, pubRepo := tt.mock(ctrl)
-			svc := NewPostService(repo, pubRepo)
+			svc := NewPostService(repo, pubRepo, repomocks.NewMockUserRepository(ctrl))
 
 			id, err := svc.Save(context.Background(), tt.post)
 			assert.Equal(t, tt.wantId, id)
@@ -97,11 +98,14 @@ func TestPostService_Save(t *testing.T) {
 
 func TestPostService_Publish(t *testing.T) {
 	tests := []struct {
-		name    string
-		post    domain.Post
-		mock    func(ctrl *gomock.Controller) (*repomocks.MockPostRepository, *repomocks.MockPublishedPostRepository)
-		wantId  int64
-		wantErr error
+		name string
+		post domain.Post
+		mock func(ctrl *gomock.Controller) (*repomocks.MockPostRepository, *repomocks.MockPublishedPostRepository)
+		// unverified 作者邮箱未验证
+		unverified bool
+		status     domain.UserStatus
+		wantId     int64
+		wantErr    error
 	}{
 		{
 			name: "发布帖子成功",
@@ -141,6 +145,36 @@ func TestPostService_Publish(t *testing.T) {
 			wantId:  0,
 			wantErr: errors.New("同步失败"),
 		},
+		{
+			name: "发布帖子失败-邮箱未验证",
+			post: domain.Post{
+				Id:       1,
+				Title:    "发布的标题",
+				Content:  "发布的内容",
+				AuthorId: 1,
+			},
+			mock: func(ctrl *gomock.Controller) (*repomocks.MockPostRepository, *repomocks.MockPublishedPostRepository) {
+				return repomocks.NewMockPostRepository(ctrl), repomocks.NewMockPublishedPostRepository(ctrl)
+			},
+			unverified: true,
+			wantId:     0,
+			wantErr:    domain.ErrEmailNotVerified,
+		},
+		{
+			name: "发布帖子失败-账号已封禁",
+			post: domain.Post{
+				Id:       1,
+				Title:    "发布的标题",
+				Content:  "发布的内容",
+				AuthorId: 1,
+			},
+			mock: func(ctrl *gomock.Controller) (*repomocks.MockPostRepository, *repomocks.MockPublishedPostRepository) {
+				return repomocks.NewMockPostRepository(ctrl), repomocks.NewMockPublishedPostRepository(ctrl)
+			},
+			status:  domain.UserStatusBanned,
+			wantId:  0,
+			wantErr: domain.ErrUserBanned,
+		},
 	}
 
 	for _, tt := range tests {
@@ -149,12 +183,19 @@ func TestPostService_Publish(t *testing.T) {
 			defer ctrl.Finish()
 
 			repo, pubRepo := tt.mock(ctrl)
-			svc := NewPostService(repo, pubRepo)
+			userRepo := repomocks.NewMockUserRepository(ctrl)
+			userRepo.EXPECT().
+				FindById(gomock.Any(), tt.post.AuthorId).
+				Return(domain.User{Id: tt.post.AuthorId, EmailVerified: !tt.unverified, Status: tt.status}, nil)
+			svc := NewPostService(repo, pubRepo, userRepo)
 
 			id, err := svc.Publish(context.Background(), tt.post)
 			assert.Equal(t, tt.wantId, id)
 			if tt.wantErr != nil {
 				assert.Error(t, err)
+				if tt.unverified || tt.status != domain.UserStatusActive {
+					assert.ErrorIs(t, err, tt.wantErr)
+				}
 			} else {
 				assert.NoError(t, err)
 			}
@@ -218,7 +259,7 @@ func TestPostService_GetPublishedById(t *testing.T) {
 			defer ctrl.Finish()
 
 			repo, pubRepo := tt.mock(ctrl)
-			svc := NewPostService(repo, pubRepo)
+			svc := NewPostService(repo, pubRepo, repomocks.NewMockUserRepository(ctrl))
 
 			post, err := svc.GetPublishedById(context.Background(), tt.id)
 			assert.Equal(t, tt.wantPost, post)
@@ -296,7 +337,7 @@ func TestPostService_ListByAuthor(t *testing.T) {
 			defer ctrl.Finish()
 
 			repo, pubRepo := tt.mock(ctrl)
-			svc := NewPostService(repo, pubRepo)
+			svc := NewPostService(repo, pubRepo, repomocks.NewMockUserRepository(ctrl))
 
 			posts, total, err := svc.ListByAuthor(context.Background(), tt.authorId, tt.page, tt.pageSize)
 			assert.Equal(t, tt.wantPosts, posts)
@@ -310,6 +351,64 @@ func TestPostService_ListByAuthor(t *testing.T) {
 	}
 }
 
+func TestPostService_ListLikedBy(t *testing.T) {
+	tests := []struct {
+		name      string
+		mock      func(ctrl *gomock.Controller) (*repomocks.MockPostRepository, *repomocks.MockPublishedPostRepository)
+		wantPosts []domain.Post
+		wantTotal int64
+		wantErr   error
+	}{
+		{
+			name: "获取第二页",
+			mock: func(ctrl *gomock.Controller) (*repomocks.MockPostRepository, *repomocks.MockPublishedPostRepository) {
+				repo := repomocks.NewMockPostRepository(ctrl)
+				pubRepo := repomocks.NewMockPublishedPostRepository(ctrl)
+				pubRepo.EXPECT().
+					ListLikedBy(gomock.Any(), int64(3), 10, 10).
+					Return([]domain.Post{{Id: 5, Title: "帖子5", AuthorId: 1}}, nil)
+				pubRepo.EXPECT().
+					CountLikedBy(gomock.Any(), int64(3)).
+					Return(int64(11), nil)
+				return repo, pubRepo
+			},
+			wantPosts: []domain.Post{{Id: 5, Title: "帖子5", AuthorId: 1}},
+			wantTotal: 11,
+		},
+		{
+			name: "查询失败",
+			mock: func(ctrl *gomock.Controller) (*repomocks.MockPostRepository, *repomocks.MockPublishedPostRepository) {
+				repo := repomocks.NewMockPostRepository(ctrl)
+				pubRepo := repomocks.NewMockPublishedPostRepository(ctrl)
+				pubRepo.EXPECT().
+					ListLikedBy(gomock.Any(), int64(3), 10, 10).
+					Return(nil, errors.New("db error"))
+				return repo, pubRepo
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, pubRepo := tt.mock(ctrl)
+			svc := NewPostService(repo, pubRepo, repomocks.NewMockUserRepository(ctrl))
+
+			posts, total, err := svc.ListLikedBy(context.Background(), 3, 2, 10)
+			assert.Equal(t, tt.wantPosts, posts)
+			assert.Equal(t, tt.wantTotal, total)
+			if tt.wantErr != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestPostService_Delete(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -354,7 +453,7 @@ func TestPostService_Delete(t *testing.T) {
 			defer ctrl.Finish()
 
 			repo, pubRepo := tt.mock(ctrl)
-			svc := NewPostService(repo, pubRepo)
+			svc := NewPostService(repo, pubRepo, repomocks.NewMockUserRepository(ctrl))
 
 			err := svc.Delete(context.Background(), tt.id, tt.authorId)
 			if tt.wantErr != nil {
@@ -365,3 +464,176 @@ func TestPostService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestPostService_Schedule(t *testing.T) {
+	future := time.Now().Add(time.Hour).UnixMilli()
+	tests := []struct {
+		name        string
+		id          int64
+		authorId    int64
+		scheduledAt int64
+		mock        func(ctrl *gomock.Controller) (*repomocks.MockPostRepository, *repomocks.MockPublishedPostRepository)
+		wantErr     error
+	}{
+		{
+			name:        "设置定时发布成功",
+			id:          1,
+			authorId:    1,
+			scheduledAt: future,
+			mock: func(ctrl *gomock.Controller) (*repomocks.MockPostRepository, *repomocks.MockPublishedPostRepository) {
+				repo := repomocks.NewMockPostRepository(ctrl)
+				pubRepo := repomocks.NewMockPublishedPostRepository(ctrl)
+				repo.EXPECT().
+					Schedule(gomock.Any(), int64(1), int64(1), future).
+					Return(nil)
+				return repo, pubRepo
+			},
+			wantErr: nil,
+		},
+		{
+			name:        "设置定时发布失败-时间已过",
+			id:          1,
+			authorId:    1,
+			scheduledAt: time.Now().Add(-time.Minute).UnixMilli(),
+			mock: func(ctrl *gomock.Controller) (*repomocks.MockPostRepository, *repomocks.MockPublishedPostRepository) {
+				return repomocks.NewMockPostRepository(ctrl), repomocks.NewMockPublishedPostRepository(ctrl)
+			},
+			wantErr: domain.ErrInvalidScheduleTime,
+		},
+		{
+			name:        "设置定时发布失败-帖子不存在",
+			id:          999,
+			authorId:    1,
+			scheduledAt: future,
+			mock: func(ctrl *gomock.Controller) (*repomocks.MockPostRepository, *repomocks.MockPublishedPostRepository) {
+				repo := repomocks.NewMockPostRepository(ctrl)
+				pubRepo := repomocks.NewMockPublishedPostRepository(ctrl)
+				repo.EXPECT().
+					Schedule(gomock.Any(), int64(999), int64(1), future).
+					Return(domain.ErrPostNotFound)
+				return repo, pubRepo
+			},
+			wantErr: domain.ErrPostNotFound,
+		},
+		{
+			name:        "设置定时发布失败-帖子已发布",
+			id:          2,
+			authorId:    1,
+			scheduledAt: future,
+			mock: func(ctrl *gomock.Controller) (*repomocks.MockPostRepository, *repomocks.MockPublishedPostRepository) {
+				repo := repomocks.NewMockPostRepository(ctrl)
+				pubRepo := repomocks.NewMockPublishedPostRepository(ctrl)
+				repo.EXPECT().
+					Schedule(gomock.Any(), int64(2), int64(1), future).
+					Return(domain.ErrPostNotDraft)
+				return repo, pubRepo
+			},
+			wantErr: domain.ErrPostNotDraft,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo, pubRepo := tt.mock(ctrl)
+			userRepo := repomocks.NewMockUserRepository(ctrl)
+			userRepo.EXPECT().
+				FindById(gomock.Any(), tt.authorId).
+				Return(domain.User{Id: tt.authorId, EmailVerified: true}, nil).
+				AnyTimes()
+			svc := NewPostService(repo, pubRepo, userRepo)
+
+			err := svc.Schedule(context.Background(), tt.id, tt.authorId, tt.scheduledAt)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPostService_Reschedule(t *testing.T) {
+	future := time.Now().Add(time.Hour).UnixMilli()
+	tests := []struct {
+		name        string
+		scheduledAt int64
+		mock        func(repo *repomocks.MockPostRepository)
+		wantErr     error
+	}{
+		{
+			name:        "修改定时发布时间成功",
+			scheduledAt: future,
+			mock: func(repo *repomocks.MockPostRepository) {
+				repo.EXPECT().Reschedule(gomock.Any(), int64(1), int64(1), future).Return(nil)
+			},
+		},
+		{
+			name:        "修改失败-时间已过",
+			scheduledAt: time.Now().Add(-time.Minute).UnixMilli(),
+			wantErr:     domain.ErrInvalidScheduleTime,
+		},
+		{
+			name:        "修改失败-帖子未设置定时发布",
+			scheduledAt: future,
+			mock: func(repo *repomocks.MockPostRepository) {
+				repo.EXPECT().Reschedule(gomock.Any(), int64(1), int64(1), future).Return(domain.ErrPostNotScheduled)
+			},
+			wantErr: domain.ErrPostNotScheduled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repomocks.NewMockPostRepository(ctrl)
+			if tt.mock != nil {
+				tt.mock(repo)
+			}
+			svc := NewPostService(repo, repomocks.NewMockPublishedPostRepository(ctrl), repomocks.NewMockUserRepository(ctrl))
+
+			err := svc.Reschedule(context.Background(), 1, 1, tt.scheduledAt)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPostService_CancelSchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		mock    func(repo *repomocks.MockPostRepository)
+		wantErr error
+	}{
+		{
+			name: "取消定时发布成功",
+			mock: func(repo *repomocks.MockPostRepository) {
+				repo.EXPECT().CancelSchedule(gomock.Any(), int64(1), int64(1)).Return(nil)
+			},
+		},
+		{
+			name: "取消失败-已经发布或未设置定时",
+			mock: func(repo *repomocks.MockPostRepository) {
+				repo.EXPECT().CancelSchedule(gomock.Any(), int64(1), int64(1)).Return(domain.ErrPostNotScheduled)
+			},
+			wantErr: domain.ErrPostNotScheduled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repomocks.NewMockPostRepository(ctrl)
+			tt.mock(repo)
+			svc := NewPostService(repo, repomocks.NewMockPublishedPostRepository(ctrl), repomocks.NewMockUserRepository(ctrl))
+
+			err := svc.CancelSchedule(context.Background(), 1, 1)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
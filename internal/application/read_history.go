@@ -0,0 +1,62 @@
+package application
+
+import (
+	"context"
+	"webook/internal/domain"
+	input "webook/internal/ports/input"
+	output "webook/internal/ports/output"
+)
+
+type readHistoryService struct {
+	repo    output.ReadHistoryRepository
+	pubRepo output.PublishedPostRepository
+}
+
+func NewReadHistoryService(repo output.ReadHistoryRepository, pubRepo output.PublishedPostRepository) input.ReadHistoryService {
+	return &readHistoryService{
+		repo:    repo,
+		pubRepo: pubRepo,
+	}
+}
+
+func (s *readHistoryService) List(ctx context.Context, userId int64, cursor domain.ReadHistoryCursor, limit int, unfinished bool) ([]domain.ReadHistory, error) {
+	histories, err := s.repo.FindByUser(ctx, userId, cursor, limit, unfinished)
+	if err != nil {
+		return nil, err
+	}
+	if len(histories) == 0 {
+		return histories, nil
+	}
+
+	ids := make([]int64, len(histories))
+	for i, h := range histories {
+		ids[i] = h.PostId
+	}
+	posts, err := s.pubRepo.FindByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byId := make(map[int64]domain.Post, len(posts))
+	for _, p := range posts {
+		byId[p.Id] = p
+	}
+	result := make([]domain.ReadHistory, 0, len(histories))
+	for _, h := range histories {
+		// 两次查询之间被下线的帖子
+		p, ok := byId[h.PostId]
+		if !ok {
+			continue
+		}
+		h.Post = p
+		result = append(result, h)
+	}
+	return result, nil
+}
+
+func (s *readHistoryService) Delete(ctx context.Context, userId, postId int64) error {
+	return s.repo.Delete(ctx, userId, postId)
+}
+
+func (s *readHistoryService) Clear(ctx context.Context, userId int64) error {
+	return s.repo.DeleteByUser(ctx, userId)
+}
@@ -0,0 +1,41 @@
+package application
+
+import (
+	"context"
+	"testing"
+	repomocks "webook/internal/adapters/outbound/mocks"
+	"webook/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReadHistoryService_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repomocks.NewMockReadHistoryRepository(ctrl)
+	pubRepo := repomocks.NewMockPublishedPostRepository(ctrl)
+	cursor := domain.ReadHistoryCursor{ReadAt: 1700000000000, Id: 20}
+	repo.EXPECT().FindByUser(gomock.Any(), int64(7), cursor, 3, true).Return([]domain.ReadHistory{
+		{Id: 13, UserId: 7, PostId: 3, Progress: 40, ReadAt: 1699999999000},
+		{Id: 12, UserId: 7, PostId: 2, Progress: 0, ReadAt: 1699999998000},
+		{Id: 11, UserId: 7, PostId: 1, Progress: 90, ReadAt: 1699999998000},
+	}, nil)
+	// 帖子 2 在两次查询之间被下线
+	pubRepo.EXPECT().FindByIds(gomock.Any(), []int64{3, 2, 1}).Return([]domain.Post{
+		{Id: 1, Title: "帖子1"},
+		{Id: 3, Title: "帖子3"},
+	}, nil)
+
+	svc := NewReadHistoryService(repo, pubRepo)
+	histories, err := svc.List(context.Background(), 7, cursor, 3, true)
+	require.NoError(t, err)
+	require.Len(t, histories, 2)
+	assert.Equal(t, "帖子3", histories[0].Post.Title)
+	assert.Equal(t, 40, histories[0].Progress)
+	assert.Equal(t, "帖子1", histories[1].Post.Title)
+	assert.Equal(t, int64(1699999998000), histories[1].ReadAt)
+	assert.Equal(t, int64(11), histories[1].Id)
+}
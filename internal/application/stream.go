@@ -0,0 +1,122 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"webook/internal/domain"
+	input "webook/internal/ports/input"
+	output "webook/internal/ports/output"
+)
+
+type streamService struct {
+	broker  output.StreamBroker
+	cache   output.PostStatsCache
+	tickets output.StreamTicketStore
+}
+
+func NewStreamService(broker output.StreamBroker, cache output.PostStatsCache, tickets output.StreamTicketStore) input.StreamService {
+	return &streamService{
+		broker:  broker,
+		cache:   cache,
+		tickets: tickets,
+	}
+}
+
+func (s *streamService) IssueTicket(ctx context.Context, userId int64) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	ticket := base64.RawURLEncoding.EncodeToString(b)
+	if err := s.tickets.Save(ctx, ticket, userId, domain.StreamTicketTTL); err != nil {
+		return "", err
+	}
+	return ticket, nil
+}
+
+func (s *streamService) RedeemTicket(ctx context.Context, ticket string) (int64, error) {
+	return s.tickets.Consume(ctx, ticket)
+}
+
+func (s *streamService) PublishStats(ctx context.Context, postId int64) error {
+	stats, err := s.cache.Get(ctx, postId)
+	if err != nil {
+		return err
+	}
+	return s.broker.Publish(ctx, domain.StreamEvent{
+		Type:   domain.StreamEventStats,
+		PostId: postId,
+		Stats:  stats,
+	})
+}
+
+func (s *streamService) Subscribe(ctx context.Context, userId, lastEventId int64, postIds []int64) (<-chan domain.StreamEvent, error) {
+	// 先订阅再查补发，避免两者之间发布的事件被漏掉；重复的由下面按 ID 过滤
+	live, err := s.broker.Subscribe(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var missed []domain.StreamEvent
+	if lastEventId > 0 {
+		missed, err = s.broker.Replay(ctx, userId, lastEventId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	watched := make(map[int64]struct{}, len(postIds))
+	for _, id := range postIds {
+		watched[id] = struct{}{}
+	}
+	out := make(chan domain.StreamEvent, len(missed)+1)
+	go func() {
+		defer close(out)
+		lastId := lastEventId
+		for _, event := range missed {
+			if !s.send(ctx, out, event) {
+				return
+			}
+			lastId = event.Id
+		}
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-live:
+				if !ok {
+					return
+				}
+				if event.Id <= lastId || !matchStreamEvent(event, userId, watched) {
+					continue
+				}
+				if !s.send(ctx, out, event) {
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (s *streamService) send(ctx context.Context, out chan<- domain.StreamEvent, event domain.StreamEvent) bool {
+	select {
+	case out <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// matchStreamEvent 事件是否推给该连接：通知只推给接收人，计数只推给订阅了该帖子的连接
+func matchStreamEvent(event domain.StreamEvent, userId int64, watched map[int64]struct{}) bool {
+	switch event.Type {
+	case domain.StreamEventNotification:
+		return event.UserId == userId
+	case domain.StreamEventStats:
+		_, ok := watched[event.PostId]
+		return ok
+	default:
+		return false
+	}
+}
@@ -0,0 +1,105 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+	repomocks "webook/internal/adapters/outbound/mocks"
+	"webook/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStreamService_Subscribe(t *testing.T) {
+	notify := func(id, userId int64) domain.StreamEvent {
+		return domain.StreamEvent{Id: id, Type: domain.StreamEventNotification, UserId: userId}
+	}
+	stats := func(id, postId int64) domain.StreamEvent {
+		return domain.StreamEvent{Id: id, Type: domain.StreamEventStats, PostId: postId}
+	}
+
+	tests := []struct {
+		name        string
+		lastEventId int64
+		replay      []domain.StreamEvent
+		live        []domain.StreamEvent
+		wantIds     []int64
+	}{
+		{
+			name: "只推送自己的通知和订阅帖子的计数",
+			live: []domain.StreamEvent{
+				notify(1, 5), notify(2, 6), stats(3, 10), stats(4, 11),
+			},
+			wantIds: []int64{1, 3},
+		},
+		{
+			name:        "重连-先补发错过的通知，跳过已补发的事件",
+			lastEventId: 2,
+			replay:      []domain.StreamEvent{notify(3, 5), notify(5, 5)},
+			live: []domain.StreamEvent{
+				notify(5, 5), stats(4, 10), stats(6, 10), notify(7, 5),
+			},
+			wantIds: []int64{3, 5, 6, 7},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			broker := repomocks.NewMockStreamBroker(ctrl)
+			live := make(chan domain.StreamEvent, len(tt.live))
+			for _, e := range tt.live {
+				live <- e
+			}
+			close(live)
+			broker.EXPECT().Subscribe(gomock.Any()).Return((<-chan domain.StreamEvent)(live), nil)
+			if tt.lastEventId > 0 {
+				broker.EXPECT().Replay(gomock.Any(), int64(5), tt.lastEventId).Return(tt.replay, nil)
+			}
+			svc := NewStreamService(broker, repomocks.NewMockPostStatsCache(ctrl), repomocks.NewMockStreamTicketStore(ctrl))
+
+			events, err := svc.Subscribe(ctx, 5, tt.lastEventId, []int64{10})
+			require.NoError(t, err)
+			ids := []int64{}
+			for e := range events {
+				ids = append(ids, e.Id)
+			}
+			assert.Equal(t, tt.wantIds, ids)
+		})
+	}
+}
+
+func TestStreamService_Ticket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tickets := repomocks.NewMockStreamTicketStore(ctrl)
+	var saved string
+	tickets.EXPECT().Save(gomock.Any(), gomock.Any(), int64(5), domain.StreamTicketTTL).
+		DoAndReturn(func(_ context.Context, ticket string, _ int64, _ time.Duration) error {
+			saved = ticket
+			return nil
+		})
+	svc := NewStreamService(repomocks.NewMockStreamBroker(ctrl), repomocks.NewMockPostStatsCache(ctrl), tickets)
+
+	ticket, err := svc.IssueTicket(context.Background(), 5)
+	require.NoError(t, err)
+	assert.Len(t, ticket, 43)
+	assert.Equal(t, saved, ticket)
+
+	tickets.EXPECT().Consume(gomock.Any(), ticket).Return(int64(5), nil)
+	uid, err := svc.RedeemTicket(context.Background(), ticket)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), uid)
+
+	// 票据只能用一次
+	tickets.EXPECT().Consume(gomock.Any(), ticket).Return(int64(0), domain.ErrInvalidStreamTicket)
+	_, err = svc.RedeemTicket(context.Background(), ticket)
+	assert.ErrorIs(t, err, domain.ErrInvalidStreamTicket)
+}
@@ -0,0 +1,56 @@
+package application
+
+import (
+	"context"
+	"unicode/utf8"
+	"webook/internal/domain"
+	input "webook/internal/ports/input"
+	output "webook/internal/ports/output"
+)
+
+// 标签名最大长度（字符数）
+const maxTagNameLen = 32
+
+type tagService struct {
+	repo output.TagRepository
+}
+
+func NewTagService(repo output.TagRepository) input.TagService {
+	return &tagService{repo: repo}
+}
+
+func (s *tagService) Create(ctx context.Context, name string) (int64, error) {
+	name, err := normalizeTagName(name)
+	if err != nil {
+		return 0, err
+	}
+	return s.repo.Create(ctx, domain.Tag{Name: name})
+}
+
+func (s *tagService) Rename(ctx context.Context, id int64, name string) error {
+	name, err := normalizeTagName(name)
+	if err != nil {
+		return err
+	}
+	return s.repo.Update(ctx, domain.Tag{Id: id, Name: name})
+}
+
+func (s *tagService) Delete(ctx context.Context, id int64) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *tagService) GetById(ctx context.Context, id int64) (domain.Tag, error) {
+	return s.repo.FindById(ctx, id)
+}
+
+func (s *tagService) ListWithPostCount(ctx context.Context) ([]domain.Tag, error) {
+	return s.repo.ListWithPostCount(ctx)
+}
+
+func normalizeTagName(name string) (string, error) {
+	name = domain.NormalizeTagName(name)
+	if name == "" || utf8.RuneCountInString(name) > maxTagNameLen {
+		return "", domain.ErrInvalidTagName
+	}
+	return name, nil
+}
@@ -0,0 +1,110 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+	repomocks "webook/internal/adapters/outbound/mocks"
+	"webook/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTagService_Create(t *testing.T) {
+	tests := []struct {
+		name    string
+		tagName string
+		mock    func(repo *repomocks.MockTagRepository)
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name:    "创建成功-名称统一为小写并去除空白",
+			tagName: "  Golang ",
+			mock: func(repo *repomocks.MockTagRepository) {
+				repo.EXPECT().Create(gomock.Any(), domain.Tag{Name: "golang"}).Return(int64(1), nil)
+			},
+			wantId: 1,
+		},
+		{
+			name:    "名称重复",
+			tagName: "go",
+			mock: func(repo *repomocks.MockTagRepository) {
+				repo.EXPECT().Create(gomock.Any(), domain.Tag{Name: "go"}).Return(int64(0), domain.ErrDuplicateTag)
+			},
+			wantErr: domain.ErrDuplicateTag,
+		},
+		{
+			name:    "名称为空",
+			tagName: "   ",
+			wantErr: domain.ErrInvalidTagName,
+		},
+		{
+			name:    "名称过长",
+			tagName: strings.Repeat("标", maxTagNameLen+1),
+			wantErr: domain.ErrInvalidTagName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repomocks.NewMockTagRepository(ctrl)
+			if tt.mock != nil {
+				tt.mock(repo)
+			}
+
+			id, err := NewTagService(repo).Create(context.Background(), tt.tagName)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantId, id)
+		})
+	}
+}
+
+func TestTagService_Rename(t *testing.T) {
+	tests := []struct {
+		name    string
+		tagName string
+		mock    func(repo *repomocks.MockTagRepository)
+		wantErr error
+	}{
+		{
+			name:    "重命名成功",
+			tagName: "Rust",
+			mock: func(repo *repomocks.MockTagRepository) {
+				repo.EXPECT().Update(gomock.Any(), domain.Tag{Id: 1, Name: "rust"}).Return(nil)
+			},
+		},
+		{
+			name:    "标签不存在",
+			tagName: "rust",
+			mock: func(repo *repomocks.MockTagRepository) {
+				repo.EXPECT().Update(gomock.Any(), domain.Tag{Id: 1, Name: "rust"}).Return(domain.ErrTagNotFound)
+			},
+			wantErr: domain.ErrTagNotFound,
+		},
+		{
+			name:    "名称为空",
+			tagName: "",
+			wantErr: domain.ErrInvalidTagName,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repomocks.NewMockTagRepository(ctrl)
+			if tt.mock != nil {
+				tt.mock(repo)
+			}
+
+			err := NewTagService(repo).Rename(context.Background(), 1, tt.tagName)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
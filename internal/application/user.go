@@ -2,6 +2,10 @@ package application
 
 import (
 	"context"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
 	"webook/internal/domain"
 	input "webook/internal/ports/input"
 	output "webook/internal/ports/output"
@@ -9,13 +13,40 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	maxNicknameLen  = 24
+	maxAvatarLen    = 512
+	maxBioLen       = 200
+	birthdayLayout  = "2006-01-02"
+	minBirthdayYear = 1900
+)
+
+// LoginGuardOptions 登录失败锁定的配置。失败次数达到阈值时锁定 BaseLockout，
+// 之后每多失败一次锁定时长翻倍，最长 MaxLockout
+type LoginGuardOptions struct {
+	MaxEmailFailures int64         // 同一邮箱连续失败多少次后锁定
+	MaxIPFailures    int64         // 同一 IP 连续失败多少次后锁定，多人共用出口 IP，阈值应更高
+	BaseLockout      time.Duration // 首次锁定时长
+	MaxLockout       time.Duration // 锁定时长上限
+	FailureWindow    time.Duration // 失败计数在最后一次失败后保留的时长，需长于 MaxLockout
+}
+
 type userService struct {
-	repo output.UserRepository
+	repo     output.UserRepository
+	pubRepo  output.PublishedPostRepository
+	attempts output.LoginAttemptCache
+	audit    output.LoginAuditRepository
+	guard    LoginGuardOptions
 }
 
-func NewUserService(repo output.UserRepository) input.UserService {
+func NewUserService(repo output.UserRepository, pubRepo output.PublishedPostRepository, attempts output.LoginAttemptCache,
+	audit output.LoginAuditRepository, guard LoginGuardOptions) input.UserService {
 	return &userService{
-		repo: repo,
+		repo:     repo,
+		pubRepo:  pubRepo,
+		attempts: attempts,
+		audit:    audit,
+		guard:    guard,
 	}
 }
 
@@ -28,19 +59,106 @@ func (svc *userService) SignUp(ctx context.Context, u domain.User) error {
 	return svc.repo.Create(ctx, u)
 }
 
-func (svc *userService) Login(ctx context.Context, email, password string) (domain.User, error) {
-	u, err := svc.repo.FindByEmail(ctx, email)
-	if err != nil {
-		return domain.User{}, domain.ErrInvalidUserOrPassword
+func (svc *userService) Login(ctx context.Context, email, password string, client domain.LoginClient) (domain.User, error) {
+	attempt := domain.LoginAttempt{
+		Email:     normalizeLoginEmail(email),
+		IP:        client.IP,
+		UserAgent: client.UserAgent,
+	}
+	if err := svc.checkLocked(ctx, attempt.Email, client.IP); err != nil {
+		attempt.Result = domain.LoginResultLocked
+		svc.recordAttempt(ctx, attempt)
+		return domain.User{}, err
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
+	u, err := svc.repo.FindByEmail(ctx, email)
+	if err == nil {
+		attempt.UserId = u.Id
+		err = bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
+	}
 	if err != nil {
+		svc.recordFailure(ctx, attempt.Email, client.IP)
+		attempt.Result = domain.LoginResultBadCredentials
+		svc.recordAttempt(ctx, attempt)
 		return domain.User{}, domain.ErrInvalidUserOrPassword
 	}
+	// 密码正确才提示账号已停用或封禁，避免通过登录探测账号状态
+	if err = u.Status.Err(); err != nil {
+		attempt.Result = domain.LoginResultDisabled
+		svc.recordAttempt(ctx, attempt)
+		return domain.User{}, err
+	}
+
+	// 只清空邮箱的计数；IP 的计数不清空，否则攻击者可以穿插登录自己的账号来重置它
+	_ = svc.attempts.Reset(ctx, domain.LoginScopeEmail, attempt.Email)
+	attempt.Result = domain.LoginResultSuccess
+	svc.recordAttempt(ctx, attempt)
 	return u, nil
 }
 
+// checkLocked 邮箱或 IP 处于锁定期时返回 *domain.LoginLockedError。
+// Redis 不可用时放行，不因限流组件故障让所有人无法登录
+func (svc *userService) checkLocked(ctx context.Context, email, ip string) error {
+	for _, k := range loginGuardKeys(email, ip) {
+		d, err := svc.attempts.LockedFor(ctx, k.scope, k.value)
+		if err != nil {
+			return nil
+		}
+		if d > 0 {
+			return &domain.LoginLockedError{Scope: k.scope, RetryAfter: d}
+		}
+	}
+	return nil
+}
+
+// recordFailure 累加失败次数，达到阈值后按超出的次数指数增加锁定时长
+func (svc *userService) recordFailure(ctx context.Context, email, ip string) {
+	for _, k := range loginGuardKeys(email, ip) {
+		limit := svc.guard.MaxEmailFailures
+		if k.scope == domain.LoginScopeIP {
+			limit = svc.guard.MaxIPFailures
+		}
+		n, err := svc.attempts.IncrFailure(ctx, k.scope, k.value, svc.guard.FailureWindow)
+		if err != nil || limit <= 0 || n < limit {
+			continue
+		}
+		_ = svc.attempts.Lock(ctx, k.scope, k.value, svc.lockoutFor(n-limit))
+	}
+}
+
+// lockoutFor 超出阈值 over 次时的锁定时长：BaseLockout * 2^over，不超过 MaxLockout
+func (svc *userService) lockoutFor(over int64) time.Duration {
+	d := svc.guard.BaseLockout
+	for ; over > 0 && d < svc.guard.MaxLockout; over-- {
+		d *= 2
+	}
+	return min(d, svc.guard.MaxLockout)
+}
+
+// recordAttempt 写审计日志，写入失败不影响登录结果
+func (svc *userService) recordAttempt(ctx context.Context, attempt domain.LoginAttempt) {
+	_ = svc.audit.Create(context.WithoutCancel(ctx), attempt)
+}
+
+type loginGuardKey struct {
+	scope domain.LoginScope
+	value string
+}
+
+// loginGuardKeys 需要计数的维度，拿不到客户端 IP 时只按邮箱计数
+func loginGuardKeys(email, ip string) []loginGuardKey {
+	keys := []loginGuardKey{{scope: domain.LoginScopeEmail, value: email}}
+	if ip != "" {
+		keys = append(keys, loginGuardKey{scope: domain.LoginScopeIP, value: ip})
+	}
+	return keys
+}
+
+// normalizeLoginEmail 计数和审计用的邮箱，大小写不同的写法视为同一个
+func normalizeLoginEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
 func (svc *userService) Profile(ctx context.Context, id int64) (domain.User, error) {
 	return svc.repo.FindById(ctx, id)
 }
@@ -63,3 +181,78 @@ func (svc *userService) UpdatePassword(ctx context.Context, id int64, oldPwd, ne
 	u.Password = string(hash)
 	return svc.repo.Update(ctx, u)
 }
+
+func (svc *userService) UpdateProfile(ctx context.Context, u domain.User) error {
+	u, err := normalizeProfile(u, time.Now())
+	if err != nil {
+		return err
+	}
+	return svc.repo.UpdateProfile(ctx, u)
+}
+
+func (svc *userService) PublicProfile(ctx context.Context, id int64) (domain.UserPublicProfile, error) {
+	u, err := svc.repo.FindById(ctx, id)
+	if err != nil {
+		return domain.UserPublicProfile{}, err
+	}
+	postCnt, err := svc.pubRepo.CountByAuthor(ctx, id)
+	if err != nil {
+		return domain.UserPublicProfile{}, err
+	}
+	likeCnt, err := svc.pubRepo.SumLikesByAuthor(ctx, id)
+	if err != nil {
+		return domain.UserPublicProfile{}, err
+	}
+	return domain.UserPublicProfile{
+		Id:       u.Id,
+		Nickname: u.Nickname,
+		Avatar:   u.Avatar,
+		Bio:      u.Bio,
+		Ctime:    u.Ctime,
+		PostCnt:  postCnt,
+		LikeCnt:  likeCnt,
+	}, nil
+}
+
+func (svc *userService) Unlock(ctx context.Context, id int64) error {
+	u, err := svc.repo.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+	return svc.attempts.Reset(ctx, domain.LoginScopeEmail, normalizeLoginEmail(u.Email))
+}
+
+func (svc *userService) LoginAttempts(ctx context.Context, id int64, limit int) ([]domain.LoginAttempt, error) {
+	u, err := svc.repo.FindById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return svc.audit.FindByEmail(ctx, normalizeLoginEmail(u.Email), limit)
+}
+
+// normalizeProfile 去除首尾空白并校验资料字段，空字段表示清空
+func normalizeProfile(u domain.User, now time.Time) (domain.User, error) {
+	u.Nickname = strings.TrimSpace(u.Nickname)
+	u.Avatar = strings.TrimSpace(u.Avatar)
+	u.Bio = strings.TrimSpace(u.Bio)
+	u.Birthday = strings.TrimSpace(u.Birthday)
+	if utf8.RuneCountInString(u.Nickname) > maxNicknameLen ||
+		utf8.RuneCountInString(u.Bio) > maxBioLen ||
+		len(u.Avatar) > maxAvatarLen {
+		return u, domain.ErrInvalidProfile
+	}
+	if u.Avatar != "" {
+		// 头像只接受 http(s) 链接，避免 javascript: 等协议
+		parsed, err := url.Parse(u.Avatar)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return u, domain.ErrInvalidProfile
+		}
+	}
+	if u.Birthday != "" {
+		birthday, err := time.Parse(birthdayLayout, u.Birthday)
+		if err != nil || birthday.Year() < minBirthdayYear || birthday.After(now) {
+			return u, domain.ErrInvalidProfile
+		}
+	}
+	return u, nil
+}
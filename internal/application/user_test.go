@@ -3,15 +3,31 @@ package application
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 	repomocks "webook/internal/adapters/outbound/mocks"
 	"webook/internal/domain"
+	input "webook/internal/ports/input"
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"golang.org/x/crypto/bcrypt"
 )
 
+var testLoginGuard = LoginGuardOptions{
+	MaxEmailFailures: 5,
+	MaxIPFailures:    20,
+	BaseLockout:      time.Minute,
+	MaxLockout:       time.Hour,
+	FailureWindow:    24 * time.Hour,
+}
+
+func newTestUserService(ctrl *gomock.Controller, repo *repomocks.MockUserRepository) input.UserService {
+	return NewUserService(repo, repomocks.NewMockPublishedPostRepository(ctrl),
+		repomocks.NewMockLoginAttemptCache(ctrl), repomocks.NewMockLoginAuditRepository(ctrl), testLoginGuard)
+}
+
 func TestUserService_SignUp(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -57,7 +73,7 @@ func TestUserService_SignUp(t *testing.T) {
 			defer ctrl.Finish()
 
 			repo := tt.mock(ctrl)
-			svc := NewUserService(repo)
+			svc := newTestUserService(ctrl, repo)
 
 			err := svc.SignUp(context.Background(), tt.user)
 			if tt.wantErr != nil {
@@ -71,68 +87,103 @@ func TestUserService_SignUp(t *testing.T) {
 
 func TestUserService_Login(t *testing.T) {
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	user := domain.User{
+		Id:       1,
+		Email:    "test@example.com",
+		Password: string(hashedPassword),
+	}
+	client := domain.LoginClient{IP: "1.2.3.4", UserAgent: "test-agent"}
+
+	notLocked := func(attempts *repomocks.MockLoginAttemptCache) {
+		attempts.EXPECT().LockedFor(gomock.Any(), domain.LoginScopeEmail, gomock.Any()).Return(time.Duration(0), nil)
+		attempts.EXPECT().LockedFor(gomock.Any(), domain.LoginScopeIP, "1.2.3.4").Return(time.Duration(0), nil)
+	}
 
 	tests := []struct {
-		name     string
-		email    string
-		password string
-		mock     func(ctrl *gomock.Controller) *repomocks.MockUserRepository
-		wantUser domain.User
-		wantErr  error
+		name       string
+		email      string
+		password   string
+		mock       func(repo *repomocks.MockUserRepository, attempts *repomocks.MockLoginAttemptCache)
+		wantResult domain.LoginResult
+		wantUser   domain.User
+		wantErr    error
 	}{
 		{
-			name:     "login success",
-			email:    "test@example.com",
+			name:     "login success - resets email counter",
+			email:    "Test@Example.com",
 			password: "password123",
-			mock: func(ctrl *gomock.Controller) *repomocks.MockUserRepository {
-				repo := repomocks.NewMockUserRepository(ctrl)
-				repo.EXPECT().
-					FindByEmail(gomock.Any(), "test@example.com").
-					Return(domain.User{
-						Id:       1,
-						Email:    "test@example.com",
-						Password: string(hashedPassword),
-					}, nil)
-				return repo
+			mock: func(repo *repomocks.MockUserRepository, attempts *repomocks.MockLoginAttemptCache) {
+				notLocked(attempts)
+				repo.EXPECT().FindByEmail(gomock.Any(), "Test@Example.com").Return(user, nil)
+				attempts.EXPECT().Reset(gomock.Any(), domain.LoginScopeEmail, "test@example.com").Return(nil)
 			},
-			wantUser: domain.User{
-				Id:       1,
-				Email:    "test@example.com",
-				Password: string(hashedPassword),
+			wantResult: domain.LoginResultSuccess,
+			wantUser:   user,
+		},
+		{
+			name:     "login rejected - user banned",
+			email:    "test@example.com",
+			password: "password123",
+			mock: func(repo *repomocks.MockUserRepository, attempts *repomocks.MockLoginAttemptCache) {
+				notLocked(attempts)
+				banned := user
+				banned.Status = domain.UserStatusBanned
+				repo.EXPECT().FindByEmail(gomock.Any(), "test@example.com").Return(banned, nil)
 			},
-			wantErr: nil,
+			wantResult: domain.LoginResultDisabled,
+			wantErr:    domain.ErrUserBanned,
 		},
 		{
 			name:     "login failed - user not found",
 			email:    "notexist@example.com",
 			password: "password123",
-			mock: func(ctrl *gomock.Controller) *repomocks.MockUserRepository {
-				repo := repomocks.NewMockUserRepository(ctrl)
-				repo.EXPECT().
-					FindByEmail(gomock.Any(), "notexist@example.com").
-					Return(domain.User{}, errors.New("user not found"))
-				return repo
+			mock: func(repo *repomocks.MockUserRepository, attempts *repomocks.MockLoginAttemptCache) {
+				notLocked(attempts)
+				repo.EXPECT().FindByEmail(gomock.Any(), "notexist@example.com").Return(domain.User{}, errors.New("user not found"))
+				attempts.EXPECT().IncrFailure(gomock.Any(), domain.LoginScopeEmail, "notexist@example.com", 24*time.Hour).Return(int64(1), nil)
+				attempts.EXPECT().IncrFailure(gomock.Any(), domain.LoginScopeIP, "1.2.3.4", 24*time.Hour).Return(int64(1), nil)
 			},
-			wantUser: domain.User{},
-			wantErr:  domain.ErrInvalidUserOrPassword,
+			wantResult: domain.LoginResultBadCredentials,
+			wantErr:    domain.ErrInvalidUserOrPassword,
 		},
 		{
-			name:     "login failed - wrong password",
+			name:     "login failed - wrong password reaches threshold",
 			email:    "test@example.com",
 			password: "wrongpassword",
-			mock: func(ctrl *gomock.Controller) *repomocks.MockUserRepository {
-				repo := repomocks.NewMockUserRepository(ctrl)
-				repo.EXPECT().
-					FindByEmail(gomock.Any(), "test@example.com").
-					Return(domain.User{
-						Id:       1,
-						Email:    "test@example.com",
-						Password: string(hashedPassword),
-					}, nil)
-				return repo
+			mock: func(repo *repomocks.MockUserRepository, attempts *repomocks.MockLoginAttemptCache) {
+				notLocked(attempts)
+				repo.EXPECT().FindByEmail(gomock.Any(), "test@example.com").Return(user, nil)
+				attempts.EXPECT().IncrFailure(gomock.Any(), domain.LoginScopeEmail, "test@example.com", 24*time.Hour).Return(int64(5), nil)
+				attempts.EXPECT().Lock(gomock.Any(), domain.LoginScopeEmail, "test@example.com", time.Minute).Return(nil)
+				attempts.EXPECT().IncrFailure(gomock.Any(), domain.LoginScopeIP, "1.2.3.4", 24*time.Hour).Return(int64(5), nil)
 			},
-			wantUser: domain.User{},
-			wantErr:  domain.ErrInvalidUserOrPassword,
+			wantResult: domain.LoginResultBadCredentials,
+			wantErr:    domain.ErrInvalidUserOrPassword,
+		},
+		{
+			name:     "login failed - lockout doubles and is capped",
+			email:    "test@example.com",
+			password: "wrongpassword",
+			mock: func(repo *repomocks.MockUserRepository, attempts *repomocks.MockLoginAttemptCache) {
+				notLocked(attempts)
+				repo.EXPECT().FindByEmail(gomock.Any(), "test@example.com").Return(user, nil)
+				attempts.EXPECT().IncrFailure(gomock.Any(), domain.LoginScopeEmail, "test@example.com", 24*time.Hour).Return(int64(7), nil)
+				attempts.EXPECT().Lock(gomock.Any(), domain.LoginScopeEmail, "test@example.com", 4*time.Minute).Return(nil)
+				attempts.EXPECT().IncrFailure(gomock.Any(), domain.LoginScopeIP, "1.2.3.4", 24*time.Hour).Return(int64(40), nil)
+				attempts.EXPECT().Lock(gomock.Any(), domain.LoginScopeIP, "1.2.3.4", time.Hour).Return(nil)
+			},
+			wantResult: domain.LoginResultBadCredentials,
+			wantErr:    domain.ErrInvalidUserOrPassword,
+		},
+		{
+			name:     "login rejected - email locked",
+			email:    "test@example.com",
+			password: "password123",
+			mock: func(repo *repomocks.MockUserRepository, attempts *repomocks.MockLoginAttemptCache) {
+				attempts.EXPECT().LockedFor(gomock.Any(), domain.LoginScopeEmail, "test@example.com").Return(2*time.Minute, nil)
+			},
+			wantResult: domain.LoginResultLocked,
+			wantErr:    domain.ErrLoginLocked,
 		},
 	}
 
@@ -141,10 +192,18 @@ func TestUserService_Login(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 
-			repo := tt.mock(ctrl)
-			svc := NewUserService(repo)
+			repo := repomocks.NewMockUserRepository(ctrl)
+			attempts := repomocks.NewMockLoginAttemptCache(ctrl)
+			audit := repomocks.NewMockLoginAuditRepository(ctrl)
+			tt.mock(repo, attempts)
+			audit.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, a domain.LoginAttempt) error {
+				assert.Equal(t, tt.wantResult, a.Result)
+				assert.Equal(t, "1.2.3.4", a.IP)
+				return nil
+			})
+			svc := NewUserService(repo, repomocks.NewMockPublishedPostRepository(ctrl), attempts, audit, testLoginGuard)
 
-			user, err := svc.Login(context.Background(), tt.email, tt.password)
+			user, err := svc.Login(context.Background(), tt.email, tt.password, client)
 			if tt.wantErr != nil {
 				assert.ErrorIs(t, err, tt.wantErr)
 			} else {
@@ -156,6 +215,19 @@ func TestUserService_Login(t *testing.T) {
 	}
 }
 
+func TestUserService_Unlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := repomocks.NewMockUserRepository(ctrl)
+	attempts := repomocks.NewMockLoginAttemptCache(ctrl)
+	repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(domain.User{Id: 1, Email: "Test@Example.com"}, nil)
+	attempts.EXPECT().Reset(gomock.Any(), domain.LoginScopeEmail, "test@example.com").Return(nil)
+	svc := NewUserService(repo, repomocks.NewMockPublishedPostRepository(ctrl), attempts, repomocks.NewMockLoginAuditRepository(ctrl), testLoginGuard)
+
+	assert.NoError(t, svc.Unlock(context.Background(), 1))
+}
+
 func TestUserService_Profile(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -204,7 +276,7 @@ func TestUserService_Profile(t *testing.T) {
 			defer ctrl.Finish()
 
 			repo := tt.mock(ctrl)
-			svc := NewUserService(repo)
+			svc := newTestUserService(ctrl, repo)
 
 			user, err := svc.Profile(context.Background(), tt.userId)
 			if tt.wantErr != nil {
@@ -289,7 +361,7 @@ func TestUserService_UpdatePassword(t *testing.T) {
 			defer ctrl.Finish()
 
 			repo := tt.mock(ctrl)
-			svc := NewUserService(repo)
+			svc := newTestUserService(ctrl, repo)
 
 			err := svc.UpdatePassword(context.Background(), tt.userId, tt.oldPwd, tt.newPwd)
 			if tt.wantErr != nil {
@@ -300,3 +372,130 @@ func TestUserService_UpdatePassword(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_UpdateProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		user    domain.User
+		mock    func(ctrl *gomock.Controller) *repomocks.MockUserRepository
+		wantErr error
+	}{
+		{
+			name: "update profile success - fields trimmed",
+			user: domain.User{Id: 1, Nickname: "  小明 ", Avatar: "https://cdn.example.com/a.png", Bio: "hello", Birthday: "1995-06-01"},
+			mock: func(ctrl *gomock.Controller) *repomocks.MockUserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().
+					UpdateProfile(gomock.Any(), domain.User{Id: 1, Nickname: "小明", Avatar: "https://cdn.example.com/a.png", Bio: "hello", Birthday: "1995-06-01"}).
+					Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "clear profile",
+			user: domain.User{Id: 1},
+			mock: func(ctrl *gomock.Controller) *repomocks.MockUserRepository {
+				repo := repomocks.NewMockUserRepository(ctrl)
+				repo.EXPECT().UpdateProfile(gomock.Any(), domain.User{Id: 1}).Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "nickname too long",
+			user: domain.User{Id: 1, Nickname: strings.Repeat("名", maxNicknameLen+1)},
+			mock: func(ctrl *gomock.Controller) *repomocks.MockUserRepository {
+				return repomocks.NewMockUserRepository(ctrl)
+			},
+			wantErr: domain.ErrInvalidProfile,
+		},
+		{
+			name: "avatar not an http url",
+			user: domain.User{Id: 1, Avatar: "javascript:alert(1)"},
+			mock: func(ctrl *gomock.Controller) *repomocks.MockUserRepository {
+				return repomocks.NewMockUserRepository(ctrl)
+			},
+			wantErr: domain.ErrInvalidProfile,
+		},
+		{
+			name: "birthday in the future",
+			user: domain.User{Id: 1, Birthday: time.Now().AddDate(1, 0, 0).Format("2006-01-02")},
+			mock: func(ctrl *gomock.Controller) *repomocks.MockUserRepository {
+				return repomocks.NewMockUserRepository(ctrl)
+			},
+			wantErr: domain.ErrInvalidProfile,
+		},
+		{
+			name: "birthday malformed",
+			user: domain.User{Id: 1, Birthday: "1995/06/01"},
+			mock: func(ctrl *gomock.Controller) *repomocks.MockUserRepository {
+				return repomocks.NewMockUserRepository(ctrl)
+			},
+			wantErr: domain.ErrInvalidProfile,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := tt.mock(ctrl)
+			svc := newTestUserService(ctrl, repo)
+
+			err := svc.UpdateProfile(context.Background(), tt.user)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUserService_PublicProfile(t *testing.T) {
+	tests := []struct {
+		name        string
+		mock        func(repo *repomocks.MockUserRepository, pubRepo *repomocks.MockPublishedPostRepository)
+		wantProfile domain.UserPublicProfile
+		wantErr     error
+	}{
+		{
+			name: "public profile with post and like counts",
+			mock: func(repo *repomocks.MockUserRepository, pubRepo *repomocks.MockPublishedPostRepository) {
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(domain.User{
+					Id: 1, Email: "test@example.com", Nickname: "小明", Bio: "hello", Birthday: "1995-06-01", Ctime: 100,
+				}, nil)
+				pubRepo.EXPECT().CountByAuthor(gomock.Any(), int64(1)).Return(int64(3), nil)
+				pubRepo.EXPECT().SumLikesByAuthor(gomock.Any(), int64(1)).Return(int64(42), nil)
+			},
+			wantProfile: domain.UserPublicProfile{Id: 1, Nickname: "小明", Bio: "hello", Ctime: 100, PostCnt: 3, LikeCnt: 42},
+		},
+		{
+			name: "user not found",
+			mock: func(repo *repomocks.MockUserRepository, pubRepo *repomocks.MockPublishedPostRepository) {
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(domain.User{}, domain.ErrUserNotFound)
+			},
+			wantErr: domain.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			repo := repomocks.NewMockUserRepository(ctrl)
+			pubRepo := repomocks.NewMockPublishedPostRepository(ctrl)
+			tt.mock(repo, pubRepo)
+			svc := NewUserService(repo, pubRepo, repomocks.NewMockLoginAttemptCache(ctrl), repomocks.NewMockLoginAuditRepository(ctrl), testLoginGuard)
+
+			profile, err := svc.PublicProfile(context.Background(), 1)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantProfile, profile)
+			}
+		})
+	}
+}
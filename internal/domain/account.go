@@ -0,0 +1,16 @@
+package domain
+
+// AccountTokenPurpose 账号邮件中一次性令牌的用途，不同用途的令牌互不通用
+type AccountTokenPurpose string
+
+const (
+	AccountTokenVerifyEmail   AccountTokenPurpose = "verify_email"   // 注册邮箱验证
+	AccountTokenResetPassword AccountTokenPurpose = "reset_password" // 找回密码
+)
+
+// Mail 一封纯文本邮件
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
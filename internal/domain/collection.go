@@ -0,0 +1,27 @@
+package domain
+
+import "strings"
+
+// DefaultCollectionFolderId 默认收藏夹ID。未指定收藏夹的收藏都在这里，
+// 它不对应数据库记录，也不能公开
+const DefaultCollectionFolderId int64 = 0
+
+// DefaultCollectionFolderName 默认收藏夹的展示名称
+const DefaultCollectionFolderName = "默认收藏夹"
+
+// CollectionFolder 收藏夹，同一帖子在一个用户下只会出现在一个收藏夹中
+type CollectionFolder struct {
+	Id          int64  // 收藏夹ID，0 表示默认收藏夹
+	UserId      int64  // 所属用户ID
+	Name        string // 名称（同一用户下唯一）
+	Description string // 描述
+	Public      bool   // 是否公开，公开后其他用户可以查看其中的帖子
+	PostCnt     int64  // 收藏的帖子数，仅列表查询时填充
+	Ctime       int64  // 创建时间（毫秒时间戳）
+	Utime       int64  // 更新时间（毫秒时间戳）
+}
+
+// NormalizeCollectionFolderName 去除收藏夹名称首尾空白
+func NormalizeCollectionFolderName(name string) string {
+	return strings.TrimSpace(name)
+}
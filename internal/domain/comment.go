@@ -0,0 +1,22 @@
+package domain
+
+// Comment 帖子评论。两级结构：根评论 RootId 为 0，回复的 RootId 指向所属根评论，
+// ParentId 指向直接回复的那条评论
+type Comment struct {
+	Id            int64     // 评论ID
+	PostId        int64     // 所属帖子ID
+	UserId        int64     // 评论者ID
+	RootId        int64     // 根评论ID，0 表示自身为根评论
+	ParentId      int64     // 被回复的评论ID，0 表示直接评论帖子
+	ReplyToUserId int64     // 被回复的用户ID
+	Content       string    // 评论内容
+	ReplyCnt      int64     // 回复数，仅根评论列表查询时填充
+	Replies       []Comment // 回复预览，仅根评论列表查询时填充
+	Ctime         int64     // 创建时间（毫秒时间戳）
+	Utime         int64     // 更新时间（毫秒时间戳）
+}
+
+// IsRoot 是否为根评论
+func (c Comment) IsRoot() bool {
+	return c.RootId == 0
+}
@@ -11,4 +11,36 @@ var (
 	ErrPostNotFound          = errors.New("post not found")
 	ErrPostNotAuthor         = errors.New("post not author")
 	ErrPostAlreadyPublished  = errors.New("post already published")
+	ErrPostRevisionNotFound  = errors.New("post revision not found")
+	ErrPostNotScheduled      = errors.New("post not scheduled")
+	ErrPostNotDraft          = errors.New("post not draft")
+	ErrPostHidden            = errors.New("post hidden by moderator")
+	ErrPostNotHidden         = errors.New("post not hidden")
+	ErrInvalidScheduleTime   = errors.New("invalid schedule time")
+	ErrTagNotFound           = errors.New("tag not found")
+	ErrDuplicateTag          = errors.New("duplicate tag")
+	ErrInvalidTagName        = errors.New("invalid tag name")
+	ErrCommentNotFound       = errors.New("comment not found")
+	ErrInvalidComment        = errors.New("invalid comment")
+	ErrInvalidStatsRange     = errors.New("invalid stats date range")
+	ErrFolderNotFound        = errors.New("collection folder not found")
+	ErrDuplicateFolder       = errors.New("duplicate collection folder")
+	ErrInvalidFolder         = errors.New("invalid collection folder")
+	ErrPostNotCollected      = errors.New("post not collected")
+	ErrReadHistoryNotFound   = errors.New("read history not found")
+	ErrInvalidReadProgress   = errors.New("invalid read progress")
+	ErrFollowSelf            = errors.New("cannot follow yourself")
+	ErrNotificationNotFound  = errors.New("notification not found")
+	ErrInvalidProfile        = errors.New("invalid user profile")
+	ErrEmailNotVerified      = errors.New("email not verified")
+	ErrInvalidAccountToken   = errors.New("invalid or expired account token")
+	ErrLoginLocked           = errors.New("login temporarily locked")
+	ErrSessionNotFound       = errors.New("session not found")
+	ErrAccessTokenNotFound   = errors.New("personal access token not found")
+	ErrInvalidAccessToken    = errors.New("invalid personal access token")
+	ErrUserDisabled          = errors.New("user disabled")
+	ErrUserBanned            = errors.New("user banned")
+	ErrInvalidRole           = errors.New("invalid role")
+	ErrInvalidUserStatus     = errors.New("invalid user status")
+	ErrInvalidStreamTicket   = errors.New("invalid or expired stream ticket")
 )
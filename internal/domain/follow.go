@@ -0,0 +1,51 @@
+package domain
+
+import "cmp"
+
+// FollowRelation 关注关系
+type FollowRelation struct {
+	FollowerId int64 // 粉丝
+	FolloweeId int64 // 被关注的用户
+	Ctime      int64 // 关注时间（毫秒时间戳），取消后重新关注会刷新
+}
+
+// FollowStats 用户的粉丝数与关注数
+type FollowStats struct {
+	UserId      int64
+	FollowerCnt int64 // 粉丝数
+	FolloweeCnt int64 // 关注数
+	Followed    bool  // 当前登录用户是否已关注该用户
+}
+
+// FeedItem 收件箱中的一条动态
+type FeedItem struct {
+	PostId int64
+	Ts     int64 // 帖子发布时间（毫秒时间戳），用于排序和游标
+}
+
+// FeedCursor 关注流游标，即上一页最后一篇帖子的发布时间与帖子ID，零值表示第一页。
+// 同一毫秒内可能发布多篇帖子，只按发布时间翻页会漏掉它们
+type FeedCursor struct {
+	Ts     int64
+	PostId int64
+}
+
+// Compare 按发布时间、再按帖子ID比较两个位置
+func (c FeedCursor) Compare(o FeedCursor) int {
+	if c.Ts != o.Ts {
+		return cmp.Compare(c.Ts, o.Ts)
+	}
+	return cmp.Compare(c.PostId, o.PostId)
+}
+
+// Cursor 动态在关注流中的位置
+func (i FeedItem) Cursor() FeedCursor {
+	return FeedCursor{Ts: i.Ts, PostId: i.PostId}
+}
+
+// FeedPage 关注流的一页，NextCursor 为下一页的游标
+type FeedPage struct {
+	Posts      []Post
+	NextCursor FeedCursor
+	HasMore    bool
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// LoginScope 登录失败计数的维度，邮箱和 IP 分别计数、分别锁定
+type LoginScope string
+
+const (
+	LoginScopeEmail LoginScope = "email"
+	LoginScopeIP    LoginScope = "ip"
+)
+
+// LoginResult 登录尝试的结果
+type LoginResult string
+
+const (
+	LoginResultSuccess        LoginResult = "success"         // 登录成功
+	LoginResultBadCredentials LoginResult = "bad_credentials" // 邮箱不存在或密码错误
+	LoginResultLocked         LoginResult = "locked"          // 处于锁定期，未校验密码
+	LoginResultDisabled       LoginResult = "disabled"        // 密码正确，但账号已停用或封禁
+)
+
+// LoginClient 发起登录的客户端
+type LoginClient struct {
+	IP        string
+	UserAgent string
+}
+
+// LoginAttempt 一次登录尝试的审计记录
+type LoginAttempt struct {
+	Id        int64
+	UserId    int64       // 邮箱未注册时为 0
+	Email     string      // 登录时填写的邮箱（已转小写）
+	IP        string      // 客户端 IP
+	UserAgent string      // 客户端 User-Agent
+	Result    LoginResult // 尝试结果
+	Ctime     int64       // 尝试时间（毫秒时间戳）
+}
+
+// LoginLockedError 连续登录失败导致邮箱或 IP 被锁定，errors.Is(err, ErrLoginLocked) 为 true
+type LoginLockedError struct {
+	Scope      LoginScope    // 被锁定的维度
+	RetryAfter time.Duration // 剩余锁定时间
+}
+
+func (e *LoginLockedError) Error() string {
+	return fmt.Sprintf("login locked by %s, retry after %s", e.Scope, e.RetryAfter)
+}
+
+func (e *LoginLockedError) Is(target error) bool {
+	return target == ErrLoginLocked
+}
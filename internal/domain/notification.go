@@ -0,0 +1,60 @@
+package domain
+
+import "fmt"
+
+// NotificationKind 通知类型
+type NotificationKind string
+
+const (
+	NotificationLike    NotificationKind = "like"    // 帖子被点赞
+	NotificationCollect NotificationKind = "collect" // 帖子被收藏
+	NotificationComment NotificationKind = "comment" // 帖子被评论
+)
+
+// NotificationKindOf 事件对应的通知类型，ok 为 false 表示该事件不产生通知
+// （取消类事件、阅读、发布等）。新的事件类型在这里登记即可
+func NotificationKindOf(t PostStatsEventType) (kind NotificationKind, ok bool) {
+	switch t {
+	case PostStatsEventLike:
+		return NotificationLike, true
+	case PostStatsEventCollect:
+		return NotificationCollect, true
+	case PostStatsEventComment:
+		return NotificationComment, true
+	default:
+		return "", false
+	}
+}
+
+// Notification 站内通知。同一帖子上同一类型的未读通知会聚合成一条，
+// 已读后再有新的动作则开始新的一条
+type Notification struct {
+	Id            int64
+	RecipientId   int64            // 接收人（帖子作者）
+	Kind          NotificationKind // 通知类型
+	PostId        int64            // 相关帖子
+	LatestActorId int64            // 最近一次触发通知的用户
+	ActorCnt      int64            // 触发通知的不同用户数
+	Read          bool             // 是否已读
+	Ctime         int64            // 创建时间（毫秒时间戳）
+	Utime         int64            // 最近一次聚合的时间（毫秒时间戳）
+}
+
+// Summary 通知文案，例如“用户 3 等 13 人赞了你的帖子”
+func (n Notification) Summary() string {
+	var action string
+	switch n.Kind {
+	case NotificationLike:
+		action = "赞了你的帖子"
+	case NotificationCollect:
+		action = "收藏了你的帖子"
+	case NotificationComment:
+		action = "评论了你的帖子"
+	default:
+		action = "与你的帖子互动"
+	}
+	if n.ActorCnt > 1 {
+		return fmt.Sprintf("用户 %d 等 %d 人%s", n.LatestActorId, n.ActorCnt, action)
+	}
+	return fmt.Sprintf("用户 %d %s", n.LatestActorId, action)
+}
@@ -0,0 +1,53 @@
+package domain
+
+// PersonalAccessTokenPrefix 个人访问令牌的前缀，中间件据此区分个人访问令牌和 JWT
+const PersonalAccessTokenPrefix = "wbk_pat_"
+
+// TokenScope 个人访问令牌的权限范围，令牌只能访问声明了对应范围的接口
+type TokenScope string
+
+const (
+	ScopePostsWrite TokenScope = "posts:write" // 管理自己的帖子：草稿、发布、定时发布、删除、恢复修订
+	ScopeStatsRead  TokenScope = "stats:read"  // 查看自己帖子的统计数据
+)
+
+// TokenScopes 全部可用的权限范围
+var TokenScopes = []TokenScope{ScopePostsWrite, ScopeStatsRead}
+
+// ValidTokenScope 是否为已定义的权限范围
+func ValidTokenScope(scope TokenScope) bool {
+	for _, s := range TokenScopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// PersonalAccessToken 用户为脚本和第三方集成创建的长期令牌，只保存哈希，明文仅在创建时返回一次
+type PersonalAccessToken struct {
+	Id        int64
+	UserId    int64        // 所属用户ID
+	Name      string       // 用户填写的名称，便于辨认用途
+	Hash      string       // 令牌的 SHA-256（十六进制）
+	Hint      string       // 令牌末尾 4 个字符，便于在列表中辨认
+	Scopes    []TokenScope // 权限范围
+	ExpiresAt int64        // 过期时间（毫秒时间戳），0 表示永不过期
+	LastUsed  int64        // 最近一次使用时间（毫秒时间戳），0 表示从未使用
+	Ctime     int64        // 创建时间（毫秒时间戳）
+}
+
+// HasScope 令牌是否拥有该权限范围
+func (t PersonalAccessToken) HasScope(scope TokenScope) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Expired now（毫秒时间戳）时令牌是否已过期
+func (t PersonalAccessToken) Expired(now int64) bool {
+	return t.ExpiresAt > 0 && now >= t.ExpiresAt
+}
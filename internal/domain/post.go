@@ -2,13 +2,15 @@ package domain
 
 // Post 帖子领域模型
 type Post struct {
-	Id       int64  // 帖子ID
-	Title    string // 标题
-	Content  string // 正文内容
-	AuthorId int64  // 作者ID
-	Status   uint8  // 状态：0-未发布，1-已发布，2-仅自己可见
-	Ctime    int64  // 创建时间（毫秒时间戳）
-	Utime    int64  // 更新时间（毫秒时间戳）
+	Id          int64    // 帖子ID
+	Title       string   // 标题
+	Content     string   // 正文内容
+	AuthorId    int64    // 作者ID
+	Status      uint8    // 状态：0-未发布，1-已发布，2-仅自己可见，3-定时发布，4-被管理员隐藏
+	Ctime       int64    // 创建时间（毫秒时间戳）
+	Utime       int64    // 更新时间（毫秒时间戳）
+	ScheduledAt int64    // 定时发布时间（毫秒时间戳），0 表示未设置
+	Tags        []string // 标签名列表；保存时为 nil 表示不修改标签
 }
 
 // 帖子状态常量
@@ -16,4 +18,11 @@ const (
 	PostStatusUnpublished uint8 = iota // 未发布（草稿）
 	PostStatusPublished                // 已发布
 	PostStatusPrivate                  // 仅自己可见
+	PostStatusScheduled                // 定时发布（等待调度器发布）
+	PostStatusHidden                   // 被管理员隐藏，只有管理员能解除，作者不能再发布
 )
+
+// PostStatusOffline 该状态的帖子不在线上库中，需要从排行和搜索中移除
+func PostStatusOffline(status uint8) bool {
+	return status == PostStatusPrivate || status == PostStatusHidden
+}
@@ -0,0 +1,121 @@
+package domain
+
+import "time"
+
+// StatsDateLayout is the layout of PostDailyStats.Date, in server local time.
+const StatsDateLayout = "2006-01-02"
+
+// MaxStatsRangeDays caps the number of days a daily stats query may span.
+const MaxStatsRangeDays = 366
+
+// StatsRange is an inclusive range of days in StatsDateLayout.
+type StatsRange struct {
+	From string
+	To   string
+}
+
+// NewStatsRange validates a requested range. An empty to means today and an
+// empty from means 30 days ending at to.
+func NewStatsRange(from, to string, now time.Time) (StatsRange, error) {
+	end := now
+	if to != "" {
+		t, err := time.ParseInLocation(StatsDateLayout, to, now.Location())
+		if err != nil {
+			return StatsRange{}, ErrInvalidStatsRange
+		}
+		end = t
+	}
+	start := end.AddDate(0, 0, -29)
+	if from != "" {
+		t, err := time.ParseInLocation(StatsDateLayout, from, now.Location())
+		if err != nil {
+			return StatsRange{}, ErrInvalidStatsRange
+		}
+		start = t
+	}
+	r := StatsRange{From: start.Format(StatsDateLayout), To: end.Format(StatsDateLayout)}
+	if r.From > r.To || len(r.Days()) > MaxStatsRangeDays {
+		return StatsRange{}, ErrInvalidStatsRange
+	}
+	return r, nil
+}
+
+// Days lists every day of the range in order.
+func (r StatsRange) Days() []string {
+	start, err := time.Parse(StatsDateLayout, r.From)
+	if err != nil {
+		return nil
+	}
+	var days []string
+	for d := start; ; d = d.AddDate(0, 0, 1) {
+		day := d.Format(StatsDateLayout)
+		if day > r.To || len(days) > MaxStatsRangeDays {
+			return days
+		}
+		days = append(days, day)
+	}
+}
+
+// PostDailyStats holds the interactions a post received on one day. Unlike
+// PostStats these are event counts, so an unlike does not cancel a like.
+type PostDailyStats struct {
+	PostId       int64
+	Date         string
+	ReadCnt      int64
+	LikeCnt      int64
+	UnlikeCnt    int64
+	CollectCnt   int64
+	UncollectCnt int64
+	// UniqueReadCnt is a snapshot of the day's distinct readers rather than an
+	// event count, it is never part of a delta.
+	UniqueReadCnt int64
+}
+
+// NewPostDailyDelta maps a stats event to the daily counter it increments.
+// It returns false for events that are not tracked per day.
+func NewPostDailyDelta(event PostStatsEvent, at time.Time) (PostDailyStats, bool) {
+	d := PostDailyStats{PostId: event.PostId, Date: at.Format(StatsDateLayout)}
+	switch event.Type {
+	case PostStatsEventRead:
+		d.ReadCnt = 1
+	case PostStatsEventLike:
+		d.LikeCnt = 1
+	case PostStatsEventUnlike:
+		d.UnlikeCnt = 1
+	case PostStatsEventCollect:
+		d.CollectCnt = 1
+	case PostStatsEventUncollect:
+		d.UncollectCnt = 1
+	default:
+		return PostDailyStats{}, false
+	}
+	return d, true
+}
+
+// Add sums the counters, keeping the receiver's PostId and Date. UniqueReadCnt
+// is left as is, distinct readers do not add up across days or posts.
+func (s PostDailyStats) Add(o PostDailyStats) PostDailyStats {
+	s.ReadCnt += o.ReadCnt
+	s.LikeCnt += o.LikeCnt
+	s.UnlikeCnt += o.UnlikeCnt
+	s.CollectCnt += o.CollectCnt
+	s.UncollectCnt += o.UncollectCnt
+	return s
+}
+
+// AuthorPostStats is one post's interactions summed over a dashboard range.
+type AuthorPostStats struct {
+	PostId int64
+	Title  string
+	Stats  PostDailyStats
+}
+
+// AuthorDashboard aggregates the daily stats of all posts of an author.
+// UniqueReadCnt is always 0 here, it only exists per post and day.
+type AuthorDashboard struct {
+	From  string
+	To    string
+	Total PostDailyStats    // summed over the range
+	Days  []PostDailyStats  // one entry per day from From to To, PostId is 0
+	Posts []AuthorPostStats // posts with any interaction in the range, most read first
+}
@@ -0,0 +1,71 @@
+package domain
+
+// HotWindow is the time window of the hot post ranking.
+type HotWindow string
+
+const (
+	HotWindowDaily  HotWindow = "daily"
+	HotWindowWeekly HotWindow = "weekly"
+	HotWindowAll    HotWindow = "all"
+)
+
+// HotWindows lists every window the ranking job materialises.
+var HotWindows = []HotWindow{HotWindowDaily, HotWindowWeekly, HotWindowAll}
+
+func (w HotWindow) Valid() bool {
+	switch w {
+	case HotWindowDaily, HotWindowWeekly, HotWindowAll:
+		return true
+	}
+	return false
+}
+
+// Weights of each interaction in the hot score.
+const (
+	hotWeightRead    = 1
+	hotWeightLike    = 5
+	hotWeightComment = 6
+	hotWeightCollect = 8
+)
+
+// HotScore is the undecayed score of a post's accumulated counters.
+func HotScore(st PostStats) float64 {
+	return float64(st.ReadCnt*hotWeightRead +
+		st.LikeCnt*hotWeightLike +
+		st.CommentCnt*hotWeightComment +
+		st.CollectCnt*hotWeightCollect)
+}
+
+// HotScoreDelta is how much a single stats event moves the undecayed score,
+// so that summing the deltas of all events yields HotScore.
+func HotScoreDelta(t PostStatsEventType) float64 {
+	switch t {
+	case PostStatsEventRead:
+		return hotWeightRead
+	case PostStatsEventLike:
+		return hotWeightLike
+	case PostStatsEventUnlike:
+		return -hotWeightLike
+	case PostStatsEventComment:
+		return hotWeightComment
+	case PostStatsEventUncomment:
+		return -hotWeightComment
+	case PostStatsEventCollect:
+		return hotWeightCollect
+	case PostStatsEventUncollect:
+		return -hotWeightCollect
+	}
+	return 0
+}
+
+// PostScore is a post id with its score in a ranking.
+type PostScore struct {
+	PostId int64
+	Score  float64
+}
+
+// HotPost is a published post in the hot ranking.
+type HotPost struct {
+	Post  Post
+	Score float64
+}
@@ -0,0 +1,28 @@
+package domain
+
+// PostRevision 帖子修订版本（每次保存/发布生成一条，不可修改）
+type PostRevision struct {
+	Id       int64  // 修订记录ID
+	PostId   int64  // 所属帖子ID
+	Version  int64  // 帖子内递增的版本号，从 1 开始
+	Title    string // 该版本的标题
+	Content  string // 该版本的正文
+	AuthorId int64  // 作者ID
+	Status   uint8  // 生成该版本时帖子的状态
+	Ctime    int64  // 创建时间（毫秒时间戳）
+}
+
+// DiffOp 行级差异操作类型
+type DiffOp string
+
+const (
+	DiffOpEqual  DiffOp = "equal"  // 两个版本中都存在
+	DiffOpInsert DiffOp = "insert" // 仅新版本中存在
+	DiffOpDelete DiffOp = "delete" // 仅旧版本中存在
+)
+
+// DiffLine 行级差异中的一行
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
@@ -0,0 +1,11 @@
+package domain
+
+// PostSearchHit 一条全文检索结果
+type PostSearchHit struct {
+	PostId   int64   // 帖子ID
+	AuthorId int64   // 作者ID
+	Title    string  // 标题（命中词已用 <em> 高亮，已做 HTML 转义）
+	Snippet  string  // 正文摘要（命中词已用 <em> 高亮，已做 HTML 转义）
+	Utime    int64   // 发布时间（毫秒时间戳）
+	Score    float64 // 相关度得分
+}
@@ -6,6 +6,19 @@ type PostStats struct {
 	LikeCnt    int64
 	CollectCnt int64
 	ReadCnt    int64
+	CommentCnt int64
+	// UniqueReadCnt is the HyperLogLog estimate of distinct readers, whereas
+	// ReadCnt counts page views deduped for 30 seconds only.
+	UniqueReadCnt int64
+}
+
+// PostReaderSnapshot is a point-in-time copy of a post's unique reader counts.
+type PostReaderSnapshot struct {
+	PostId   int64
+	Sketch   []byte // raw lifetime HyperLogLog, can be loaded back into Redis
+	Count    int64  // lifetime unique readers
+	Date     string // day of DayCount, in StatsDateLayout
+	DayCount int64  // unique readers on Date
 }
 
 // PostUserStats holds user-specific flags for a post.
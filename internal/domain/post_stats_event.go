@@ -11,6 +11,11 @@ const (
 	PostStatsEventCollect   PostStatsEventType = "collect"
 	PostStatsEventUncollect PostStatsEventType = "uncollect"
 	PostStatsEventRead      PostStatsEventType = "read"
+	PostStatsEventComment   PostStatsEventType = "comment"
+	PostStatsEventUncomment PostStatsEventType = "uncomment"
+	// PostStatsEventPublish is emitted when a post goes online. It changes no
+	// counter and only drives the feed fan-out; UserId is the author.
+	PostStatsEventPublish PostStatsEventType = "publish"
 )
 
 // PostStatsEvent is published to MQ for async counter updates.
@@ -31,3 +36,12 @@ func NewPostStatsEvent(eventId string, eventType PostStatsEventType, postId, use
 		Ts:      time.Now().Unix(),
 	}
 }
+
+// PostStatsDeadLetter is a stats message the consumer gave up on and moved to the DLQ.
+type PostStatsDeadLetter struct {
+	Event    PostStatsEvent // zero value if Body is not a valid event
+	Body     string
+	Error    string
+	Attempts int
+	FailedAt int64
+}
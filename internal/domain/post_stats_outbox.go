@@ -0,0 +1,9 @@
+package domain
+
+// PostStatsOutboxMessage is a stats event stored in the outbox table,
+// written in the same transaction as the state change that produced it.
+type PostStatsOutboxMessage struct {
+	Id      int64
+	Event   PostStatsEvent
+	Retries int
+}
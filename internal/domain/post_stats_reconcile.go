@@ -0,0 +1,29 @@
+package domain
+
+// PostStatsMismatch describes a post whose like/collect counters disagree with
+// the like/collect relation tables.
+type PostStatsMismatch struct {
+	PostId int64
+	// Expected values recounted from the relation tables.
+	LikeCnt    int64
+	CollectCnt int64
+	// Values currently stored in post_stats.
+	DBLikeCnt    int64
+	DBCollectCnt int64
+	// Values currently in the Redis stats hash, only meaningful when InCache is true.
+	InCache         bool
+	CacheLikeCnt    int64
+	CacheCollectCnt int64
+	Repaired        bool
+}
+
+// PostStatsReconcileReport summarizes a reconciliation run over [FromId, ToId).
+type PostStatsReconcileReport struct {
+	FromId      int64
+	ToId        int64
+	Scanned     int
+	MismatchCnt int
+	RepairedCnt int
+	// Mismatches holds the first mismatches found, capped to keep reports small.
+	Mismatches []PostStatsMismatch
+}
@@ -0,0 +1,30 @@
+package domain
+
+const (
+	// ReadProgressUnknown 客户端未上报阅读进度，记录阅读时保留原有进度
+	ReadProgressUnknown = -1
+	// ReadProgressFinished 读完时的进度
+	ReadProgressFinished = 100
+)
+
+// ReadHistory 登录用户的阅读记录，每个用户每篇帖子一条，重复阅读只更新时间与进度
+type ReadHistory struct {
+	Id       int64 // 记录ID，阅读时间相同时用于游标分页
+	UserId   int64 // 用户ID
+	PostId   int64 // 帖子ID
+	Progress int   // 阅读进度百分比（0-100）
+	ReadAt   int64 // 最近一次阅读时间（毫秒时间戳）
+	Post     Post  // 帖子内容，仅列表查询时填充
+}
+
+// ReadHistoryCursor 阅读历史游标，即上一页最后一条的阅读时间与记录ID，零值表示第一页。
+// 同一毫秒内可能有多条记录，只按阅读时间翻页会漏掉它们
+type ReadHistoryCursor struct {
+	ReadAt int64
+	Id     int64
+}
+
+// Finished 是否已读完
+func (h ReadHistory) Finished() bool {
+	return h.Progress >= ReadProgressFinished
+}
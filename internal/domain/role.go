@@ -0,0 +1,47 @@
+package domain
+
+// Role 用户角色，决定用户拥有哪些管理权限
+type Role string
+
+const (
+	RoleUser      Role = "user"      // 普通用户（默认），只能操作自己的资源
+	RoleModerator Role = "moderator" // 版主：查看任意用户资料和草稿，隐藏违规帖子，管理标签
+	RoleAdmin     Role = "admin"     // 管理员：拥有全部权限
+)
+
+// Permission 操作他人资源或管理后台所需的权限
+type Permission string
+
+const (
+	PermUserRead     Permission = "user:read"     // 查看任意用户的资料、用户列表和登录记录
+	PermUserManage   Permission = "user:manage"   // 停用、封禁、解锁用户，分配角色
+	PermPostRead     Permission = "post:read"     // 查看任意帖子的草稿
+	PermPostModerate Permission = "post:moderate" // 强制隐藏任意帖子
+	PermTagManage    Permission = "tag:manage"    // 创建、重命名和删除全站标签
+)
+
+// rolePermissions 各角色拥有的权限，普通用户没有任何管理权限
+var rolePermissions = map[Role][]Permission{
+	RoleModerator: {PermUserRead, PermPostRead, PermPostModerate, PermTagManage},
+	RoleAdmin:     {PermUserRead, PermUserManage, PermPostRead, PermPostModerate, PermTagManage},
+}
+
+// Can 角色是否拥有该权限，未知角色没有任何权限
+func (r Role) Can(perm Permission) bool {
+	for _, p := range rolePermissions[r] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// Permissions 角色拥有的全部权限
+func (r Role) Permissions() []Permission {
+	return rolePermissions[r]
+}
+
+// ValidRole 是否为已定义的角色
+func ValidRole(r Role) bool {
+	return r == RoleUser || r == RoleModerator || r == RoleAdmin
+}
@@ -0,0 +1,11 @@
+package domain
+
+// Session 一次登录产生的会话。刷新 token 时 ssid 会轮换，会话ID始终是登录时首个 ssid（即会话族ID）
+type Session struct {
+	Id        string // 会话ID
+	UserId    int64  // 用户ID
+	UserAgent string // 最近一次使用时的 User-Agent
+	IP        string // 最近一次使用时的 IP
+	Ctime     int64  // 登录时间（毫秒时间戳）
+	LastUsed  int64  // 最近一次签发 token 的时间（毫秒时间戳）
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "time"
+
+// StreamTicketTTL 连接票据的有效期，只需覆盖从换票到建立连接的时间
+const StreamTicketTTL = 30 * time.Second
+
+// StreamEventType 实时事件类型
+type StreamEventType string
+
+const (
+	StreamEventStats        StreamEventType = "stats"        // 帖子计数变化
+	StreamEventNotification StreamEventType = "notification" // 新通知或通知有新的聚合
+)
+
+// StreamEvent 通过 SSE 推送给在线客户端的实时事件，在各实例间广播
+type StreamEvent struct {
+	Id           int64           // 全局递增，客户端断线重连时以 Last-Event-ID 回传；0 表示不参与补发（如计数快照）
+	Type         StreamEventType // 事件类型
+	UserId       int64           // 接收人，只推给该用户的连接；0 表示按 PostId 推给订阅了该帖子的连接
+	PostId       int64           // 相关帖子
+	Stats        PostStats       // Type 为 stats 时的最新计数
+	Notification Notification    // Type 为 notification 时的通知
+}
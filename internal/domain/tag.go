@@ -0,0 +1,17 @@
+package domain
+
+import "strings"
+
+// Tag 帖子标签
+type Tag struct {
+	Id      int64  // 标签ID
+	Name    string // 标签名（小写，全局唯一）
+	PostCnt int64  // 使用该标签的已发布帖子数，仅列表查询时填充
+	Ctime   int64  // 创建时间（毫秒时间戳）
+	Utime   int64  // 更新时间（毫秒时间戳）
+}
+
+// NormalizeTagName 统一标签名格式：去除首尾空白并转为小写
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
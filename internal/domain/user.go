@@ -4,4 +4,55 @@ type User struct {
 	Id       int64
 	Email    string
 	Password string
+	// EmailVerified 邮箱是否已验证，未验证的用户不能发布帖子
+	EmailVerified bool
+	Nickname      string     // 昵称
+	Avatar        string     // 头像 URL
+	Bio           string     // 个人简介
+	Birthday      string     // 生日，格式 2006-01-02，空表示未填写
+	Role          Role       // 角色，默认普通用户
+	Status        UserStatus // 账号状态，停用或封禁后不能登录
+	Ctime         int64      // 注册时间（毫秒时间戳）
+}
+
+// UserStatus 账号状态
+type UserStatus uint8
+
+const (
+	UserStatusActive   UserStatus = iota // 正常
+	UserStatusDisabled                   // 停用：暂时禁止登录，如账号存在安全风险或用户申请
+	UserStatusBanned                     // 封禁：因违规禁止登录
+)
+
+// Active 账号是否可以正常使用
+func (s UserStatus) Active() bool {
+	return s == UserStatusActive
+}
+
+// Err 账号不可用时登录返回的错误，正常时返回 nil
+func (s UserStatus) Err() error {
+	switch s {
+	case UserStatusActive:
+		return nil
+	case UserStatusBanned:
+		return ErrUserBanned
+	default:
+		return ErrUserDisabled
+	}
+}
+
+// ValidUserStatus 是否为已定义的账号状态
+func ValidUserStatus(s UserStatus) bool {
+	return s <= UserStatusBanned
+}
+
+// UserPublicProfile 用户公开主页，不含邮箱、生日等隐私信息
+type UserPublicProfile struct {
+	Id       int64
+	Nickname string
+	Avatar   string
+	Bio      string
+	Ctime    int64 // 注册时间（毫秒时间戳）
+	PostCnt  int64 // 已发布帖子数
+	LikeCnt  int64 // 已发布帖子获得的点赞总数
 }
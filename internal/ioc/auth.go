@@ -6,8 +6,25 @@ import (
 	ports "webook/internal/ports/output"
 )
 
+// NewJWTService 按 JWT_ALG 创建签名密钥环：HS256 使用共享密钥，RS256 / EdDSA 从 PEM 文件加载
 func NewJWTService(cfg *config.Config) *auth.JWTService {
-	return auth.NewJWTService(cfg.JWT.SecretKey, "")
+	var ring *auth.KeyRing
+	switch cfg.JWT.Algorithm {
+	case "HS256":
+		ring = auth.NewHMACKeyRing("hs256", []byte(cfg.JWT.SecretKey))
+	case "RS256", "EdDSA":
+		var err error
+		ring, err = auth.LoadKeyRing(cfg.JWT.PrivateKeyFile, cfg.JWT.PublicKeyFiles...)
+		if err != nil {
+			panic(err)
+		}
+		if alg := ring.Algorithm(); alg != cfg.JWT.Algorithm {
+			panic("JWT_ALG is " + cfg.JWT.Algorithm + " but the private key is for " + alg)
+		}
+	default:
+		panic("unknown JWT algorithm: " + cfg.JWT.Algorithm)
+	}
+	return auth.NewJWTService(ring, cfg.JWT.RefreshSecretKey)
 }
 
 func NewTokenService(svc *auth.JWTService) ports.TokenService {
@@ -17,3 +34,7 @@ func NewTokenService(svc *auth.JWTService) ports.TokenService {
 func NewAccessTokenVerifier(svc *auth.JWTService) ports.AccessTokenVerifier {
 	return svc
 }
+
+func NewPublicKeyProvider(svc *auth.JWTService) ports.PublicKeyProvider {
+	return svc
+}
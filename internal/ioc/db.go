@@ -14,17 +14,43 @@ func NewDB(cfg *config.Config) *gorm.DB {
 	if err != nil {
 		panic(err)
 	}
+	// 邮箱验证上线前注册的账号视为已验证，只在这次迁移新增 email_verified 列时补一次
+	backfillVerified := db.Migrator().HasTable(&dao.User{}) && !db.Migrator().HasColumn(&dao.User{}, "EmailVerified")
 	// 自动迁移数据库表结构
 	err = db.AutoMigrate(
 		&dao.User{},
 		&dao.Post{},
 		&dao.PublishedPost{},
+		&dao.PostRevision{},
+		&dao.Tag{},
+		&dao.PostTag{},
+		&dao.PublishedPostTag{},
 		&dao.PostStats{},
 		&dao.PostLikeRelation{},
 		&dao.PostCollectRelation{},
+		&dao.PostStatsOutbox{},
+		&dao.Comment{},
+		&dao.PostDailyStats{},
+		&dao.PostReaderSketch{},
+		&dao.CollectionFolder{},
+		&dao.ReadHistory{},
+		&dao.FollowRelation{},
+		&dao.FollowStats{},
+		&dao.Notification{},
+		&dao.NotificationActor{},
+		&dao.NotificationEvent{},
+		&dao.LoginAudit{},
+		&dao.UserSession{},
+		&dao.PersonalAccessToken{},
 	)
 	if err != nil {
 		panic(err)
 	}
+	if backfillVerified {
+		err = db.Model(&dao.User{}).Where("email_verified = ?", false).Update("email_verified", true).Error
+		if err != nil {
+			panic(err)
+		}
+	}
 	return db
 }
@@ -0,0 +1,20 @@
+package ioc
+
+import (
+	"webook/config"
+	"webook/internal/adapters/outbound/mail"
+	output "webook/internal/ports/output"
+	"webook/pkg/logger"
+)
+
+// NewMailer 按 MAIL_DRIVER 创建邮件发送器
+func NewMailer(cfg *config.Config, l logger.Logger) output.Mailer {
+	switch cfg.Mail.Driver {
+	case "smtp":
+		return mail.NewSMTPMailer(cfg.Mail.SMTPAddr, cfg.Mail.SMTPUsername, cfg.Mail.SMTPPassword, cfg.Mail.From)
+	case "log":
+		return mail.NewLogMailer(l, cfg.Mail.FilePath)
+	default:
+		panic("unknown mail driver: " + cfg.Mail.Driver)
+	}
+}
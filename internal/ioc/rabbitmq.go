@@ -3,6 +3,7 @@ package ioc
 import (
 	"webook/config"
 	"webook/internal/adapters/outbound/mq"
+	input "webook/internal/ports/input"
 	output "webook/internal/ports/output"
 	"webook/pkg/logger"
 
@@ -35,17 +36,34 @@ func NewRabbitMQConsumerChannel(conn *amqp.Connection) mq.ConsumerChannel {
 }
 
 func NewPostStatsPublisher(ch mq.ProducerChannel, cfg *config.Config) output.PostStatsEventPublisher {
-	pub, err := mq.NewRabbitMQStatsPublisher(ch, cfg.MQ.Exchange, cfg.MQ.Queue, cfg.MQ.RoutingKey)
+	pub, err := mq.NewRabbitMQStatsPublisher(ch, statsTopology(cfg))
 	if err != nil {
 		panic(err)
 	}
 	return pub
 }
 
-func NewPostStatsConsumer(ch mq.ConsumerChannel, cfg *config.Config, cache output.PostStatsCache, l logger.Logger) *mq.RabbitMQStatsConsumer {
-	consumer, err := mq.NewRabbitMQStatsConsumer(ch, cfg.MQ.Exchange, cfg.MQ.Queue, cfg.MQ.RoutingKey, cfg.MQ.Prefetch, cache, l)
+func NewPostStatsConsumer(ch mq.ConsumerChannel, cfg *config.Config, cache output.PostStatsCache, rank output.PostRankCache, feed input.FeedService, notifier input.NotificationService, stream input.StreamService, l logger.Logger) *mq.RabbitMQStatsConsumer {
+	consumer, err := mq.NewRabbitMQStatsConsumer(ch, statsTopology(cfg), cfg.MQ.Prefetch, cfg.MQ.MaxAttempts, cache, rank, feed, notifier, stream, l)
 	if err != nil {
 		panic(err)
 	}
 	return consumer
 }
+
+func NewPostStatsDLQ(ch mq.ProducerChannel, cfg *config.Config) output.PostStatsDeadLetterQueue {
+	dlq, err := mq.NewRabbitMQStatsDLQ(ch, statsTopology(cfg))
+	if err != nil {
+		panic(err)
+	}
+	return dlq
+}
+
+func statsTopology(cfg *config.Config) mq.StatsTopology {
+	return mq.StatsTopology{
+		Exchange:   cfg.MQ.Exchange,
+		Queue:      cfg.MQ.Queue,
+		RoutingKey: cfg.MQ.RoutingKey,
+		RetryDelay: cfg.MQ.RetryDelay,
+	}
+}
@@ -2,6 +2,9 @@ package ioc
 
 import (
 	"webook/config"
+	cache "webook/internal/adapters/outbound/persistence/redis"
+	output "webook/internal/ports/output"
+	"webook/pkg/logger"
 
 	"github.com/redis/go-redis/v9"
 )
@@ -13,3 +16,12 @@ func NewRedis(cfg *config.Config) redis.Cmdable {
 		Password: cfg.Redis.Password,
 	})
 }
+
+// NewStreamBroker 创建实时事件广播，需要支持 Pub/Sub 的客户端
+func NewStreamBroker(client redis.Cmdable, l logger.Logger) output.StreamBroker {
+	uc, ok := client.(redis.UniversalClient)
+	if !ok {
+		panic("stream broker requires a redis.UniversalClient")
+	}
+	return cache.NewStreamBroker(uc, l)
+}
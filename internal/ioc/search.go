@@ -0,0 +1,12 @@
+package ioc
+
+import (
+	"webook/internal/adapters/outbound/search"
+	ports "webook/internal/ports/output"
+)
+
+// NewSearchService 创建内嵌的全文索引
+// 由 main 创建一次并同时注入 Web 服务与后台 Worker，保证两者共用同一份索引
+func NewSearchService() ports.SearchService {
+	return search.NewInvertedIndex()
+}
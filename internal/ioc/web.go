@@ -2,6 +2,8 @@ package ioc
 
 import (
 	"webook/config"
+	"webook/internal/domain"
+	service "webook/internal/ports/input"
 	ports "webook/internal/ports/output"
 	web "webook/internal/adapters/inbound/http"
 	"webook/internal/adapters/inbound/http/middleware"
@@ -11,8 +13,12 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func NewGinEngine(cfg *config.Config, userHandler *web.UserHandler, postHandler *web.PostHandler, verifier ports.AccessTokenVerifier, l logger.Logger) *gin.Engine {
+func NewGinEngine(cfg *config.Config, userHandler *web.UserHandler, postHandler *web.PostHandler, revisionHandler *web.PostRevisionHandler, tagHandler *web.TagHandler, searchHandler *web.PostSearchHandler, commentHandler *web.CommentHandler, rankHandler *web.PostRankHandler, analyticsHandler *web.PostAnalyticsHandler, collectionHandler *web.CollectionHandler, historyHandler *web.ReadHistoryHandler, followHandler *web.FollowHandler, feedHandler *web.FeedHandler, notificationHandler *web.NotificationHandler, streamHandler *web.StreamHandler, adminHandler *web.AdminHandler, jwksHandler *web.JWKSHandler, tokenHandler *web.PersonalAccessTokenHandler, verifier ports.AccessTokenVerifier, auth service.AuthService, tokens service.PersonalAccessTokenService, streams service.StreamService, l logger.Logger) *gin.Engine {
 	server := gin.Default()
+	// ClientIP 用于登录限流和阅读去重，只信任配置的代理转发的 X-Forwarded-For，否则客户端可以伪造
+	if err := server.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		panic(err)
+	}
 
 	server.Use(middleware.NewRequestLoggerBuilder(l).
 		IgnorePath("/health").
@@ -29,12 +35,42 @@ func NewGinEngine(cfg *config.Config, userHandler *web.UserHandler, postHandler
 		MaxAge: cfg.CORS.MaxAge,
 	}))
 
-	server.Use(middleware.NewJWTMiddlewareBuilder(verifier).
-		IgnorePaths("/users", "/users/login", "/auth/refresh", "/auth/logout").
+	jwtBuilder := middleware.NewJWTMiddlewareBuilder(verifier).CheckWrites(auth)
+	if cfg.JWT.CheckSession {
+		jwtBuilder.CheckSession(auth)
+	}
+	server.Use(jwtBuilder.
+		IgnorePaths("/users", "/users/login", "/auth/refresh", "/auth/logout",
+			"/users/verify-email", "/users/password/forgot", "/users/password/reset",
+			"/.well-known/jwks.json").
+		StreamTickets(streams, "/stream").
+		PersonalAccessTokens(tokens).
+		RouteScope(domain.ScopePostsWrite,
+			"POST /posts", "POST /posts/publish", "GET /posts/author", "GET /posts/draft/:id", "DELETE /posts/:id",
+			"POST /posts/:id/schedule", "PUT /posts/:id/schedule", "DELETE /posts/:id/schedule",
+			"GET /posts/:id/revisions", "GET /posts/:id/revisions/diff", "GET /posts/:id/revisions/:version",
+			"POST /posts/:id/revisions/:version/restore").
+		RouteScope(domain.ScopeStatsRead,
+			"GET /posts/:id/stats/daily", "GET /posts/author/dashboard").
 		Build())
 
 	userHandler.RegisterRoutes(server)
 	postHandler.RegisterRoutes(server)
+	revisionHandler.RegisterRoutes(server)
+	tagHandler.RegisterRoutes(server)
+	searchHandler.RegisterRoutes(server)
+	commentHandler.RegisterRoutes(server)
+	rankHandler.RegisterRoutes(server)
+	analyticsHandler.RegisterRoutes(server)
+	collectionHandler.RegisterRoutes(server)
+	historyHandler.RegisterRoutes(server)
+	followHandler.RegisterRoutes(server)
+	feedHandler.RegisterRoutes(server)
+	notificationHandler.RegisterRoutes(server)
+	streamHandler.RegisterRoutes(server)
+	adminHandler.RegisterRoutes(server)
+	jwksHandler.RegisterRoutes(server)
+	tokenHandler.RegisterRoutes(server)
 
 	return server
 }
@@ -0,0 +1,15 @@
+package input
+
+import "context"
+
+// AccountService 邮箱验证与找回密码业务接口，令牌通过邮件发送，只能使用一次
+type AccountService interface {
+	// SendVerification 向该邮箱发送验证链接；邮箱已验证、未注册或发送过于频繁时不发送
+	SendVerification(ctx context.Context, email string) error
+	// VerifyEmail 使用验证链接中的令牌完成邮箱验证
+	VerifyEmail(ctx context.Context, token string) error
+	// ForgotPassword 向该邮箱发送重置密码链接；邮箱未注册时同样返回成功，避免暴露注册情况
+	ForgotPassword(ctx context.Context, email string) error
+	// ResetPassword 使用令牌重置密码，并使该用户已有的 refresh token 全部失效
+	ResetPassword(ctx context.Context, token, newPassword string) error
+}
@@ -0,0 +1,21 @@
+package input
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// AdminService 管理后台业务接口，调用方负责权限检查
+type AdminService interface {
+	// ListUsers 按注册时间倒序分页获取用户，返回当前页和总数
+	ListUsers(ctx context.Context, page, pageSize int) ([]domain.User, int64, error)
+	// SetUserStatus 停用、封禁或恢复用户；停用和封禁后不能登录，
+	// 同时注销该用户的全部会话并删除其个人访问令牌，恢复后需要重新登录
+	SetUserStatus(ctx context.Context, uid int64, status domain.UserStatus) error
+	// SetUserRole 修改用户角色
+	SetUserRole(ctx context.Context, uid int64, role domain.Role) error
+	// HidePost 隐藏任意帖子并从线上库下架，解除之前作者不能重新发布或定时发布
+	HidePost(ctx context.Context, postId int64) error
+	// UnhidePost 解除隐藏，帖子回到仅作者可见
+	UnhidePost(ctx context.Context, postId int64) error
+}
@@ -1,10 +1,26 @@
 package input
 
-import "context"
+import (
+	"context"
+	"webook/internal/domain"
+)
 
 // AuthService 认证业务接口
 type AuthService interface {
-	GenerateTokenPair(ctx context.Context, uid int64, userAgent string) (string, string, error)
-	RefreshAccessToken(ctx context.Context, refreshToken, userAgent string) (string, error)
+	// GenerateTokenPair 登录成功后签发 token 对，同时创建一条会话记录
+	GenerateTokenPair(ctx context.Context, uid int64, client domain.LoginClient) (string, string, error)
+	// RefreshAccessToken 轮换 refresh token：返回新的 access token 和同一会话族的新 refresh token，
+	// 旧 refresh token 随即失效；已轮换过的 refresh token 再次使用时视为被盗用，吊销整个会话族
+	RefreshAccessToken(ctx context.Context, refreshToken string, client domain.LoginClient) (accessToken, newRefreshToken string, err error)
 	Logout(ctx context.Context, refreshToken string) error
+	// Sessions 用户仍然有效的会话，最近使用的在前
+	Sessions(ctx context.Context, uid int64) ([]domain.Session, error)
+	// RevokeSession 注销用户的一个会话，该会话的 refresh token 立即失效。currentSessionId 为发起请求的
+	// access token 所属会话，注销它不依赖会话记录；会话不属于该用户时返回 domain.ErrSessionNotFound
+	RevokeSession(ctx context.Context, uid int64, currentSessionId, sessionId string) error
+	// LogoutAll 注销用户的全部会话
+	LogoutAll(ctx context.Context, uid int64) error
+	// SessionActive issuedAt（unix 秒）签发的、属于该会话的 access token 是否仍然有效，
+	// 供中间件在 access token 过期前拦截已注销的会话
+	SessionActive(ctx context.Context, uid int64, sessionId string, issuedAt int64) (bool, error)
 }
@@ -0,0 +1,17 @@
+package input
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// AuthorizationService 基于角色的权限判断，供中间件和需要判断资源归属的处理器使用
+type AuthorizationService interface {
+	// Role 用户当前生效的角色
+	Role(ctx context.Context, uid int64) (domain.Role, error)
+	// Authorize 用户的角色拥有该权限时返回 nil；否则返回 domain.ErrForbidden，未登录时返回 domain.ErrUnauthorized。
+	// 停用或封禁的用户没有任何权限
+	Authorize(ctx context.Context, uid int64, perm domain.Permission) error
+	// AuthorizeOwner 资源属于该用户（ownerId == uid）时直接放行，否则按 Authorize 判断
+	AuthorizeOwner(ctx context.Context, uid, ownerId int64, perm domain.Permission) error
+}
@@ -0,0 +1,22 @@
+package input
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// CollectionService 收藏夹业务接口。收藏/取消收藏与收藏数仍由 PostInteractionService 负责，
+// 一篇帖子在同一用户下只属于一个收藏夹，收藏数只计一次
+type CollectionService interface {
+	CreateFolder(ctx context.Context, f domain.CollectionFolder) (int64, error)
+	// UpdateFolder 修改名称、描述与公开状态，只能修改自己的收藏夹
+	UpdateFolder(ctx context.Context, f domain.CollectionFolder) error
+	// DeleteFolder 删除收藏夹，其中的帖子移回默认收藏夹
+	DeleteFolder(ctx context.Context, id, userId int64) error
+	// ListFolders 获取用户的收藏夹，第一个是默认收藏夹
+	ListFolders(ctx context.Context, userId int64) ([]domain.CollectionFolder, error)
+	// CollectTo 收藏帖子到指定收藏夹，已收藏时移动到该收藏夹
+	CollectTo(ctx context.Context, postId, userId, folderId int64) error
+	// ListPosts 分页获取收藏夹中的已发布帖子，非公开收藏夹只有本人可见
+	ListPosts(ctx context.Context, ownerId, folderId, viewerId int64, page, pageSize int) ([]domain.Post, int64, error)
+}
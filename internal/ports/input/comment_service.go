@@ -0,0 +1,18 @@
+package input
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// CommentService 评论业务接口
+type CommentService interface {
+	// Create 发表评论，ParentId 不为 0 时表示回复该评论
+	Create(ctx context.Context, c domain.Comment) (int64, error)
+	Edit(ctx context.Context, id, userId int64, content string) error
+	// Delete 删除评论，评论者本人或帖子作者可删除
+	Delete(ctx context.Context, id, userId int64) error
+	// ListByPost 游标分页获取根评论，附带回复数与前几条回复预览
+	ListByPost(ctx context.Context, postId, cursor int64, limit int) ([]domain.Comment, error)
+	ListReplies(ctx context.Context, rootId, cursor int64, limit int) ([]domain.Comment, error)
+}
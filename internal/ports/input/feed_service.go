@@ -0,0 +1,18 @@
+package input
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// FeedService 关注流业务接口。粉丝数低于阈值的作者发帖时推送到粉丝的收件箱（写扩散），
+// 达到阈值的作者不再推送，由粉丝读关注流时拉取（读扩散）
+type FeedService interface {
+	// FanOut 处理帖子上线事件，把帖子推送到作者粉丝的收件箱；帖子已下线时忽略
+	FanOut(ctx context.Context, postId int64) error
+	// Backfill 关注作者后把作者最近的帖子补进粉丝的收件箱
+	Backfill(ctx context.Context, followerId, followeeId int64) error
+	// List 按发布时间倒序获取关注流，发布时间相同时按帖子ID倒序；
+	// cursor 为上一页返回的 NextCursor，零值表示第一页
+	List(ctx context.Context, userId int64, cursor domain.FeedCursor, limit int) (domain.FeedPage, error)
+}
@@ -0,0 +1,19 @@
+package input
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// FollowService 关注业务接口
+type FollowService interface {
+	// Follow 关注用户，重复关注视为成功；不能关注自己
+	Follow(ctx context.Context, followerId, followeeId int64) error
+	// Unfollow 取消关注，未关注时视为成功
+	Unfollow(ctx context.Context, followerId, followeeId int64) error
+	// GetStats 获取用户的粉丝数与关注数，viewerId 不为 0 时同时返回其是否已关注该用户
+	GetStats(ctx context.Context, userId, viewerId int64) (domain.FollowStats, error)
+	// ListFollowers/ListFollowees 按关注时间倒序游标分页，cursor 为上一页最后一条的关注时间，0 表示第一页
+	ListFollowers(ctx context.Context, userId, cursor int64, limit int) ([]domain.FollowRelation, error)
+	ListFollowees(ctx context.Context, userId, cursor int64, limit int) ([]domain.FollowRelation, error)
+}
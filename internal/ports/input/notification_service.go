@@ -0,0 +1,19 @@
+package input
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// NotificationService 站内通知业务接口
+type NotificationService interface {
+	// Handle 处理一条帖子事件，为帖子作者生成或聚合通知；不产生通知的事件直接忽略。
+	// 以 EventId 保证幂等，同一事件重复投递只记一次
+	Handle(ctx context.Context, event domain.PostStatsEvent) error
+	// UnreadCount 未读通知数（聚合后的条数）
+	UnreadCount(ctx context.Context, userId int64) (int64, error)
+	// List 按最近更新时间倒序游标分页，cursor 为上一页最后一条的更新时间，0 表示第一页
+	List(ctx context.Context, userId, cursor int64, limit int, unreadOnly bool) ([]domain.Notification, error)
+	MarkRead(ctx context.Context, userId, id int64) error
+	MarkAllRead(ctx context.Context, userId int64) error
+}
@@ -0,0 +1,19 @@
+package input
+
+import (
+	"context"
+	"time"
+	"webook/internal/domain"
+)
+
+// PersonalAccessTokenService 个人访问令牌业务接口
+type PersonalAccessTokenService interface {
+	// Create 创建令牌，expiresIn 为 0 表示永不过期；明文令牌只在这里返回一次
+	Create(ctx context.Context, uid int64, name string, scopes []domain.TokenScope, expiresIn time.Duration) (domain.PersonalAccessToken, string, error)
+	// List 用户的全部令牌（含已过期），最新创建的在前
+	List(ctx context.Context, uid int64) ([]domain.PersonalAccessToken, error)
+	// Revoke 删除用户的一个令牌，立即失效
+	Revoke(ctx context.Context, uid, id int64) error
+	// Authenticate 校验明文令牌并记录使用时间；令牌不存在或已过期时返回 domain.ErrUnauthorized
+	Authenticate(ctx context.Context, token string) (domain.PersonalAccessToken, error)
+}
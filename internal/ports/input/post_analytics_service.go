@@ -0,0 +1,14 @@
+package input
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// PostAnalyticsService 作者数据分析业务接口（按天统计，仅作者本人可用）
+type PostAnalyticsService interface {
+	// GetDaily 获取单篇帖子在区间内每天的互动数，没有数据的日期补 0
+	GetDaily(ctx context.Context, postId, uid int64, r domain.StatsRange) ([]domain.PostDailyStats, error)
+	// GetDashboard 汇总作者所有帖子在区间内的互动数
+	GetDashboard(ctx context.Context, authorId int64, r domain.StatsRange) (domain.AuthorDashboard, error)
+}
@@ -0,0 +1,12 @@
+package input
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// PostRankService 热门帖子榜单业务接口
+type PostRankService interface {
+	// ListHot 分页获取指定时间窗口的热榜，已下线的帖子会被跳过
+	ListHot(ctx context.Context, window domain.HotWindow, page, pageSize int) ([]domain.HotPost, int64, error)
+}
@@ -0,0 +1,14 @@
+package input
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// PostRevisionService 帖子修订历史业务接口（仅作者本人可用）
+type PostRevisionService interface {
+	List(ctx context.Context, postId, uid int64, page, pageSize int) ([]domain.PostRevision, int64, error)
+	Get(ctx context.Context, postId, uid, version int64) (domain.PostRevision, error)
+	Diff(ctx context.Context, postId, uid, from, to int64) ([]domain.DiffLine, error)
+	Restore(ctx context.Context, postId, uid, version int64) error
+}
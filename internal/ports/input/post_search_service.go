@@ -0,0 +1,12 @@
+package input
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// PostSearchService 已发布帖子全文检索业务接口
+type PostSearchService interface {
+	// Search 按相关度分页检索，boost 为 true 时结合点赞/收藏/阅读数调整排序
+	Search(ctx context.Context, query string, page, pageSize int, boost bool) ([]domain.PostSearchHit, int64, error)
+}
@@ -13,5 +13,13 @@ type PostService interface {
 	GetPublishedById(ctx context.Context, id int64) (domain.Post, error)
 	ListByAuthor(ctx context.Context, uid int64, page, pageSize int) ([]domain.Post, int64, error)
 	ListPublished(ctx context.Context, page, pageSize int) ([]domain.Post, int64, error)
+	ListPublishedByTag(ctx context.Context, tag string, page, pageSize int) ([]domain.Post, int64, error)
+	// ListLikedBy 获取用户点赞的已发布帖子，按点赞时间倒序
+	ListLikedBy(ctx context.Context, uid int64, page, pageSize int) ([]domain.Post, int64, error)
+	// ListCollectedBy 获取用户收藏的已发布帖子（所有收藏夹），按收藏时间倒序
+	ListCollectedBy(ctx context.Context, uid int64, page, pageSize int) ([]domain.Post, int64, error)
 	Delete(ctx context.Context, id int64, uid int64) error
+	Schedule(ctx context.Context, id int64, uid int64, scheduledAt int64) error
+	Reschedule(ctx context.Context, id int64, uid int64, scheduledAt int64) error
+	CancelSchedule(ctx context.Context, id int64, uid int64) error
 }
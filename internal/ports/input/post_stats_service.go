@@ -11,7 +11,9 @@ type PostInteractionService interface {
 	Unlike(ctx context.Context, postId, userId int64) error
 	Collect(ctx context.Context, postId, userId int64) error
 	Uncollect(ctx context.Context, postId, userId int64) error
-	Read(ctx context.Context, postId, userId int64, ip, userAgent string) error
+	// Read counts a read and, for logged-in users, records reading history. progress
+	// is a percentage, or domain.ReadProgressUnknown if the client did not report it.
+	Read(ctx context.Context, postId, userId int64, ip, userAgent string, progress int) error
 
 	GetStats(ctx context.Context, postId, userId int64) (domain.PostStats, domain.PostUserStats, error)
 	GetStatsBatch(ctx context.Context, postIds []int64, userId int64) (map[int64]domain.PostStats, map[int64]domain.PostUserStats, error)
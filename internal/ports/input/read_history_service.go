@@ -0,0 +1,15 @@
+package input
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// ReadHistoryService 阅读历史业务接口，记录由 PostInteractionService.Read 写入
+type ReadHistoryService interface {
+	// List 按阅读时间倒序游标分页获取阅读历史，cursor 为上一页最后一条的阅读时间与记录ID，零值表示第一页；
+	// unfinished 为 true 时只返回未读完的记录（继续阅读）
+	List(ctx context.Context, userId int64, cursor domain.ReadHistoryCursor, limit int, unfinished bool) ([]domain.ReadHistory, error)
+	Delete(ctx context.Context, userId, postId int64) error
+	Clear(ctx context.Context, userId int64) error
+}
@@ -0,0 +1,20 @@
+package input
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// StreamService 实时推送业务接口，事件经 Redis 在各实例间广播后推给 SSE 连接
+type StreamService interface {
+	// PublishStats 推送帖子的最新计数，由统计事件消费者在计数变化后调用
+	PublishStats(ctx context.Context, postId int64) error
+	// Subscribe 打开用户的实时事件流：订阅帖子 postIds 的计数变化和用户自己的新通知，
+	// ctx 结束时关闭。lastEventId 大于 0 时先补发该 ID 之后错过的通知
+	Subscribe(ctx context.Context, userId, lastEventId int64, postIds []int64) (<-chan domain.StreamEvent, error)
+	// IssueTicket 为用户签发一次性的连接票据。浏览器 EventSource 无法设置请求头，
+	// 用票据代替 URL 中的访问令牌，避免令牌出现在访问日志里
+	IssueTicket(ctx context.Context, userId int64) (string, error)
+	// RedeemTicket 核销票据并返回签发时的用户，票据不存在、已过期或已使用时返回 domain.ErrInvalidStreamTicket
+	RedeemTicket(ctx context.Context, ticket string) (int64, error)
+}
@@ -0,0 +1,15 @@
+package input
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// TagService 标签业务接口
+type TagService interface {
+	Create(ctx context.Context, name string) (int64, error)
+	Rename(ctx context.Context, id int64, name string) error
+	Delete(ctx context.Context, id int64) error
+	GetById(ctx context.Context, id int64) (domain.Tag, error)
+	ListWithPostCount(ctx context.Context) ([]domain.Tag, error)
+}
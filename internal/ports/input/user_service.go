@@ -8,7 +8,17 @@ import (
 // UserService 用户业务接口
 type UserService interface {
 	SignUp(ctx context.Context, u domain.User) error
-	Login(ctx context.Context, email, password string) (domain.User, error)
+	// Login 校验邮箱密码，按邮箱和 IP 统计连续失败次数，超过阈值后锁定一段时间，
+	// 锁定期间返回 *domain.LoginLockedError；每次尝试都写入审计日志
+	Login(ctx context.Context, email, password string, client domain.LoginClient) (domain.User, error)
 	Profile(ctx context.Context, id int64) (domain.User, error)
 	UpdatePassword(ctx context.Context, id int64, old, new string) error
+	// UpdateProfile 校验并覆盖用户资料（昵称、头像、简介、生日），不合法时返回 ErrInvalidProfile
+	UpdateProfile(ctx context.Context, u domain.User) error
+	// PublicProfile 用户公开主页，含已发布帖子数和获赞总数
+	PublicProfile(ctx context.Context, id int64) (domain.UserPublicProfile, error)
+	// Unlock 解除该用户邮箱的登录锁定并清空失败计数
+	Unlock(ctx context.Context, id int64) error
+	// LoginAttempts 该用户邮箱最近的登录尝试，最新的在前
+	LoginAttempts(ctx context.Context, id int64, limit int) ([]domain.LoginAttempt, error)
 }
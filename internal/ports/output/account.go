@@ -0,0 +1,25 @@
+package output
+
+import (
+	"context"
+	"time"
+	"webook/internal/domain"
+)
+
+// Mailer sends account emails such as verification and password reset links.
+type Mailer interface {
+	Send(ctx context.Context, mail domain.Mail) error
+}
+
+// AccountTokenStore keeps the single-use tokens sent in account emails.
+type AccountTokenStore interface {
+	// Save binds the token to the user until ttl elapses.
+	Save(ctx context.Context, purpose domain.AccountTokenPurpose, token string, userId int64, ttl time.Duration) error
+	// Consume returns the user bound to the token and deletes it, so a token
+	// works only once. It returns domain.ErrInvalidAccountToken if the token is
+	// unknown or expired.
+	Consume(ctx context.Context, purpose domain.AccountTokenPurpose, token string) (int64, error)
+	// Throttle reports whether a mail of this purpose may be sent to the user
+	// now, allowing one per interval.
+	Throttle(ctx context.Context, purpose domain.AccountTokenPurpose, userId int64, interval time.Duration) (bool, error)
+}
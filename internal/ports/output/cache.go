@@ -16,9 +16,22 @@ type PostCache interface {
 	Get(ctx context.Context, id int64) (domain.Post, error)
 	Set(ctx context.Context, p domain.Post) error
 	Delete(ctx context.Context, id int64) error
+	TryLock(ctx context.Context, key string, ttl time.Duration) (bool, error)
 }
 
 type TokenBlacklist interface {
 	Add(ctx context.Context, ssid string, expiration time.Duration) error
 	IsBlacklisted(ctx context.Context, ssid string) (bool, error)
+	// Consume blacklists the ssid and reports whether it was not blacklisted
+	// before. Only one of several concurrent calls for the same ssid gets true.
+	Consume(ctx context.Context, ssid string, expiration time.Duration) (bool, error)
+	// RevokeFamily revokes every refresh token of the family, including ones
+	// rotated later. expiration should cover the refresh token lifetime.
+	RevokeFamily(ctx context.Context, family string, expiration time.Duration) error
+	IsFamilyRevoked(ctx context.Context, family string) (bool, error)
+	// RevokeUser revokes every refresh token of the user issued before now.
+	// expiration should cover the refresh token lifetime.
+	RevokeUser(ctx context.Context, userId int64, expiration time.Duration) error
+	// UserRevokedAt returns the unix seconds of the last RevokeUser, 0 if none.
+	UserRevokedAt(ctx context.Context, userId int64) (int64, error)
 }
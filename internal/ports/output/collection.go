@@ -0,0 +1,18 @@
+package output
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// CollectionFolderRepository stores collection folders. Which folder a post is
+// in is kept on the collect relation, see PostCollectRepository.
+type CollectionFolderRepository interface {
+	Create(ctx context.Context, f domain.CollectionFolder) (int64, error)
+	// Update changes name, description and visibility of a folder owned by f.UserId.
+	Update(ctx context.Context, f domain.CollectionFolder) error
+	// Delete removes a folder owned by userId and moves its posts to the default folder.
+	Delete(ctx context.Context, id, userId int64) error
+	FindById(ctx context.Context, id int64) (domain.CollectionFolder, error)
+	FindByUser(ctx context.Context, userId int64) ([]domain.CollectionFolder, error)
+}
@@ -0,0 +1,20 @@
+package output
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// CommentRepository stores comments. Create and Delete also record the matching
+// comment/uncomment stats events in the outbox within the same transaction.
+type CommentRepository interface {
+	Create(ctx context.Context, c domain.Comment) (int64, error)
+	FindById(ctx context.Context, id int64) (domain.Comment, error)
+	UpdateContent(ctx context.Context, id, userId int64, content string) error
+	// Delete removes the comment, and all of its replies if it is a root comment.
+	Delete(ctx context.Context, id int64) error
+	FindRoots(ctx context.Context, postId, cursor int64, limit int) ([]domain.Comment, error)
+	FindReplies(ctx context.Context, rootId, cursor int64, limit int) ([]domain.Comment, error)
+	FindRepliesPreview(ctx context.Context, rootIds []int64, n int) (map[int64][]domain.Comment, error)
+	CountReplies(ctx context.Context, rootIds []int64) (map[int64]int64, error)
+}
@@ -0,0 +1,16 @@
+package output
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// FeedCache keeps each user's feed inbox for push fan-out.
+type FeedCache interface {
+	// Push adds the items to every user's inbox and trims the inboxes to their
+	// maximum size, dropping the oldest items.
+	Push(ctx context.Context, userIds []int64, items ...domain.FeedItem) error
+	// Inbox returns the items after cursor (the zero cursor for the newest), newest
+	// first, ties in publish time broken by post id.
+	Inbox(ctx context.Context, userId int64, cursor domain.FeedCursor, limit int) ([]domain.FeedItem, error)
+}
@@ -0,0 +1,26 @@
+package output
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// FollowRepository stores the follow graph and the per-user follow counters.
+type FollowRepository interface {
+	// SetStatus follows (followed=true) or unfollows and keeps both users'
+	// counters in step. It reports whether the relation actually changed.
+	SetStatus(ctx context.Context, followerId, followeeId int64, followed bool) (bool, error)
+	IsFollowing(ctx context.Context, followerId, followeeId int64) (bool, error)
+	// ListFollowees/ListFollowers page by follow time, most recent first.
+	// cursor is the Ctime of the previous page's last relation, 0 for the first page.
+	ListFollowees(ctx context.Context, userId, cursor int64, limit int) ([]domain.FollowRelation, error)
+	ListFollowers(ctx context.Context, userId, cursor int64, limit int) ([]domain.FollowRelation, error)
+	// FindFolloweeIds returns at most limit followed user ids, most recent first.
+	FindFolloweeIds(ctx context.Context, userId int64, limit int) ([]int64, error)
+	// FindFollowerIds walks the followers in ascending id order, starting after afterId.
+	FindFollowerIds(ctx context.Context, followeeId, afterId int64, limit int) ([]int64, error)
+	// GetStats returns zero counters for users nobody has followed yet.
+	GetStats(ctx context.Context, userId int64) (domain.FollowStats, error)
+	// FollowerCnts maps user id to follower count; users without followers are omitted.
+	FollowerCnts(ctx context.Context, userIds []int64) (map[int64]int64, error)
+}
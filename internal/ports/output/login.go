@@ -0,0 +1,28 @@
+package output
+
+import (
+	"context"
+	"time"
+	"webook/internal/domain"
+)
+
+// LoginAttemptCache counts failed logins and holds lockouts, separately for
+// each scope (email or IP) and value.
+type LoginAttemptCache interface {
+	// LockedFor returns how long the value stays locked, 0 if it is not locked.
+	LockedFor(ctx context.Context, scope domain.LoginScope, value string) (time.Duration, error)
+	// IncrFailure bumps the failure count and returns it. The count expires
+	// window after the last failure.
+	IncrFailure(ctx context.Context, scope domain.LoginScope, value string, window time.Duration) (int64, error)
+	// Lock rejects logins for the value until d elapses.
+	Lock(ctx context.Context, scope domain.LoginScope, value string, d time.Duration) error
+	// Reset clears both the failure count and the lockout.
+	Reset(ctx context.Context, scope domain.LoginScope, value string) error
+}
+
+// LoginAuditRepository is the append-only log of login attempts.
+type LoginAuditRepository interface {
+	Create(ctx context.Context, a domain.LoginAttempt) error
+	// FindByEmail returns the attempts for the email, most recent first.
+	FindByEmail(ctx context.Context, email string, limit int) ([]domain.LoginAttempt, error)
+}
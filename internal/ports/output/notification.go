@@ -0,0 +1,23 @@
+package output
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// NotificationRepository stores per-recipient notifications.
+type NotificationRepository interface {
+	// Record folds the actor into the recipient's unread notification for the
+	// same kind and post, creating one if needed, and returns the result. It is
+	// idempotent by eventId and reports false when the event was already recorded.
+	Record(ctx context.Context, eventId string, n domain.Notification, actorId int64) (domain.Notification, bool, error)
+	// FindByRecipient returns notifications updated before cursor (0 for the
+	// first page), most recently updated first.
+	FindByRecipient(ctx context.Context, recipientId, cursor int64, limit int, unreadOnly bool) ([]domain.Notification, error)
+	CountUnread(ctx context.Context, recipientId int64) (int64, error)
+	MarkRead(ctx context.Context, id, recipientId int64) error
+	MarkAllRead(ctx context.Context, recipientId int64) error
+	// DeleteEventsBefore purges the idempotency records of events recorded
+	// before the given time, at most limit rows per call.
+	DeleteEventsBefore(ctx context.Context, before int64, limit int) (int64, error)
+}
@@ -0,0 +1,22 @@
+package output
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// PersonalAccessTokenRepository stores personal access tokens by the hash of
+// the token; the plaintext is never stored.
+type PersonalAccessTokenRepository interface {
+	// Create returns the token with Id and Ctime filled in.
+	Create(ctx context.Context, t domain.PersonalAccessToken) (domain.PersonalAccessToken, error)
+	// FindByHash returns domain.ErrAccessTokenNotFound if no token has this hash.
+	FindByHash(ctx context.Context, hash string) (domain.PersonalAccessToken, error)
+	// FindByUser returns the user's tokens, newest first.
+	FindByUser(ctx context.Context, userId int64) ([]domain.PersonalAccessToken, error)
+	// Delete returns domain.ErrAccessTokenNotFound if the user has no such token.
+	Delete(ctx context.Context, userId, id int64) error
+	DeleteByUser(ctx context.Context, userId int64) error
+	// UpdateLastUsed records a use of the token at lastUsed (unix millis).
+	UpdateLastUsed(ctx context.Context, id, lastUsed int64) error
+}
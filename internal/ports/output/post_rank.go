@@ -0,0 +1,25 @@
+package output
+
+import (
+	"context"
+	"time"
+	"webook/internal/domain"
+)
+
+// PostRankCache keeps the hot post ranking. Scores are accumulated in per-day
+// buckets and an all-time set, and every window is materialised into a top-N
+// set that the read path pages through.
+type PostRankCache interface {
+	// IncrScore adds delta to the all-time score and to the bucket of the day of at.
+	IncrScore(ctx context.Context, postId int64, delta float64, at time.Time) error
+	// ReplaceTotal atomically replaces the all-time scores.
+	ReplaceTotal(ctx context.Context, scores []domain.PostScore) error
+	// RefreshWindow rebuilds the top-N set of window. dayWeights[i] scales the
+	// bucket of the day i days before now; an empty dayWeights copies the all-time scores.
+	RefreshWindow(ctx context.Context, window domain.HotWindow, now time.Time, dayWeights []float64, topN int) error
+	// Remove drops posts from the all-time set, every window and the day buckets,
+	// so that posts taken offline do not come back on the next refresh.
+	Remove(ctx context.Context, postIds ...int64) error
+	// Top returns a page of the materialised window and its size.
+	Top(ctx context.Context, window domain.HotWindow, offset, limit int) ([]domain.PostScore, int64, error)
+}
@@ -11,12 +11,46 @@ type PostRepository interface {
 	FindById(ctx context.Context, id int64) (domain.Post, error)
 	FindByAuthor(ctx context.Context, authorId int64, offset, limit int) ([]domain.Post, error)
 	CountByAuthor(ctx context.Context, authorId int64) (int64, error)
+	// Sync publishes the post. It returns ErrPostHidden if a moderator has hidden it.
 	Sync(ctx context.Context, p domain.Post) (int64, error)
+	// SyncStatus changes the status of the post and takes it offline when the new
+	// status is private or hidden. Only hiding is allowed on a hidden post, any
+	// other status returns ErrPostHidden.
 	SyncStatus(ctx context.Context, id int64, authorId int64, status uint8) error
+	// Unhide lifts a moderator hide and leaves the post private to its author.
+	// It returns ErrPostNotHidden if the post does not exist or is not hidden.
+	Unhide(ctx context.Context, id int64) error
+	Schedule(ctx context.Context, id int64, authorId int64, scheduledAt int64) error
+	Reschedule(ctx context.Context, id int64, authorId int64, scheduledAt int64) error
+	CancelSchedule(ctx context.Context, id int64, authorId int64) error
+	FindDueScheduled(ctx context.Context, now int64, limit int) ([]domain.Post, error)
+	// PublishScheduled publishes the current content of a scheduled post whose time
+	// has come. It returns ErrPostNotScheduled if the post was cancelled, rescheduled,
+	// deleted or hidden after it was found due.
+	PublishScheduled(ctx context.Context, id int64, now int64) (domain.Post, error)
 }
 
 type PublishedPostRepository interface {
 	FindById(ctx context.Context, id int64) (domain.Post, error)
+	// FindByIds skips ids that are not published; the order of the result is unspecified.
+	FindByIds(ctx context.Context, ids []int64) ([]domain.Post, error)
 	List(ctx context.Context, offset, limit int) ([]domain.Post, error)
 	Count(ctx context.Context) (int64, error)
+	// ListAfterId walks the published posts in ascending id order, starting after afterId.
+	ListAfterId(ctx context.Context, afterId int64, limit int) ([]domain.Post, error)
+	ListByTag(ctx context.Context, tag string, offset, limit int) ([]domain.Post, error)
+	CountByTag(ctx context.Context, tag string) (int64, error)
+	// ListLikedBy/ListCollectedBy return posts the user likes/collects, most recent first.
+	ListLikedBy(ctx context.Context, userId int64, offset, limit int) ([]domain.Post, error)
+	CountLikedBy(ctx context.Context, userId int64) (int64, error)
+	ListCollectedBy(ctx context.Context, userId int64, offset, limit int) ([]domain.Post, error)
+	CountCollectedBy(ctx context.Context, userId int64) (int64, error)
+	// ListByAuthors returns the authors' posts by first publish time, newest first,
+	// ties broken by id. It returns the posts after cursor, the previous page's last
+	// post; the zero cursor starts from the newest.
+	ListByAuthors(ctx context.Context, authorIds []int64, cursor domain.FeedCursor, limit int) ([]domain.Post, error)
+	CountByAuthor(ctx context.Context, authorId int64) (int64, error)
+	// SumLikesByAuthor returns the total likes of the author's published posts as
+	// last flushed to the database, so it may lag the live counters slightly.
+	SumLikesByAuthor(ctx context.Context, authorId int64) (int64, error)
 }
@@ -0,0 +1,12 @@
+package output
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+type PostRevisionRepository interface {
+	FindByPostId(ctx context.Context, postId int64, offset, limit int) ([]domain.PostRevision, error)
+	CountByPostId(ctx context.Context, postId int64) (int64, error)
+	FindByVersion(ctx context.Context, postId, version int64) (domain.PostRevision, error)
+}
@@ -9,6 +9,12 @@ import (
 type PostStatsRepository interface {
 	FindByPostIds(ctx context.Context, postIds []int64) ([]domain.PostStats, error)
 	Upsert(ctx context.Context, stats []domain.PostStats) error
+
+	// Used by reconciliation, which scans post ids in [from, to) batches.
+	FindByPostRange(ctx context.Context, from, to int64) ([]domain.PostStats, error)
+	MaxPostId(ctx context.Context) (int64, error)
+	// UpdateCounts overwrites LikeCnt/CollectCnt only.
+	UpdateCounts(ctx context.Context, stats domain.PostStats) error
 }
 
 type PostStatsCache interface {
@@ -16,31 +22,103 @@ type PostStatsCache interface {
 	BatchGet(ctx context.Context, postIds []int64) (map[int64]domain.PostStats, error)
 	Set(ctx context.Context, stats domain.PostStats) error
 	BatchSet(ctx context.Context, stats []domain.PostStats) error
+	// SetCountsIfPresent overwrites LikeCnt/CollectCnt of an existing hash and is a no-op otherwise.
+	SetCountsIfPresent(ctx context.Context, stats domain.PostStats) error
 
 	IncrLike(ctx context.Context, postId int64, delta int64) (int64, error)
 	IncrCollect(ctx context.Context, postId int64, delta int64) (int64, error)
 	IncrRead(ctx context.Context, postId int64, delta int64) (int64, error)
+	IncrComment(ctx context.Context, postId int64, delta int64) (int64, error)
 
 	MarkDirty(ctx context.Context, postId int64) error
 	PopDirty(ctx context.Context, count int64) ([]int64, error)
 
+	// IncrDaily buffers a daily stats delta until the flusher takes it.
+	IncrDaily(ctx context.Context, delta domain.PostDailyStats) error
+	// TakeDaily removes and returns up to count buffered daily deltas.
+	TakeDaily(ctx context.Context, count int64) ([]domain.PostDailyStats, error)
+
+	// AddReader records a reader in the lifetime and daily HyperLogLogs of a post.
+	// It returns false without recording anything if the lifetime HyperLogLog is
+	// not in Redis, so the caller can restore it first.
+	AddReader(ctx context.Context, postId int64, reader string, date string) (bool, error)
+	// RestoreReaders loads a lifetime HyperLogLog snapshot unless one is already
+	// present. A nil sketch creates an empty one.
+	RestoreReaders(ctx context.Context, postId int64, sketch []byte) error
+	CountReaders(ctx context.Context, postIds []int64) (map[int64]int64, error)
+	// TakeReaderSnapshots returns snapshots of up to count posts read since the last call.
+	TakeReaderSnapshots(ctx context.Context, count int64) ([]domain.PostReaderSnapshot, error)
+	// RequeueReaderSnapshots marks the posts of failed snapshots for the next call.
+	RequeueReaderSnapshots(ctx context.Context, snaps []domain.PostReaderSnapshot) error
+
 	SetReadDedupe(ctx context.Context, key string, ttl time.Duration) (bool, error)
 	SetEventProcessed(ctx context.Context, eventId string, ttl time.Duration) (bool, error)
+	UnsetEventProcessed(ctx context.Context, eventId string) error
 	TryLock(ctx context.Context, key string, ttl time.Duration) (bool, error)
 }
 
+// PostDailyStatsRepository stores per-day interaction counts.
+type PostDailyStatsRepository interface {
+	// IncrBatch adds the deltas to the stored counters.
+	IncrBatch(ctx context.Context, deltas []domain.PostDailyStats) error
+	// FindByPost returns the days of [from, to] that have data, oldest first.
+	FindByPost(ctx context.Context, postId int64, from, to string) ([]domain.PostDailyStats, error)
+	SumByAuthorPerDay(ctx context.Context, authorId int64, from, to string) ([]domain.PostDailyStats, error)
+	SumByAuthorPerPost(ctx context.Context, authorId int64, from, to string) ([]domain.AuthorPostStats, error)
+}
+
+// PostReaderRepository persists unique reader snapshots.
+type PostReaderRepository interface {
+	// FindSketch returns the last saved lifetime HyperLogLog of a post, nil if none.
+	FindSketch(ctx context.Context, postId int64) ([]byte, error)
+	// SaveSnapshots stores the sketches and the lifetime/daily unique reader counts.
+	SaveSnapshots(ctx context.Context, snaps []domain.PostReaderSnapshot) error
+}
+
+// PostLikeRepository stores like relations. A status change also records the
+// like/unlike stats event in the outbox within the same transaction.
 type PostLikeRepository interface {
 	SetStatus(ctx context.Context, postId, userId int64, status uint8) (bool, error)
 	HasLiked(ctx context.Context, postId, userId int64) (bool, error)
 	FindLikedPostIds(ctx context.Context, postIds []int64, userId int64) (map[int64]bool, error)
+	CountActiveByPostRange(ctx context.Context, from, to int64) (map[int64]int64, error)
 }
 
+// PostCollectRepository stores collect relations. A status change also records the
+// collect/uncollect stats event in the outbox within the same transaction.
+//
+// Newly collected posts go to the default folder.
 type PostCollectRepository interface {
 	SetStatus(ctx context.Context, postId, userId int64, status uint8) (bool, error)
 	HasCollected(ctx context.Context, postId, userId int64) (bool, error)
 	FindCollectedPostIds(ctx context.Context, postIds []int64, userId int64) (map[int64]bool, error)
+	CountActiveByPostRange(ctx context.Context, from, to int64) (map[int64]int64, error)
+
+	// MoveToFolder returns domain.ErrPostNotCollected if the user has not collected the post.
+	MoveToFolder(ctx context.Context, postId, userId, folderId int64) error
+	// CountByFolder returns the number of collected posts per folder id.
+	CountByFolder(ctx context.Context, userId int64) (map[int64]int64, error)
+	// FindPostIdsByFolder returns post ids, most recently collected or moved first.
+	FindPostIdsByFolder(ctx context.Context, userId, folderId int64, offset, limit int) ([]int64, error)
 }
 
 type PostStatsEventPublisher interface {
 	Publish(ctx context.Context, event domain.PostStatsEvent) error
 }
+
+// PostStatsOutboxRepository gives the relay access to undelivered stats events.
+type PostStatsOutboxRepository interface {
+	FindPending(ctx context.Context, now int64, limit int) ([]domain.PostStatsOutboxMessage, error)
+	MarkSent(ctx context.Context, ids []int64) error
+	MarkRetry(ctx context.Context, id int64, nextRetryAt int64, reason string) error
+	MarkFailed(ctx context.Context, id int64, reason string) error
+	DeleteSentBefore(ctx context.Context, before int64, limit int) (int64, error)
+}
+
+// PostStatsDeadLetterQueue lets operators inspect and replay dead-lettered stats events.
+type PostStatsDeadLetterQueue interface {
+	// Peek returns up to limit messages without removing them from the DLQ.
+	Peek(ctx context.Context, limit int) ([]domain.PostStatsDeadLetter, error)
+	// Replay moves up to limit messages back to the main queue with a fresh retry budget.
+	Replay(ctx context.Context, limit int) (int, error)
+}
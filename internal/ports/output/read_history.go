@@ -0,0 +1,19 @@
+package output
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// ReadHistoryRepository stores per-user reading history.
+type ReadHistoryRepository interface {
+	// Record upserts the entry and bumps its read time. A Progress of
+	// domain.ReadProgressUnknown keeps the stored progress.
+	Record(ctx context.Context, h domain.ReadHistory) error
+	// FindByUser returns entries of published posts after cursor (the zero
+	// cursor for the first page), ordered by read time then id, most recent
+	// first. Post is not filled.
+	FindByUser(ctx context.Context, userId int64, cursor domain.ReadHistoryCursor, limit int, unfinished bool) ([]domain.ReadHistory, error)
+	Delete(ctx context.Context, userId, postId int64) error
+	DeleteByUser(ctx context.Context, userId int64) error
+}
@@ -0,0 +1,18 @@
+package output
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// SearchService is a full-text index over published posts.
+type SearchService interface {
+	Index(ctx context.Context, p domain.Post) error
+	Remove(ctx context.Context, postId int64) error
+	// Rebuild replaces the whole index with the posts returned by load. Index and
+	// Remove calls made while load runs are replayed on top of the new index, so
+	// changes that the load read too early are not lost.
+	Rebuild(ctx context.Context, load func(ctx context.Context) ([]domain.Post, error)) error
+	// Search returns at most limit hits ordered by relevance, plus the total number of matches.
+	Search(ctx context.Context, query string, limit int) ([]domain.PostSearchHit, int64, error)
+}
@@ -0,0 +1,21 @@
+package output
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+// SessionRepository stores one record per login session so users can see
+// where they are logged in. Revocation itself lives in TokenBlacklist; the
+// records are for display only.
+type SessionRepository interface {
+	// Upsert creates the session or records another use of it, updating the
+	// user agent, IP and last used time. Ctime is kept on update.
+	Upsert(ctx context.Context, s domain.Session) error
+	// FindByUser returns the sessions used at or after since (unix millis),
+	// most recently used first.
+	FindByUser(ctx context.Context, userId, since int64) ([]domain.Session, error)
+	// Delete returns domain.ErrSessionNotFound if the user has no such session.
+	Delete(ctx context.Context, userId int64, id string) error
+	DeleteByUser(ctx context.Context, userId int64) error
+}
@@ -0,0 +1,32 @@
+package output
+
+import (
+	"context"
+	"time"
+	"webook/internal/domain"
+)
+
+// StreamBroker broadcasts realtime events to the SSE connections of every instance.
+type StreamBroker interface {
+	// Publish assigns the event a new id and broadcasts it. Events addressed to a
+	// user are also kept for a short while, so a reconnecting client can replay them.
+	Publish(ctx context.Context, event domain.StreamEvent) error
+	// Subscribe returns every event published from now on, by any instance. The
+	// channel is closed once ctx is done. Events are dropped for a subscriber that
+	// falls too far behind.
+	Subscribe(ctx context.Context) (<-chan domain.StreamEvent, error)
+	// Replay returns the kept events of a user with an id greater than afterId,
+	// oldest first.
+	Replay(ctx context.Context, userId, afterId int64) ([]domain.StreamEvent, error)
+}
+
+// StreamTicketStore keeps the single-use tickets that open a stream connection
+// in place of an access token in the URL.
+type StreamTicketStore interface {
+	// Save binds the ticket to the user until ttl elapses.
+	Save(ctx context.Context, ticket string, userId int64, ttl time.Duration) error
+	// Consume returns the user bound to the ticket and deletes it, so a ticket
+	// works only once. It returns domain.ErrInvalidStreamTicket if the ticket is
+	// unknown or expired.
+	Consume(ctx context.Context, ticket string) (int64, error)
+}
@@ -0,0 +1,14 @@
+package output
+
+import (
+	"context"
+	"webook/internal/domain"
+)
+
+type TagRepository interface {
+	Create(ctx context.Context, t domain.Tag) (int64, error)
+	Update(ctx context.Context, t domain.Tag) error
+	Delete(ctx context.Context, id int64) error
+	FindById(ctx context.Context, id int64) (domain.Tag, error)
+	ListWithPostCount(ctx context.Context) ([]domain.Tag, error)
+}
@@ -3,16 +3,43 @@ package output
 import "time"
 
 type RefreshClaims struct {
-	UserId int64
-	SSid   string
+	UserId   int64
+	SSid     string
+	Family   string // shared by all refresh tokens rotated from the same login
+	IssuedAt int64  // unix seconds
+}
+
+type AccessClaims struct {
+	UserId    int64
+	SessionId string // family of the refresh token issued alongside
+	IssuedAt  int64  // unix seconds
 }
 
 type TokenService interface {
-	GenerateTokenPair(userId int64, userAgent, ssid string, accessExpire, refreshExpire time.Duration) (accessToken, refreshToken string, err error)
-	GenerateAccessToken(userId int64, userAgent string, expireTime time.Duration) (string, error)
+	GenerateTokenPair(userId int64, userAgent, ssid, family string, accessExpire, refreshExpire time.Duration) (accessToken, refreshToken string, err error)
+	GenerateAccessToken(userId int64, userAgent, sessionId string, expireTime time.Duration) (string, error)
 	ParseRefreshToken(tokenString string) (RefreshClaims, error)
 }
 
 type AccessTokenVerifier interface {
-	Verify(tokenString, userAgent string) (userId int64, err error)
+	Verify(tokenString, userAgent string) (AccessClaims, error)
+}
+
+// JSONWebKey is a public key in JWK form (RFC 7517). RSA keys set N and E,
+// Ed25519 keys set Crv and X.
+type JSONWebKey struct {
+	Kty string
+	Kid string
+	Alg string
+	N   string
+	E   string
+	Crv string
+	X   string
+}
+
+// PublicKeyProvider exposes the keys that verify access tokens, for other
+// services to verify tokens without sharing a secret.
+type PublicKeyProvider interface {
+	// PublicKeys is empty when tokens are signed with a shared secret.
+	PublicKeys() []JSONWebKey
 }
@@ -10,4 +10,14 @@ type UserRepository interface {
 	FindByEmail(ctx context.Context, email string) (domain.User, error)
 	FindById(ctx context.Context, id int64) (domain.User, error)
 	Update(ctx context.Context, u domain.User) error
+	// UpdateProfile overwrites the profile fields (nickname, avatar, bio, birthday) only.
+	UpdateProfile(ctx context.Context, u domain.User) error
+	MarkEmailVerified(ctx context.Context, id int64) error
+	// List returns users newest first.
+	List(ctx context.Context, offset, limit int) ([]domain.User, error)
+	Count(ctx context.Context) (int64, error)
+	// UpdateStatus returns domain.ErrUserNotFound if there is no such user.
+	UpdateStatus(ctx context.Context, id int64, status domain.UserStatus) error
+	// UpdateRole returns domain.ErrUserNotFound if there is no such user.
+	UpdateRole(ctx context.Context, id int64, role domain.Role) error
 }